@@ -11,12 +11,15 @@ import (
 
 	"github.com/hydr0g3nz/poc_pos_restuarant/config"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/controller"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	mockAdapter "github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/mock"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	gormRepo "github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm"
 	migrater "github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/migration"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/service"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/infrastructure"
 )
 
@@ -45,6 +48,8 @@ func main() {
 	// Setup cache
 	cache := infrastructure.NewRedisClient(cfg.Cache)
 	defer cache.Close()
+	rateLimiter := infrastructure.NewRedisRateLimiter(cache)
+	idempotencyStore := infrastructure.NewRedisIdempotencyStore(cache)
 	// printerService, err := infrastructure.NewPrinterService(cfg.Printer.URL)
 	// if err != nil {
 	// 	logger.Fatal("Failed to connect to printer service", "error", err)
@@ -53,11 +58,20 @@ func main() {
 	printerMock := mockAdapter.NewPrinterService()
 	// infra
 	qrcodeGenerator := infrastructure.NewQRCodeService()
+	tokenService := infrastructure.NewJWTService(cfg.JWT.Secret, time.Duration(cfg.JWT.AccessExpiration)*time.Minute)
+	mailSender, err := infrastructure.NewMailSender(cfg.Mail, logger)
+	if err != nil {
+		logger.Fatal("Failed to create mail sender", "error", err)
+	}
 
 	errorPresenter := presenter.NewErrorPresenter(logger)
 	// Setup repositories
 	repoContainer := gormRepo.NewRepositoryContainer(db)
 	userRepo := repoContainer.UserRepository()
+	refreshTokenRepo := repoContainer.RefreshTokenRepository()
+	userTokenRepo := repoContainer.UserTokenRepository()
+	terminalRepo := repoContainer.TerminalRepository()
+	apiKeyRepo := repoContainer.APIKeyRepository()
 	categoryRepo := repoContainer.CategoryRepository()
 	menuItemRepo := repoContainer.MenuItemRepository()
 	tableRepo := repoContainer.TableRepository()
@@ -67,8 +81,13 @@ func main() {
 	revenueRepo := repoContainer.RevenueRepository() // New revenue repository
 	kitchenStationRepo := repoContainer.KitchenStationRepository()
 	orderItemOptionRepo := repoContainer.OrderItemOptionRepository()
+	orderStatusRepo := repoContainer.OrderStatusHistoryRepository()
 	menuOptionRepo := repoContainer.MenuOptionRepository()
 	optionValueRepo := repoContainer.OptionValueRepository()
+	auditLogRepo := repoContainer.AuditLogRepository()
+	approvalRepo := repoContainer.ApprovalRepository()
+	shiftRepo := repoContainer.ShiftRepository()
+	promotionRepo := repoContainer.PromotionRepository()
 	txManager := repoContainer.TxManager()
 	// menuItemOptionRepo := repoContainer.MenuItemOptionRepository()
 
@@ -81,48 +100,78 @@ func main() {
 		optionValueRepo,
 		tableRepo,
 		menuItemRepo,
+		promotionRepo,
+		pricingPolicy(cfg.Pricing),
 	)
 	qrCodeService := service.NewQRCodeService(cfg.App.QRcodeURL, qrcodeGenerator, orderRepo) // New QR code service (pass in "tableRepo)
 	// revenueService := service.NewRevenueService(revenueRepo, paymentRepo, orderRepo) // New revenue service
 
 	// Setup use cases
-	userUsecase := usecase.NewUserUsecase(userRepo, logger, cfg)
+	userUsecase := usecase.NewUserUsecase(userRepo, refreshTokenRepo, terminalRepo, userTokenRepo, tokenService, cache, rateLimiter, mailSender, txManager, logger, cfg)
+	if cfg.App.OwnerEmail != "" {
+		if err := userUsecase.EnsureOwner(context.Background(), cfg.App.OwnerEmail, cfg.App.OwnerPassword); err != nil {
+			logger.Fatal("Failed to create owner account", "error", err)
+		}
+	}
+	terminalUsecase := usecase.NewTerminalUsecase(terminalRepo, refreshTokenRepo, logger, cfg)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo, logger, cfg)
+	promotionUsecase := usecase.NewPromotionUsecase(promotionRepo, logger, cfg)
 	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, logger, cfg)
-	menuItemUsecase := usecase.NewMenuItemUsecase(menuItemRepo, categoryRepo, kitchenStationRepo, logger, cfg)
+	menuItemUsecase := usecase.NewMenuItemUsecase(menuItemRepo, categoryRepo, kitchenStationRepo, auditLogRepo, txManager, logger, cfg)
 	tableUsecase := usecase.NewTableUsecase(tableRepo, logger, cfg)
 	orderItemOptionUsecase := usecase.NewOrderItemOptionUsecase(orderItemOptionRepo, orderItemRepo, menuOptionRepo, optionValueRepo, orderRepo, logger, cfg)
+	approvalUsecase := usecase.NewApprovalUsecase(approvalRepo, userRepo, orderRepo, orderItemRepo, paymentRepo, cache, rateLimiter, logger, cfg)
 	orderUsecase := usecase.NewOrderUsecase(
 		orderItemOptionUsecase,
+		approvalUsecase,
 		orderRepo,
 		orderItemRepo,
 		tableRepo,
 		menuItemRepo,
+		paymentRepo,
+		orderStatusRepo,
+		promotionRepo,
 		orderService,
 		qrCodeService,
 		printerMock,
 		// printerService,
+		auditLogRepo,
 		txManager,
 		logger, cfg)
-	paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, orderRepo, orderService, logger, cfg)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, orderRepo, shiftRepo, orderService, printerMock, approvalUsecase, auditLogRepo, txManager, logger, cfg)
 	// qrCodeUsecase := usecase.NewQRCodeUsecase(tableRepo, orderRepo, qrCodeService, orderUsecase, logger, cfg)
 	revenueUsecase := usecase.NewRevenueUsecase(revenueRepo, paymentRepo, orderRepo, logger, cfg) // New revenue usecase
 	kitchenUsecase := usecase.NewKitchenUsecase(orderItemRepo, orderRepo, menuItemRepo, tableRepo, orderItemOptionRepo, menuOptionRepo, optionValueRepo, logger, cfg)
+	auditUsecase := usecase.NewAuditUsecase(auditLogRepo, logger, cfg)
+	shiftUsecase := usecase.NewShiftUsecase(shiftRepo, paymentRepo, orderRepo, txManager, logger, cfg)
 	kitchenStationUsecase := usecase.NewKitchenStationUsecase(kitchenStationRepo, logger, cfg)
 	// menuOptionUsecase := usecase.NewMenuOptionUsecase(menuOptionRepo, logger, cfg)
 	menuWithOptionsUsecase := usecase.NewMenuWithOptionsUsecase(repoContainer)
 	menuOptionMgmtUsecase := usecase.NewMenuOptionManagementUsecase(repoContainer)
+	// Setup middlewares
+	authMiddleware := middleware.NewAuthMiddleware(userUsecase, apiKeyUsecase, errorPresenter)
+	customerMiddleware := middleware.NewCustomerMiddleware(orderUsecase, errorPresenter)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(rateLimiter, logger, errorPresenter)
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(idempotencyStore, cfg.Idempotency, logger, errorPresenter)
+
 	// Setup controllers
-	userController := controller.NewUserController(userUsecase, errorPresenter)
-	categoryController := controller.NewCategoryController(categoryUsecase, errorPresenter)
-	menuItemController := controller.NewMenuItemController(menuItemUsecase, errorPresenter)
-	tableController := controller.NewTableController(tableUsecase, errorPresenter)
-	orderController := controller.NewOrderController(orderUsecase, errorPresenter)
-	paymentController := controller.NewPaymentController(paymentUsecase, errorPresenter)
-	revenueController := controller.NewRevenueController(revenueUsecase, errorPresenter) // New revenue controller
-	kitchenController := controller.NewKitchenController(kitchenUsecase, kitchenStationUsecase, errorPresenter)
-	customController := controller.NewCustomerController(categoryUsecase, menuItemUsecase, orderUsecase, errorPresenter)
+	userController := controller.NewUserController(userUsecase, authMiddleware, errorPresenter)
+	terminalController := controller.NewTerminalController(terminalUsecase, authMiddleware, errorPresenter)
+	apiKeyController := controller.NewAPIKeyController(apiKeyUsecase, authMiddleware, errorPresenter)
+	categoryController := controller.NewCategoryController(categoryUsecase, authMiddleware, errorPresenter)
+	menuItemController := controller.NewMenuItemController(menuItemUsecase, authMiddleware, errorPresenter)
+	tableController := controller.NewTableController(tableUsecase, authMiddleware, errorPresenter)
+	orderController := controller.NewOrderController(orderUsecase, authMiddleware, idempotencyMiddleware, errorPresenter)
+	promotionController := controller.NewPromotionController(promotionUsecase, authMiddleware, errorPresenter)
+	paymentController := controller.NewPaymentController(paymentUsecase, authMiddleware, idempotencyMiddleware, errorPresenter)
+	revenueController := controller.NewRevenueController(revenueUsecase, authMiddleware, errorPresenter) // New revenue controller
+	kitchenController := controller.NewKitchenController(kitchenUsecase, kitchenStationUsecase, authMiddleware, errorPresenter)
+	auditController := controller.NewAuditController(auditUsecase, authMiddleware, errorPresenter)
+	shiftController := controller.NewShiftController(shiftUsecase, authMiddleware, errorPresenter)
+	approvalController := controller.NewApprovalController(approvalUsecase, authMiddleware, errorPresenter)
+	customController := controller.NewCustomerController(categoryUsecase, menuItemUsecase, orderUsecase, customerMiddleware, idempotencyMiddleware, errorPresenter)
 	// menuOptionController := controller.NewMenuOptionController(menuOptionUsecase, errorPresenter)
-	menuOptionController := controller.NewMenuWithOptionsController(menuWithOptionsUsecase, menuOptionMgmtUsecase, errorPresenter)
+	menuOptionController := controller.NewMenuWithOptionsController(menuWithOptionsUsecase, menuOptionMgmtUsecase, authMiddleware, errorPresenter)
 
 	// Setup fiber server
 	app := infrastructure.NewFiber(infrastructure.ServerConfig{
@@ -132,16 +181,24 @@ func main() {
 	})
 
 	// Register routes
-	api := app.Group("/api/v1")
+	api := app.Group("/api/v1", rateLimitMiddleware.Limit("default", cfg.RateLimit.Default))
+	api.Use("/users", rateLimitMiddleware.Limit("auth", cfg.RateLimit.Auth))
+	api.Use("/customers", rateLimitMiddleware.Limit("customer", cfg.RateLimit.Customer))
 	userController.RegisterRoutes(api)
+	terminalController.RegisterRoutes(api)
+	apiKeyController.RegisterRoutes(api)
 	categoryController.RegisterRoutes(api)
 	menuItemController.RegisterRoutes(api)
 	tableController.RegisterRoutes(api)
 	orderController.RegisterRoutes(api)
+	promotionController.RegisterRoutes(api)
 	paymentController.RegisterRoutes(api)
 	revenueController.RegisterRoutes(api) // Register revenue routes
 	kitchenController.RegisterRoutes(api)
 	customController.RegisterRoutes(api)
+	auditController.RegisterRoutes(api)
+	shiftController.RegisterRoutes(api)
+	approvalController.RegisterRoutes(api)
 	menuOptionController.RegisterRoutes(api)
 
 	// Graceful shutdown
@@ -170,6 +227,25 @@ func main() {
 	logger.Info("Server exited")
 }
 
+// pricingPolicy builds the bill pricing rules from configuration
+func pricingPolicy(cfg config.PricingConfig) entity.PricingPolicy {
+	policy := entity.PricingPolicy{
+		VATRate:                 cfg.VATRate,
+		VATInclusive:            cfg.VATInclusive,
+		ServiceChargeRate:       cfg.ServiceChargeRate,
+		ServiceChargeDineInOnly: cfg.ServiceChargeDineInOnly,
+	}
+	if cfg.DiscountRate > 0 {
+		minSubtotal, _ := vo.NewMoneyFromBaht(cfg.DiscountMinSubtotal)
+		policy.DiscountRules = append(policy.DiscountRules, entity.DiscountRule{
+			Name:        "order discount",
+			Rate:        cfg.DiscountRate,
+			MinSubtotal: minSubtotal,
+		})
+	}
+	return policy
+}
+
 // NewFiberServer creates a new Fiber server instance
 func NewFiberServer(config *config.Config) *infrastructure.FiberApp {
 	return infrastructure.NewFiber(infrastructure.ServerConfig{
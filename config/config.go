@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/infrastructure"
 	"github.com/joho/godotenv"
@@ -11,16 +12,29 @@ import (
 
 // Config holds application configuration
 type Config struct {
-	Server   ServerConfig
-	Database infrastructure.DBConfig
-	Cache    infrastructure.CacheConfig
-	LogLevel string
-	App      AppConfig
-	Printer  PrinterConfig
+	Server      ServerConfig
+	Database    infrastructure.DBConfig
+	Cache       infrastructure.CacheConfig
+	Mail        infrastructure.MailConfig
+	LogLevel    string
+	App         AppConfig
+	Printer     PrinterConfig
+	JWT         JWTConfig
+	RateLimit   RateLimitConfig
+	Idempotency IdempotencyConfig
+	Pricing     PricingConfig
+	Adjustment  AdjustmentConfig
 }
 type AppConfig struct {
-	MaxAcceptedAmount float64
-	QRcodeURL         string
+	MaxAcceptedAmount       float64
+	QRcodeURL               string
+	OwnerEmail              string // bootstrap owner account created on startup when set
+	OwnerPassword           string
+	TerminalIdleLock        int    // in minutes; PIN sessions on shared terminals lock after this idle time
+	ApprovalExpiration      int    // in minutes; manager approvals must be decided and used within this time
+	FrontendURL             string // base URL of the web app, used for links in emails
+	EmailVerifyExpiration   int    // in hours
+	PasswordResetExpiration int    // in minutes
 }
 
 // ServerConfig holds server configuration
@@ -38,6 +52,48 @@ type JWTConfig struct {
 	AccessExpiration  int // in minutes
 	RefreshExpiration int // in hours
 }
+
+// RateLimitConfig holds login lockout settings and API quotas per route group
+type RateLimitConfig struct {
+	LoginMaxAttempts   int // failed logins per email within the window before a lockout
+	LoginIPMaxAttempts int // failed logins per client IP within the window before a lockout
+	LoginWindow        int // in minutes
+	LoginLockout       int // in minutes
+	Default            RateLimitQuota
+	Auth               RateLimitQuota
+	Customer           RateLimitQuota
+}
+
+// RateLimitQuota allows Requests per client within Window
+type RateLimitQuota struct {
+	Requests int
+	Window   int // in seconds
+}
+
+// IdempotencyConfig holds how long responses to requests sent with an
+// Idempotency-Key are kept for replay
+type IdempotencyConfig struct {
+	TTL         int // in hours; retries with the same key within this time get the original response
+	LockTimeout int // in seconds; a key whose request never finished is freed after this time
+}
+
+// PricingConfig holds the rules used to price order bills
+type PricingConfig struct {
+	VATRate                 float64 // e.g. 0.07 for 7% VAT
+	VATInclusive            bool    // menu prices already include VAT
+	ServiceChargeRate       float64 // e.g. 0.10 for a 10% service charge; 0 to disable
+	ServiceChargeDineInOnly bool    // charge service on dine-in orders only
+	DiscountRate            float64 // order discount, e.g. 0.10 for 10% off; 0 to disable
+	DiscountMinSubtotal     float64 // in baht; subtotal an order must reach for the discount
+}
+
+// AdjustmentConfig holds the reason codes staff pick from when they void or
+// comp an order item
+type AdjustmentConfig struct {
+	VoidReasons []string // e.g. wrong_order, quality_issue
+	CompReasons []string // e.g. quality_issue, staff_meal
+}
+
 type PrinterConfig struct {
 	URL string
 }
@@ -71,13 +127,64 @@ func LoadFromEnv() *Config {
 			Db:       getEnvAsInt("REDIS_DB", 0),
 		},
 		App: AppConfig{
-			MaxAcceptedAmount: getEnvAsFloat("MAX_ACCEPTED_AMOUNT", 100000.0),
-			QRcodeURL:         getEnv("QRCODE_URL", "http://localhost:8080"),
+			MaxAcceptedAmount:       getEnvAsFloat("MAX_ACCEPTED_AMOUNT", 100000.0),
+			QRcodeURL:               getEnv("QRCODE_URL", "http://localhost:8080"),
+			OwnerEmail:              getEnv("OWNER_EMAIL", ""),
+			OwnerPassword:           getEnv("OWNER_PASSWORD", ""),
+			TerminalIdleLock:        getEnvAsInt("TERMINAL_IDLE_LOCK", 5),   // 5 minutes
+			ApprovalExpiration:      getEnvAsInt("APPROVAL_EXPIRATION", 15), // 15 minutes
+			FrontendURL:             getEnv("FRONTEND_URL", "http://localhost:3000"),
+			EmailVerifyExpiration:   getEnvAsInt("EMAIL_VERIFY_EXPIRATION", 48),   // 48 hours
+			PasswordResetExpiration: getEnvAsInt("PASSWORD_RESET_EXPIRATION", 30), // 30 minutes
+		},
+		Mail: infrastructure.MailConfig{
+			Driver: getEnv("MAIL_DRIVER", "log"),
+			From:   getEnv("MAIL_FROM", "no-reply@localhost"),
+			Dir:    getEnv("MAIL_DIR", "tmp/mail"),
 		},
 		LogLevel: getEnv("LOG_LEVEL", "info"),
 		Printer: PrinterConfig{
 			URL: getEnv("PRINTER_URL", "ws://localhost:8080/printer"),
 		},
+		JWT: JWTConfig{
+			Secret:            getEnv("JWT_SECRET", "change-me-in-production"),
+			AccessExpiration:  getEnvAsInt("JWT_ACCESS_EXPIRATION", 15),   // 15 minutes
+			RefreshExpiration: getEnvAsInt("JWT_REFRESH_EXPIRATION", 168), // 7 days
+		},
+		RateLimit: RateLimitConfig{
+			LoginMaxAttempts:   getEnvAsInt("LOGIN_MAX_ATTEMPTS", 5),
+			LoginIPMaxAttempts: getEnvAsInt("LOGIN_IP_MAX_ATTEMPTS", 20),
+			LoginWindow:        getEnvAsInt("LOGIN_WINDOW", 15),  // 15 minutes
+			LoginLockout:       getEnvAsInt("LOGIN_LOCKOUT", 15), // 15 minutes
+			Default: RateLimitQuota{
+				Requests: getEnvAsInt("RATE_LIMIT_DEFAULT_REQUESTS", 300),
+				Window:   getEnvAsInt("RATE_LIMIT_DEFAULT_WINDOW", 60), // 1 minute
+			},
+			Auth: RateLimitQuota{
+				Requests: getEnvAsInt("RATE_LIMIT_AUTH_REQUESTS", 30),
+				Window:   getEnvAsInt("RATE_LIMIT_AUTH_WINDOW", 60),
+			},
+			Customer: RateLimitQuota{
+				Requests: getEnvAsInt("RATE_LIMIT_CUSTOMER_REQUESTS", 60),
+				Window:   getEnvAsInt("RATE_LIMIT_CUSTOMER_WINDOW", 60),
+			},
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:         getEnvAsInt("IDEMPOTENCY_TTL", 24),          // 24 hours
+			LockTimeout: getEnvAsInt("IDEMPOTENCY_LOCK_TIMEOUT", 60), // 1 minute
+		},
+		Pricing: PricingConfig{
+			VATRate:                 getEnvAsFloat("VAT_RATE", 0.07),
+			VATInclusive:            getEnvAsBool("VAT_INCLUSIVE", false),
+			ServiceChargeRate:       getEnvAsFloat("SERVICE_CHARGE_RATE", 0),
+			ServiceChargeDineInOnly: getEnvAsBool("SERVICE_CHARGE_DINE_IN_ONLY", true),
+			DiscountRate:            getEnvAsFloat("DISCOUNT_RATE", 0),
+			DiscountMinSubtotal:     getEnvAsFloat("DISCOUNT_MIN_SUBTOTAL", 0),
+		},
+		Adjustment: AdjustmentConfig{
+			VoidReasons: getEnvAsList("VOID_REASON_CODES", []string{"wrong_order", "quality_issue", "customer_changed_mind", "entry_error"}),
+			CompReasons: getEnvAsList("COMP_REASON_CODES", []string{"quality_issue", "long_wait", "staff_meal", "manager_courtesy"}),
+		},
 	}
 }
 
@@ -105,6 +212,29 @@ func getEnvAsFloat(key string, defaultValue float64) float64 {
 	}
 	return defaultValue
 }
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		boolValue, err := strconv.ParseBool(value)
+		if err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList gets a comma separated environment variable as a list
+func getEnvAsList(key string, defaultValue []string) []string {
+	if value, exists := os.LookupEnv(key); exists {
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list
+	}
+	return defaultValue
+}
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
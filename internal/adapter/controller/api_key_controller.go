@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+)
+
+// APIKeyController handles HTTP requests for integration API keys
+type APIKeyController struct {
+	apiKeyUseCase  usecase.APIKeyUsecase
+	authMiddleware *middleware.AuthMiddleware
+	errorPresenter presenter.ErrorPresenter
+}
+
+// NewAPIKeyController creates a new instance of APIKeyController
+func NewAPIKeyController(apiKeyUseCase usecase.APIKeyUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *APIKeyController {
+	return &APIKeyController{
+		apiKeyUseCase:  apiKeyUseCase,
+		authMiddleware: authMiddleware,
+		errorPresenter: errorPresenter,
+	}
+}
+
+// CreateAPIKey handles issuing a new API key
+func (c *APIKeyController) CreateAPIKey(ctx *fiber.Ctx) error {
+	var req usecase.CreateAPIKeyRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	response, err := c.apiKeyUseCase.CreateAPIKey(ctx.Context(), &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "API key created successfully", response)
+}
+
+// ListAPIKeys handles listing API keys
+func (c *APIKeyController) ListAPIKeys(ctx *fiber.Ctx) error {
+	response, err := c.apiKeyUseCase.ListAPIKeys(ctx.Context())
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "API keys retrieved successfully", response)
+}
+
+// RotateAPIKey handles replacing the secret of an API key
+func (c *APIKeyController) RotateAPIKey(ctx *fiber.Ctx) error {
+	apiKeyID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid API Key ID format",
+		})
+	}
+
+	response, err := c.apiKeyUseCase.RotateAPIKey(ctx.Context(), apiKeyID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "API key rotated successfully", response)
+}
+
+// RevokeAPIKey handles revoking an API key
+func (c *APIKeyController) RevokeAPIKey(ctx *fiber.Ctx) error {
+	apiKeyID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid API Key ID format",
+		})
+	}
+
+	if err := c.apiKeyUseCase.RevokeAPIKey(ctx.Context(), apiKeyID); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "API key revoked successfully", nil)
+}
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+)
+
+// ApprovalController handles HTTP requests for manager approvals
+type ApprovalController struct {
+	approvalUseCase usecase.ApprovalUsecase
+	authMiddleware  *middleware.AuthMiddleware
+	errorPresenter  presenter.ErrorPresenter
+}
+
+// NewApprovalController creates a new instance of ApprovalController
+func NewApprovalController(approvalUseCase usecase.ApprovalUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *ApprovalController {
+	return &ApprovalController{
+		approvalUseCase: approvalUseCase,
+		authMiddleware:  authMiddleware,
+		errorPresenter:  errorPresenter,
+	}
+}
+
+// RequestApproval handles asking a manager to approve a change remotely
+func (c *ApprovalController) RequestApproval(ctx *fiber.Ctx) error {
+	var req usecase.RequestApprovalRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.OrderID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Order ID is required and must be greater than 0",
+		})
+	}
+
+	response, err := c.approvalUseCase.RequestApproval(ctx.Context(), &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "Approval requested successfully", response)
+}
+
+// GetApproval handles getting an approval by ID, so the requesting device can poll it
+func (c *ApprovalController) GetApproval(ctx *fiber.Ctx) error {
+	approvalID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Approval ID format",
+		})
+	}
+
+	response, err := c.approvalUseCase.GetApproval(ctx.Context(), approvalID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Approval retrieved successfully", response)
+}
+
+// ListPendingApprovals handles listing approvals waiting for a manager
+func (c *ApprovalController) ListPendingApprovals(ctx *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
+	offset, _ := strconv.Atoi(ctx.Query("offset", "0"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	response, err := c.approvalUseCase.ListPendingApprovals(ctx.Context(), limit, offset)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Pending approvals retrieved successfully", response)
+}
+
+// ApproveRequest handles a manager approving a pending request
+func (c *ApprovalController) ApproveRequest(ctx *fiber.Ctx) error {
+	approvalID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Approval ID format",
+		})
+	}
+
+	response, err := c.approvalUseCase.ApproveRequest(ctx.Context(), approvalID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Approval granted successfully", response)
+}
+
+// RejectRequest handles a manager rejecting a pending request
+func (c *ApprovalController) RejectRequest(ctx *fiber.Ctx) error {
+	approvalID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Approval ID format",
+		})
+	}
+
+	response, err := c.approvalUseCase.RejectRequest(ctx.Context(), approvalID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Approval rejected successfully", response)
+}
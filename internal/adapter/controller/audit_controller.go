@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+)
+
+// AuditController handles HTTP requests for the audit log
+type AuditController struct {
+	auditUseCase   usecase.AuditUsecase
+	authMiddleware *middleware.AuthMiddleware
+	errorPresenter presenter.ErrorPresenter
+}
+
+// NewAuditController creates a new instance of AuditController
+func NewAuditController(auditUseCase usecase.AuditUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *AuditController {
+	return &AuditController{
+		auditUseCase:   auditUseCase,
+		authMiddleware: authMiddleware,
+		errorPresenter: errorPresenter,
+	}
+}
+
+// ListAuditLogs handles listing audit log entries with optional filters
+func (c *AuditController) ListAuditLogs(ctx *fiber.Ctx) error {
+	req := &usecase.AuditLogFilterRequest{
+		Action:     ctx.Query("action"),
+		EntityType: ctx.Query("entity_type"),
+	}
+
+	if actorIDParam := ctx.Query("actor_id"); actorIDParam != "" {
+		actorID, err := strconv.Atoi(actorIDParam)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid actor_id format",
+			})
+		}
+		req.ActorID = &actorID
+	}
+
+	if entityIDParam := ctx.Query("entity_id"); entityIDParam != "" {
+		entityID, err := strconv.Atoi(entityIDParam)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid entity_id format",
+			})
+		}
+		req.EntityID = entityID
+	}
+
+	if fromParam := ctx.Query("from"); fromParam != "" {
+		from, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid from format. Use YYYY-MM-DD",
+			})
+		}
+		req.From = &from
+	}
+
+	if toParam := ctx.Query("to"); toParam != "" {
+		to, err := time.Parse("2006-01-02", toParam)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid to format. Use YYYY-MM-DD",
+			})
+		}
+		// include the whole end day
+		to = to.Add(24*time.Hour - time.Nanosecond)
+		req.To = &to
+	}
+
+	// Parse pagination parameters
+	limit, _ := strconv.Atoi(ctx.Query("limit", "50"))
+	offset, _ := strconv.Atoi(ctx.Query("offset", "0"))
+
+	// Validate pagination parameters
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	response, err := c.auditUseCase.ListAuditLogs(ctx.Context(), req, limit, offset)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Audit logs retrieved successfully", response)
+}
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -11,14 +12,16 @@ import (
 // CategoryController handles HTTP requests related to category operations
 type CategoryController struct {
 	categoryUseCase usecase.CategoryUsecase
+	authMiddleware  *middleware.AuthMiddleware
 	errorPresenter  presenter.ErrorPresenter
 }
 
 // NewCategoryController creates a new instance of CategoryController
-func NewCategoryController(categoryUseCase usecase.CategoryUsecase, errorPresenter presenter.ErrorPresenter) *CategoryController {
+func NewCategoryController(categoryUseCase usecase.CategoryUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *CategoryController {
 	return &CategoryController{
 		categoryUseCase: categoryUseCase,
 		errorPresenter:  errorPresenter,
+		authMiddleware:  authMiddleware,
 	}
 }
 
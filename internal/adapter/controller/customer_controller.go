@@ -4,25 +4,31 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 )
 
 // CategoryController handles HTTP requests related to category operations
 type CustomerController struct {
-	categoryUseCase usecase.CategoryUsecase
-	menuItemUseCase usecase.MenuItemUsecase
-	orderUseCase    usecase.OrderUsecase
-	errorPresenter  presenter.ErrorPresenter
+	categoryUseCase       usecase.CategoryUsecase
+	menuItemUseCase       usecase.MenuItemUsecase
+	orderUseCase          usecase.OrderUsecase
+	customerMiddleware    *middleware.CustomerMiddleware
+	idempotencyMiddleware *middleware.IdempotencyMiddleware
+	errorPresenter        presenter.ErrorPresenter
 }
 
 // NewCategoryController creates a new instance of CategoryController
-func NewCustomerController(categoryUseCase usecase.CategoryUsecase, menuItemUseCase usecase.MenuItemUsecase, orderUseCase usecase.OrderUsecase, errorPresenter presenter.ErrorPresenter) *CustomerController {
+func NewCustomerController(categoryUseCase usecase.CategoryUsecase, menuItemUseCase usecase.MenuItemUsecase, orderUseCase usecase.OrderUsecase, customerMiddleware *middleware.CustomerMiddleware, idempotencyMiddleware *middleware.IdempotencyMiddleware, errorPresenter presenter.ErrorPresenter) *CustomerController {
 	return &CustomerController{
-		orderUseCase:    orderUseCase,
-		categoryUseCase: categoryUseCase,
-		menuItemUseCase: menuItemUseCase,
-		errorPresenter:  errorPresenter,
+		orderUseCase:          orderUseCase,
+		customerMiddleware:    customerMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
+		categoryUseCase:       categoryUseCase,
+		menuItemUseCase:       menuItemUseCase,
+		errorPresenter:        errorPresenter,
 	}
 }
 
@@ -136,6 +142,20 @@ func (c *CustomerController) AddOrderItemList(ctx *fiber.Ctx) error {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
 
+	orderID, err := scopedOrderID(ctx, req.OrderID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	req.OrderID = orderID
+
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
 	responses, err := c.orderUseCase.AddOrderItemList(ctx.Context(), &req)
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -153,6 +173,20 @@ func (c *CustomerController) UpdateOrderItemList(ctx *fiber.Ctx) error {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
 
+	orderID, err := scopedOrderID(ctx, req.OrderID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	req.OrderID = orderID
+
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
 	responses, err := c.orderUseCase.UpdateOrderItemList(ctx.Context(), &req)
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -170,6 +204,20 @@ func (c *CustomerController) ManageOrderItemList(ctx *fiber.Ctx) error {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
 
+	orderID, err := scopedOrderID(ctx, req.OrderID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	req.OrderID = orderID
+
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
 	responses, err := c.orderUseCase.ManageOrderItemList(ctx.Context(), &req)
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -182,6 +230,67 @@ func (c *CustomerController) ManageOrderItemList(ctx *fiber.Ctx) error {
 	})
 }
 
+// SubmitRound sends the items in the customer's cart to the kitchen
+func (c *CustomerController) SubmitRound(ctx *fiber.Ctx) error {
+	var req usecase.SubmitRoundRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return HandleError(ctx, err, c.errorPresenter)
+		}
+	}
+
+	orderID, err := scopedOrderID(ctx, req.OrderID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.SubmitRound(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Round submitted successfully", response)
+}
+
+// RepeatOrderItems copies a round, or chosen items, of the customer's order
+// into their cart
+func (c *CustomerController) RepeatOrderItems(ctx *fiber.Ctx) error {
+	var req usecase.RepeatOrderItemsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	// Customers may only repeat what they ordered themselves
+	orderID, err := scopedOrderID(ctx, req.SourceOrderID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	req.SourceOrderID = orderID
+
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.RepeatOrderItems(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Order items repeated successfully", response)
+}
+
 func (c *CustomerController) GetOrderDetailWithOptions(ctx *fiber.Ctx) error {
 	orderIDParam := ctx.Params("id")
 	if orderIDParam == "" {
@@ -199,6 +308,10 @@ func (c *CustomerController) GetOrderDetailWithOptions(ctx *fiber.Ctx) error {
 		})
 	}
 
+	if _, err := scopedOrderID(ctx, orderID); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
 	response, err := c.orderUseCase.GetOrderDetailWithOptions(ctx.Context(), orderID)
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -206,38 +319,31 @@ func (c *CustomerController) GetOrderDetailWithOptions(ctx *fiber.Ctx) error {
 
 	return SuccessResp(ctx, fiber.StatusOK, "Order detail retrieved successfully", response)
 }
-func (c *CustomerController) GetOrderDetailByStatus(ctx *fiber.Ctx) error {
-	status := ctx.Query("status")
-	if status == "" {
-		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Status:  fiber.StatusBadRequest,
-			Message: "Status is required",
-		})
-	}
-	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
-	page, _ := strconv.Atoi(ctx.Query("page", "1"))
-	offset := (page - 1) * limit
-	if limit <= 0 || limit > 100 {
-		limit = 10
+
+// GetCurrentOrder returns the order the customer's QR code belongs to
+func (c *CustomerController) GetCurrentOrder(ctx *fiber.Ctx) error {
+	orderID, err := scopedOrderID(ctx, 0)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
 	}
-	response, err := c.orderUseCase.GetOrdersByStatusWithDetails(ctx.Context(), status, limit, offset)
+
+	response, err := c.orderUseCase.GetOrderDetailWithOptions(ctx.Context(), orderID)
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
 
 	return SuccessResp(ctx, fiber.StatusOK, "Order detail retrieved successfully", response)
 }
-func (c *CustomerController) ListOrderItems(ctx *fiber.Ctx) error {
-	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
-	page, _ := strconv.Atoi(ctx.Query("page", "1"))
-	offset := (page - 1) * limit
-	if limit <= 0 || limit > 100 {
-		limit = 10
+
+// scopedOrderID returns the order resolved from the customer's QR code and
+// refuses any other order ID; a zero requested ID means the customer's own
+func scopedOrderID(ctx *fiber.Ctx, requested int) (int, error) {
+	orderID, ok := ctx.Locals(middleware.LocalOrderID).(int)
+	if !ok {
+		return 0, errs.ErrInvalidOrderToken
 	}
-	response, err := c.orderUseCase.ListOrdersWithItemsAndCount(ctx.Context(), limit, offset)
-	if err != nil {
-		return HandleError(ctx, err, c.errorPresenter)
+	if requested != 0 && requested != orderID {
+		return 0, errs.ErrOrderAccessDenied
 	}
-
-	return SuccessResp(ctx, fiber.StatusOK, "Order items retrieved successfully", response)
+	return orderID, nil
 }
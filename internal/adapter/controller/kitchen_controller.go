@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -14,21 +15,23 @@ import (
 type KitchenController struct {
 	kitchenUseCase        usecase.KitchenUsecase
 	kitchenStationUsecase usecase.KitchenStationUsecase
+	authMiddleware        *middleware.AuthMiddleware
 	errorPresenter        presenter.ErrorPresenter
 }
 
 // NewKitchenController creates a new instance of KitchenController
-func NewKitchenController(kitchenUseCase usecase.KitchenUsecase, kitchenStationUsecase usecase.KitchenStationUsecase, errorPresenter presenter.ErrorPresenter) *KitchenController {
+func NewKitchenController(kitchenUseCase usecase.KitchenUsecase, kitchenStationUsecase usecase.KitchenStationUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *KitchenController {
 	return &KitchenController{
 		kitchenUseCase:        kitchenUseCase,
 		errorPresenter:        errorPresenter,
 		kitchenStationUsecase: kitchenStationUsecase,
+		authMiddleware:        authMiddleware,
 	}
 }
 
 // GetKitchenQueue handles getting kitchen queue
 func (c *KitchenController) GetKitchenQueue(ctx *fiber.Ctx) error {
-	response, err := c.kitchenUseCase.GetKitchenQueue(ctx.Context())
+	response, err := c.kitchenUseCase.GetKitchenQueue(ctx.Context(), ctx.Query("order_type"))
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -12,14 +13,16 @@ import (
 // MenuItemController handles HTTP requests related to menu item operations
 type MenuItemController struct {
 	menuItemUseCase usecase.MenuItemUsecase
+	authMiddleware  *middleware.AuthMiddleware
 	errorPresenter  presenter.ErrorPresenter
 }
 
 // NewMenuItemController creates a new instance of MenuItemController
-func NewMenuItemController(menuItemUseCase usecase.MenuItemUsecase, errorPresenter presenter.ErrorPresenter) *MenuItemController {
+func NewMenuItemController(menuItemUseCase usecase.MenuItemUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *MenuItemController {
 	return &MenuItemController{
 		menuItemUseCase: menuItemUseCase,
 		errorPresenter:  errorPresenter,
+		authMiddleware:  authMiddleware,
 	}
 }
 
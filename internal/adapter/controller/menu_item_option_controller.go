@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -13,14 +14,16 @@ import (
 // MenuItemOptionController handles HTTP requests related to menu item option operations
 type MenuItemOptionController struct {
 	menuItemOptionUseCase usecase.MenuItemOptionUsecase
+	authMiddleware        *middleware.AuthMiddleware
 	errorPresenter        presenter.ErrorPresenter
 }
 
 // NewMenuItemOptionController creates a new instance of MenuItemOptionController
-func NewMenuItemOptionController(menuItemOptionUseCase usecase.MenuItemOptionUsecase, errorPresenter presenter.ErrorPresenter) *MenuItemOptionController {
+func NewMenuItemOptionController(menuItemOptionUseCase usecase.MenuItemOptionUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *MenuItemOptionController {
 	return &MenuItemOptionController{
 		menuItemOptionUseCase: menuItemOptionUseCase,
 		errorPresenter:        errorPresenter,
+		authMiddleware:        authMiddleware,
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -13,14 +14,16 @@ import (
 // MenuOptionController handles HTTP requests related to menu option operations
 type MenuOptionController struct {
 	menuOptionUseCase usecase.MenuOptionUsecase
+	authMiddleware    *middleware.AuthMiddleware
 	errorPresenter    presenter.ErrorPresenter
 }
 
 // NewMenuOptionController creates a new instance of MenuOptionController
-func NewMenuOptionController(menuOptionUseCase usecase.MenuOptionUsecase, errorPresenter presenter.ErrorPresenter) *MenuOptionController {
+func NewMenuOptionController(menuOptionUseCase usecase.MenuOptionUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *MenuOptionController {
 	return &MenuOptionController{
 		menuOptionUseCase: menuOptionUseCase,
 		errorPresenter:    errorPresenter,
+		authMiddleware:    authMiddleware,
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -12,18 +13,21 @@ import (
 type MenuWithOptionsController struct {
 	menuWithOptionsUc usecase.MenuWithOptionsUsecase
 	menuOptionMgmtUc  usecase.MenuOptionManagementUsecase
+	authMiddleware    *middleware.AuthMiddleware
 	errorPresenter    presenter.ErrorPresenter
 }
 
 func NewMenuWithOptionsController(
 	menuWithOptionsUc usecase.MenuWithOptionsUsecase,
 	menuOptionMgmtUc usecase.MenuOptionManagementUsecase,
+	authMiddleware *middleware.AuthMiddleware,
 	errorPresenter presenter.ErrorPresenter,
 ) *MenuWithOptionsController {
 	return &MenuWithOptionsController{
 		menuWithOptionsUc: menuWithOptionsUc,
 		menuOptionMgmtUc:  menuOptionMgmtUc,
 		errorPresenter:    errorPresenter,
+		authMiddleware:    authMiddleware,
 	}
 }
 
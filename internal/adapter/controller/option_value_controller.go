@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -13,14 +14,16 @@ import (
 // OptionValueController handles HTTP requests related to option value operations
 type OptionValueController struct {
 	optionValueUseCase usecase.OptionValueUsecase
+	authMiddleware     *middleware.AuthMiddleware
 	errorPresenter     presenter.ErrorPresenter
 }
 
 // NewOptionValueController creates a new instance of OptionValueController
-func NewOptionValueController(optionValueUseCase usecase.OptionValueUsecase, errorPresenter presenter.ErrorPresenter) *OptionValueController {
+func NewOptionValueController(optionValueUseCase usecase.OptionValueUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *OptionValueController {
 	return &OptionValueController{
 		optionValueUseCase: optionValueUseCase,
 		errorPresenter:     errorPresenter,
+		authMiddleware:     authMiddleware,
 	}
 }
 
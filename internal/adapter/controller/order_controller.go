@@ -2,25 +2,31 @@ package controller
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
 
 // OrderController handles HTTP requests related to order operations
 type OrderController struct {
-	orderUseCase   usecase.OrderUsecase
-	errorPresenter presenter.ErrorPresenter
+	orderUseCase          usecase.OrderUsecase
+	authMiddleware        *middleware.AuthMiddleware
+	idempotencyMiddleware *middleware.IdempotencyMiddleware
+	errorPresenter        presenter.ErrorPresenter
 }
 
 // NewOrderController creates a new instance of OrderController
-func NewOrderController(orderUseCase usecase.OrderUsecase, errorPresenter presenter.ErrorPresenter) *OrderController {
+func NewOrderController(orderUseCase usecase.OrderUsecase, authMiddleware *middleware.AuthMiddleware, idempotencyMiddleware *middleware.IdempotencyMiddleware, errorPresenter presenter.ErrorPresenter) *OrderController {
 	return &OrderController{
-		orderUseCase:   orderUseCase,
-		errorPresenter: errorPresenter,
+		orderUseCase:          orderUseCase,
+		errorPresenter:        errorPresenter,
+		authMiddleware:        authMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
 	}
 }
 
@@ -31,15 +37,21 @@ func (c *OrderController) CreateOrder(ctx *fiber.Ctx) error {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
 
-	if req.TableID <= 0 {
+	if req.TableID < 0 {
 		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
 			Status:  fiber.StatusBadRequest,
-			Message: "Table ID is required and must be greater than 0",
+			Message: "Table ID must be greater than 0",
 		})
 	}
 
 	response, qrCode, err := c.orderUseCase.CreateOrder(ctx.Context(), &usecase.CreateOrderRequest{
-		TableID: req.TableID,
+		TableID:         req.TableID,
+		OrderType:       req.OrderType,
+		CustomerName:    req.CustomerName,
+		CustomerPhone:   req.CustomerPhone,
+		DeliveryAddress: req.DeliveryAddress,
+		GuestCount:      req.GuestCount,
+		OverrideSeating: req.OverrideSeating,
 	})
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -77,6 +89,7 @@ func (c *OrderController) GetOrder(ctx *fiber.Ctx) error {
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
+	setVersionETag(ctx, response.Version)
 
 	return SuccessResp(ctx, fiber.StatusOK, "Order retrieved successfully", response)
 }
@@ -103,6 +116,7 @@ func (c *OrderController) GetOrderWithItems(ctx *fiber.Ctx) error {
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
+	setVersionETag(ctx, response.Version)
 
 	return SuccessResp(ctx, fiber.StatusOK, "Order with items retrieved successfully", response)
 }
@@ -137,8 +151,17 @@ func (c *OrderController) UpdateOrder(ctx *fiber.Ctx) error {
 		})
 	}
 
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
 	response, err := c.orderUseCase.UpdateOrder(ctx.Context(), orderID, &usecase.UpdateOrderRequest{
-		Status: req.Status,
+		Status:  req.Status,
+		Version: version,
 	})
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -173,6 +196,327 @@ func (c *OrderController) CloseOrder(ctx *fiber.Ctx) error {
 	return SuccessResp(ctx, fiber.StatusOK, "Order closed successfully", response)
 }
 
+// ReopenOrder handles reopening a completed order
+func (c *OrderController) ReopenOrder(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	var req usecase.ReopenOrderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	response, err := c.orderUseCase.ReopenOrder(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Order reopened successfully", response)
+}
+
+// GetOrderDetail handles getting an order with its items, balance and status history
+func (c *OrderController) GetOrderDetail(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	response, err := c.orderUseCase.GetOrderDetailWithOptions(ctx.Context(), orderID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	setVersionETag(ctx, response.Version)
+
+	return SuccessResp(ctx, fiber.StatusOK, "Order detail retrieved successfully", response)
+}
+
+// TransferOrder handles moving an order to another table
+func (c *OrderController) TransferOrder(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	var req usecase.TransferOrderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	if req.TableID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Table ID",
+		})
+	}
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.TransferOrder(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	setVersionETag(ctx, response.Version)
+
+	return SuccessResp(ctx, fiber.StatusOK, "Order transferred successfully", response)
+}
+
+// MergeOrders handles merging an order into another order
+func (c *OrderController) MergeOrders(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	var req usecase.MergeOrderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	if req.TargetOrderID <= 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid target Order ID",
+		})
+	}
+	// If-Match names the version of the order being merged, the one in the URL
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.MergeOrders(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Orders merged successfully", response)
+}
+
+// UpdateGuestCount handles changing the number of guests on an order
+func (c *OrderController) UpdateGuestCount(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	var req usecase.UpdateGuestCountRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.UpdateGuestCount(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	setVersionETag(ctx, response.Version)
+
+	return SuccessResp(ctx, fiber.StatusOK, "Guest count updated successfully", response)
+}
+
+// ApplyCoupon handles giving an order a promo code
+func (c *OrderController) ApplyCoupon(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	var req usecase.ApplyCouponRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	if req.Code == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Coupon code is required",
+		})
+	}
+
+	response, err := c.orderUseCase.ApplyCoupon(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Coupon applied successfully", response)
+}
+
+// RemoveCoupon handles taking the promo code off an order
+func (c *OrderController) RemoveCoupon(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	response, err := c.orderUseCase.RemoveCoupon(ctx.Context(), orderID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Coupon removed successfully", response)
+}
+
+// SubmitRound handles sending the items in an order's cart to the kitchen
+func (c *OrderController) SubmitRound(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	var req usecase.SubmitRoundRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return HandleError(ctx, err, c.errorPresenter)
+		}
+	}
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.SubmitRound(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	setVersionETag(ctx, response.Version)
+	return SuccessResp(ctx, fiber.StatusOK, "Round submitted successfully", response)
+}
+
+// RepeatOrderItems handles copying a round, or chosen items, into an order's cart
+func (c *OrderController) RepeatOrderItems(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	var req usecase.RepeatOrderItemsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.RepeatOrderItems(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Order items repeated successfully", response)
+}
+
+// FireCourse handles sending held items of an order to the kitchen
+func (c *OrderController) FireCourse(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	// Without a body every held item is fired
+	var req usecase.FireCourseRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return HandleError(ctx, err, c.errorPresenter)
+		}
+	}
+	if req.Course != nil && *req.Course < 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid course",
+		})
+	}
+
+	response, err := c.orderUseCase.FireCourse(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Course fired successfully", response)
+}
+
+// HoldCourse handles keeping pending items of an order from the kitchen
+func (c *OrderController) HoldCourse(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	// Without a body every pending item is held
+	var req usecase.HoldCourseRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return HandleError(ctx, err, c.errorPresenter)
+		}
+	}
+	if req.Course != nil && *req.Course < 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid course",
+		})
+	}
+
+	response, err := c.orderUseCase.HoldCourse(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Course held successfully", response)
+}
+
 // ListOrders handles getting all orders
 func (c *OrderController) ListOrders(ctx *fiber.Ctx) error {
 	// Parse pagination parameters
@@ -309,6 +653,30 @@ func (c *OrderController) GetOrdersByStatus(ctx *fiber.Ctx) error {
 	return SuccessResp(ctx, fiber.StatusOK, "Orders by status retrieved successfully", response)
 }
 
+// GetOrdersByType handles getting orders of one order type
+func (c *OrderController) GetOrdersByType(ctx *fiber.Ctx) error {
+	orderType := ctx.Params("orderType")
+
+	// Parse pagination parameters
+	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
+	offset, _ := strconv.Atoi(ctx.Query("offset", "0"))
+
+	// Validate pagination parameters
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	response, err := c.orderUseCase.GetOrdersByType(ctx.Context(), orderType, limit, offset)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Orders by type retrieved successfully", response)
+}
+
 // GetOrdersByDateRange handles getting orders by date range
 func (c *OrderController) GetOrdersByDateRange(ctx *fiber.Ctx) error {
 	startDateStr := ctx.Query("start_date")
@@ -427,8 +795,17 @@ func (c *OrderController) UpdateOrderItem(ctx *fiber.Ctx) error {
 		})
 	}
 
+	version, err := ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
 	response, err := c.orderUseCase.UpdateOrderItem(ctx.Context(), orderItemID, &usecase.UpdateOrderItemRequest{
 		Quantity: req.Quantity,
+		Version:  version,
 	})
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -455,7 +832,27 @@ func (c *OrderController) RemoveOrderItem(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err = c.orderUseCase.RemoveOrderItem(ctx.Context(), orderItemID)
+	// The body is optional for cart items; submitted items are voided with a
+	// reason code, and an approval once the kitchen started on them
+	var req dto.RemoveOrderItemRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return HandleError(ctx, err, c.errorPresenter)
+		}
+	}
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	err = c.orderUseCase.RemoveOrderItem(ctx.Context(), orderItemID, &usecase.VoidOrderItemRequest{
+		ReasonCode: req.ReasonCode,
+		Approval:   toApprovalInput(req.Approval),
+		Version:    req.Version,
+	})
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
@@ -463,6 +860,82 @@ func (c *OrderController) RemoveOrderItem(ctx *fiber.Ctx) error {
 	return SuccessResp(ctx, fiber.StatusOK, "Order item removed successfully", nil)
 }
 
+// CompOrderItem handles keeping an order item on the bill at zero price
+func (c *OrderController) CompOrderItem(ctx *fiber.Ctx) error {
+	orderItemID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order item ID format",
+		})
+	}
+
+	var req usecase.CompOrderItemRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.CompOrderItem(ctx.Context(), orderItemID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	setVersionETag(ctx, response.Version)
+	return SuccessResp(ctx, fiber.StatusOK, "Order item comped successfully", response)
+}
+
+// ListAdjustmentReasons handles listing the reason codes for voids and comps
+func (c *OrderController) ListAdjustmentReasons(ctx *fiber.Ctx) error {
+	response := c.orderUseCase.ListAdjustmentReasons(ctx.Context())
+	return SuccessResp(ctx, fiber.StatusOK, "Adjustment reasons retrieved successfully", response)
+}
+
+// ApplyItemDiscount handles setting a manual discount on an order item
+func (c *OrderController) ApplyItemDiscount(ctx *fiber.Ctx) error {
+	orderItemID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order item ID format",
+		})
+	}
+
+	var req usecase.ApplyItemDiscountRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.Discount < 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Discount must not be negative",
+		})
+	}
+
+	req.Version, err = ifMatchVersion(ctx, req.Version)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid If-Match header",
+		})
+	}
+
+	response, err := c.orderUseCase.ApplyItemDiscount(ctx.Context(), orderItemID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Discount applied successfully", response)
+}
+
 // ListOrderItems handles getting all items for an order
 func (c *OrderController) ListOrderItems(ctx *fiber.Ctx) error {
 	orderIDParam := ctx.Params("orderId")
@@ -578,3 +1051,36 @@ func (c *OrderController) GetOrderIDFromQRCode(ctx *fiber.Ctx) error {
 
 	return SuccessResp(ctx, fiber.StatusOK, "Order ID retrieved successfully", orderID)
 }
+
+// toApprovalInput converts an approval in a request body for the usecase layer
+func toApprovalInput(req *dto.ApprovalRequest) *usecase.ApprovalInput {
+	if req == nil {
+		return nil
+	}
+	return &usecase.ApprovalInput{
+		ApprovalID: req.ApprovalID,
+		ManagerID:  req.ManagerID,
+		ManagerPin: req.ManagerPin,
+		ReasonCode: req.ReasonCode,
+		Note:       req.Note,
+	}
+}
+
+// ifMatchVersion returns the version in the If-Match header, e.g. "3" or
+// W/"3", falling back to the one in the request body when there is none
+func ifMatchVersion(ctx *fiber.Ctx, fallback *int) (*int, error) {
+	header := strings.TrimSpace(ctx.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return fallback, nil
+	}
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// setVersionETag sends the order version as an ETag, for clients to return in If-Match
+func setVersionETag(ctx *fiber.Ctx, version int) {
+	ctx.Set(fiber.HeaderETag, strconv.Quote(strconv.Itoa(version)))
+}
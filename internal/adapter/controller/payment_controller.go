@@ -6,21 +6,26 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
 
 // PaymentController handles HTTP requests related to payment operations
 type PaymentController struct {
-	paymentUseCase usecase.PaymentUsecase
-	errorPresenter presenter.ErrorPresenter
+	paymentUseCase        usecase.PaymentUsecase
+	authMiddleware        *middleware.AuthMiddleware
+	idempotencyMiddleware *middleware.IdempotencyMiddleware
+	errorPresenter        presenter.ErrorPresenter
 }
 
 // NewPaymentController creates a new instance of PaymentController
-func NewPaymentController(paymentUseCase usecase.PaymentUsecase, errorPresenter presenter.ErrorPresenter) *PaymentController {
+func NewPaymentController(paymentUseCase usecase.PaymentUsecase, authMiddleware *middleware.AuthMiddleware, idempotencyMiddleware *middleware.IdempotencyMiddleware, errorPresenter presenter.ErrorPresenter) *PaymentController {
 	return &PaymentController{
-		paymentUseCase: paymentUseCase,
-		errorPresenter: errorPresenter,
+		paymentUseCase:        paymentUseCase,
+		errorPresenter:        errorPresenter,
+		authMiddleware:        authMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
 	}
 }
 
@@ -53,9 +58,10 @@ func (c *PaymentController) ProcessPayment(ctx *fiber.Ctx) error {
 	}
 
 	response, err := c.paymentUseCase.ProcessPayment(ctx.Context(), &usecase.ProcessPaymentRequest{
-		OrderID: req.OrderID,
-		Amount:  req.Amount,
-		Method:  req.Method,
+		OrderID:      req.OrderID,
+		Amount:       req.Amount,
+		Method:       req.Method,
+		OrderItemIDs: req.OrderItemIDs,
 	})
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -90,8 +96,31 @@ func (c *PaymentController) GetPayment(ctx *fiber.Ctx) error {
 	return SuccessResp(ctx, fiber.StatusOK, "Payment retrieved successfully", response)
 }
 
-// GetPaymentByOrder handles getting payment by order ID
-func (c *PaymentController) GetPaymentByOrder(ctx *fiber.Ctx) error {
+// RefundPayment handles refunding a payment
+func (c *PaymentController) RefundPayment(ctx *fiber.Ctx) error {
+	paymentID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Payment ID format",
+		})
+	}
+
+	var req usecase.RefundPaymentRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	response, err := c.paymentUseCase.RefundPayment(ctx.Context(), paymentID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Payment refunded successfully", response)
+}
+
+// ListPaymentsByOrder handles getting the payments and balance of an order
+func (c *PaymentController) ListPaymentsByOrder(ctx *fiber.Ctx) error {
 	orderIDParam := ctx.Params("orderId")
 	if orderIDParam == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
@@ -108,12 +137,52 @@ func (c *PaymentController) GetPaymentByOrder(ctx *fiber.Ctx) error {
 		})
 	}
 
-	response, err := c.paymentUseCase.GetPaymentByOrder(ctx.Context(), orderID)
+	response, err := c.paymentUseCase.ListPaymentsByOrder(ctx.Context(), orderID)
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
 
-	return SuccessResp(ctx, fiber.StatusOK, "Payment retrieved successfully", response)
+	return SuccessResp(ctx, fiber.StatusOK, "Order payments retrieved successfully", response)
+}
+
+// SplitBill handles working out the shares of a split bill
+func (c *PaymentController) SplitBill(ctx *fiber.Ctx) error {
+	orderID, err := strconv.Atoi(ctx.Params("orderId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Order ID format",
+		})
+	}
+
+	var req usecase.SplitBillRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	response, err := c.paymentUseCase.SplitBill(ctx.Context(), orderID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Bill split successfully", response)
+}
+
+// PrintPaymentReceipt handles printing the receipt of one payment
+func (c *PaymentController) PrintPaymentReceipt(ctx *fiber.Ctx) error {
+	paymentID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Payment ID format",
+		})
+	}
+
+	if err := c.paymentUseCase.PrintPaymentReceipt(ctx.Context(), paymentID); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Payment receipt printed successfully", nil)
 }
 
 // ListPayments handles getting all payments
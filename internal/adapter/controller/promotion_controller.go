@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+)
+
+// PromotionController handles HTTP requests for promotions
+type PromotionController struct {
+	promotionUseCase usecase.PromotionUsecase
+	authMiddleware   *middleware.AuthMiddleware
+	errorPresenter   presenter.ErrorPresenter
+}
+
+// NewPromotionController creates a new instance of PromotionController
+func NewPromotionController(promotionUseCase usecase.PromotionUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *PromotionController {
+	return &PromotionController{
+		promotionUseCase: promotionUseCase,
+		authMiddleware:   authMiddleware,
+		errorPresenter:   errorPresenter,
+	}
+}
+
+// CreatePromotion handles promotion creation
+func (c *PromotionController) CreatePromotion(ctx *fiber.Ctx) error {
+	var req usecase.PromotionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	response, err := c.promotionUseCase.CreatePromotion(ctx.Context(), &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "Promotion created successfully", response)
+}
+
+// GetPromotion handles getting a promotion by ID
+func (c *PromotionController) GetPromotion(ctx *fiber.Ctx) error {
+	promotionID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Promotion ID format",
+		})
+	}
+
+	response, err := c.promotionUseCase.GetPromotion(ctx.Context(), promotionID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Promotion retrieved successfully", response)
+}
+
+// UpdatePromotion handles replacing the rules of a promotion
+func (c *PromotionController) UpdatePromotion(ctx *fiber.Ctx) error {
+	promotionID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Promotion ID format",
+		})
+	}
+
+	var req usecase.PromotionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	response, err := c.promotionUseCase.UpdatePromotion(ctx.Context(), promotionID, &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Promotion updated successfully", response)
+}
+
+// DeletePromotion handles promotion deletion
+func (c *PromotionController) DeletePromotion(ctx *fiber.Ctx) error {
+	promotionID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Promotion ID format",
+		})
+	}
+
+	if err := c.promotionUseCase.DeletePromotion(ctx.Context(), promotionID); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Promotion deleted successfully", nil)
+}
+
+// ListPromotions handles listing promotions
+func (c *PromotionController) ListPromotions(ctx *fiber.Ctx) error {
+	onlyActive := ctx.QueryBool("active", false)
+
+	response, err := c.promotionUseCase.ListPromotions(ctx.Context(), onlyActive)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Promotions retrieved successfully", response)
+}
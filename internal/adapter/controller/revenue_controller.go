@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -12,14 +13,16 @@ import (
 // RevenueController handles HTTP requests related to revenue operations
 type RevenueController struct {
 	revenueUsecase usecase.RevenueUsecase
+	authMiddleware *middleware.AuthMiddleware
 	errorPresenter presenter.ErrorPresenter
 }
 
 // NewRevenueController creates a new instance of RevenueController
-func NewRevenueController(revenueUsecase usecase.RevenueUsecase, errorPresenter presenter.ErrorPresenter) *RevenueController {
+func NewRevenueController(revenueUsecase usecase.RevenueUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *RevenueController {
 	return &RevenueController{
 		revenueUsecase: revenueUsecase,
 		errorPresenter: errorPresenter,
+		authMiddleware: authMiddleware,
 	}
 }
 
@@ -199,3 +202,183 @@ func (c *RevenueController) GetTotalRevenue(ctx *fiber.Ctx) error {
 
 	return SuccessResp(ctx, fiber.StatusOK, "Total revenue retrieved successfully", response)
 }
+
+// GetRevenueByOrderType handles getting revenue per order type for a date range
+func (c *RevenueController) GetRevenueByOrderType(ctx *fiber.Ctx) error {
+	startDateStr := ctx.Query("start_date")
+	endDateStr := ctx.Query("end_date")
+
+	if startDateStr == "" || endDateStr == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "start_date and end_date query parameters are required",
+		})
+	}
+
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid start_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid end_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	response, err := c.revenueUsecase.GetRevenueByOrderType(ctx.Context(), startDate, endDate, ctx.Query("order_type"))
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Revenue by order type retrieved successfully", response)
+}
+
+// GetPromotionRevenue handles getting the discount given per promotion for a date range
+func (c *RevenueController) GetPromotionRevenue(ctx *fiber.Ctx) error {
+	startDateStr := ctx.Query("start_date")
+	endDateStr := ctx.Query("end_date")
+
+	if startDateStr == "" || endDateStr == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "start_date and end_date query parameters are required",
+		})
+	}
+
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid start_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid end_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	response, err := c.revenueUsecase.GetPromotionRevenue(ctx.Context(), startDate, endDate)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Promotion revenue retrieved successfully", response)
+}
+
+// GetItemDiscountRevenue handles getting the item markdowns and discounts given per menu item for a date range
+func (c *RevenueController) GetItemDiscountRevenue(ctx *fiber.Ctx) error {
+	startDateStr := ctx.Query("start_date")
+	endDateStr := ctx.Query("end_date")
+
+	if startDateStr == "" || endDateStr == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "start_date and end_date query parameters are required",
+		})
+	}
+
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid start_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid end_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	response, err := c.revenueUsecase.GetItemDiscountRevenue(ctx.Context(), startDate, endDate)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Item discount revenue retrieved successfully", response)
+}
+
+// GetItemAdjustmentRevenue handles getting the value of items voided and comped per reason code for a date range
+func (c *RevenueController) GetItemAdjustmentRevenue(ctx *fiber.Ctx) error {
+	startDateStr := ctx.Query("start_date")
+	endDateStr := ctx.Query("end_date")
+
+	if startDateStr == "" || endDateStr == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "start_date and end_date query parameters are required",
+		})
+	}
+
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid start_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid end_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	response, err := c.revenueUsecase.GetItemAdjustmentRevenue(ctx.Context(), startDate, endDate)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Item adjustment revenue retrieved successfully", response)
+}
+
+// GetCoverRevenue handles getting the guests served and their average spend for a date range
+func (c *RevenueController) GetCoverRevenue(ctx *fiber.Ctx) error {
+	startDateStr := ctx.Query("start_date")
+	endDateStr := ctx.Query("end_date")
+
+	if startDateStr == "" || endDateStr == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "start_date and end_date query parameters are required",
+		})
+	}
+
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid start_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid end_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	response, err := c.revenueUsecase.GetCoverRevenue(ctx.Context(), startDate, endDate)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Cover revenue retrieved successfully", response)
+}
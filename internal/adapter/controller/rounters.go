@@ -1,6 +1,9 @@
 package controller
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
 
 // RegisterRoutes registers the routes for the category controller
 func (c *CategoryController) RegisterRoutes(router fiber.Router) {
@@ -11,10 +14,11 @@ func (c *CategoryController) RegisterRoutes(router fiber.Router) {
 	categoryGroup.Get("/search", c.GetCategoryByName) // GET /categories/search?name=ของคาว
 	categoryGroup.Get("/:id", c.GetCategory)
 
-	// Admin routes (require admin role in real implementation)
-	categoryGroup.Post("/", c.CreateCategory)
-	categoryGroup.Put("/:id", c.UpdateCategory)
-	categoryGroup.Delete("/:id", c.DeleteCategory)
+	// Management routes
+	manage := c.authMiddleware.RequirePermission(vo.PermMenuManage)
+	categoryGroup.Post("/", manage, c.CreateCategory)
+	categoryGroup.Put("/:id", manage, c.UpdateCategory)
+	categoryGroup.Delete("/:id", manage, c.DeleteCategory)
 }
 
 // RegisterRoutes registers the routes for the menu item controller
@@ -27,55 +31,137 @@ func (c *MenuItemController) RegisterRoutes(router fiber.Router) {
 	menuItemGroup.Get("/category/:categoryId", c.ListMenuItemsByCategory) // GET /menu-items/category/1
 	menuItemGroup.Get("/:id", c.GetMenuItem)
 
-	// Admin routes (require admin role in real implementation)
-	menuItemGroup.Post("/", c.CreateMenuItem)
-	menuItemGroup.Put("/:id", c.UpdateMenuItem)
-	menuItemGroup.Delete("/:id", c.DeleteMenuItem)
+	// Management routes
+	manage := c.authMiddleware.RequirePermission(vo.PermMenuManage)
+	menuItemGroup.Post("/", manage, c.CreateMenuItem)
+	menuItemGroup.Put("/:id", manage, c.UpdateMenuItem)
+	menuItemGroup.Delete("/:id", manage, c.DeleteMenuItem)
 }
 func (c *OrderController) RegisterRoutes(router fiber.Router) {
-	orderGroup := router.Group("/orders")
+	orderGroup := router.Group("/orders", c.authMiddleware.RequirePermission(vo.PermOrderRead))
+	manage := c.authMiddleware.RequirePermission(vo.PermOrderManage)
+	idempotent := c.idempotencyMiddleware.Guard()
 
 	// Order routes
-	orderGroup.Post("/", c.CreateOrder)
+	orderGroup.Post("/", manage, idempotent, c.CreateOrder)
 	orderGroup.Get("/", c.ListOrders)
 	orderGroup.Get("/qr-code/:qr_code", c.GetOrderIDFromQRCode) // GET /orders/qr?code=some-qr-code
 	orderGroup.Get("/items", c.ListOrdersWithItems)
-	orderGroup.Get("/search", c.GetOrdersByStatus)        // GET /orders/search?status=open
-	orderGroup.Get("/date-range", c.GetOrdersByDateRange) // GET /orders/date-range?start_date=2024-01-01&end_date=2024-01-31
+	orderGroup.Get("/search", c.GetOrdersByStatus)                 // GET /orders/search?status=open
+	orderGroup.Get("/type/:orderType", c.GetOrdersByType)          // GET /orders/type/takeaway
+	orderGroup.Get("/date-range", c.GetOrdersByDateRange)          // GET /orders/date-range?start_date=2024-01-01&end_date=2024-01-31
+	orderGroup.Get("/adjustment-reasons", c.ListAdjustmentReasons) // reason codes for voids and comps
 	orderGroup.Get("/:id", c.GetOrder)
 	orderGroup.Get("/:id/items", c.GetOrderWithItems)
-	orderGroup.Put("/:id", c.UpdateOrder)
-	orderGroup.Put("/:id/close", c.CloseOrder)
-	orderGroup.Get("/:id/print/receipt", c.PrintOrderReceipt) // Print order by ID
-	orderGroup.Get("/:id/print/qrcode", c.PrintOrderQRCode)   // Print order by ID
+	orderGroup.Get("/:id/detail", c.GetOrderDetail)
+	orderGroup.Put("/:id", manage, c.UpdateOrder)
+	orderGroup.Put("/:id/close", manage, c.CloseOrder)
+	orderGroup.Put("/:id/reopen", manage, c.ReopenOrder)
+	orderGroup.Put("/:id/transfer", manage, c.TransferOrder)               // PUT /orders/1/transfer {"table_id": 5}
+	orderGroup.Put("/:id/merge", manage, c.MergeOrders)                    // PUT /orders/1/merge {"target_order_id": 2}
+	orderGroup.Put("/:id/guests", manage, c.UpdateGuestCount)              // PUT /orders/1/guests {"guest_count": 6, "override_seating": true}
+	orderGroup.Put("/:id/coupon", manage, c.ApplyCoupon)                   // PUT /orders/1/coupon {"code": "HAPPY10"}
+	orderGroup.Delete("/:id/coupon", manage, c.RemoveCoupon)               // DELETE /orders/1/coupon
+	orderGroup.Post("/:id/submit", manage, idempotent, c.SubmitRound)      // POST /orders/1/submit, sends the cart as the next round
+	orderGroup.Post("/:id/repeat", manage, idempotent, c.RepeatOrderItems) // POST /orders/1/repeat {"round": 2} or {"source_order_id": 7, "order_item_ids": [3, 4]}
+	orderGroup.Put("/:id/fire", manage, c.FireCourse)                      // PUT /orders/1/fire {"course": 2}, no body for every held item
+	orderGroup.Put("/:id/hold", manage, c.HoldCourse)                      // PUT /orders/1/hold {"course": 2}, no body for every pending item
+	orderGroup.Get("/:id/print/receipt", manage, c.PrintOrderReceipt)      // Print order by ID
+	orderGroup.Get("/:id/print/qrcode", manage, c.PrintOrderQRCode)        // Print order by ID
 	// Order by table routes
 	orderGroup.Get("/table/:tableId", c.ListOrdersByTable)
 	orderGroup.Get("/table/:tableId/open", c.GetOpenOrderByTable)
 
 	// Order items routes
 	// orderGroup.Post("/items", c.AddOrderItem)
-	orderGroup.Put("/items/:id", c.UpdateOrderItem)
-	orderGroup.Delete("/items/:id", c.RemoveOrderItem)
+	orderGroup.Put("/items/:id", manage, c.UpdateOrderItem)
+	orderGroup.Put("/items/:id/discount", manage, c.ApplyItemDiscount)
+	orderGroup.Put("/items/:id/comp", manage, c.CompOrderItem) // PUT /orders/items/1/comp {"reason_code": "staff_meal", "approval": {...}}
+	orderGroup.Delete("/items/:id", manage, c.RemoveOrderItem) // voids submitted items: {"reason_code": "wrong_order"}
 	orderGroup.Get("/:orderId/items", c.ListOrderItems)
 	orderGroup.Get("/:orderId/total", c.CalculateOrderTotal)
 }
 
+// RegisterRoutes registers the routes for the promotion controller
+func (c *PromotionController) RegisterRoutes(router fiber.Router) {
+	promotionGroup := router.Group("/promotions", c.authMiddleware.RequirePermission(vo.PermOrderRead))
+	manage := c.authMiddleware.RequirePermission(vo.PermPromotionManage)
+
+	promotionGroup.Post("/", manage, c.CreatePromotion)
+	promotionGroup.Get("/", c.ListPromotions) // GET /promotions?active=true
+	promotionGroup.Get("/:id", c.GetPromotion)
+	promotionGroup.Put("/:id", manage, c.UpdatePromotion)
+	promotionGroup.Delete("/:id", manage, c.DeletePromotion)
+}
+
 // RegisterRoutes registers the routes for the payment controller
 func (c *PaymentController) RegisterRoutes(router fiber.Router) {
-	paymentGroup := router.Group("/payments")
+	paymentGroup := router.Group("/payments", c.authMiddleware.RequirePermission(vo.PermPaymentRead))
+	idempotent := c.idempotencyMiddleware.Guard()
 
 	// Payment routes
-	paymentGroup.Post("/", c.ProcessPayment)
+	paymentGroup.Post("/", c.authMiddleware.RequirePermission(vo.PermPaymentManage), idempotent, c.ProcessPayment)
 	paymentGroup.Get("/", c.ListPayments)
 	paymentGroup.Get("/search", c.ListPaymentsByMethod)        // GET /payments/search?method=cash
 	paymentGroup.Get("/date-range", c.ListPaymentsByDateRange) // GET /payments/date-range?start_date=2024-01-01&end_date=2024-01-31
 	paymentGroup.Get("/:id", c.GetPayment)
-	paymentGroup.Get("/order/:orderId", c.GetPaymentByOrder)
+	paymentGroup.Get("/order/:orderId", c.ListPaymentsByOrder)
+	paymentGroup.Post("/order/:orderId/split", c.SplitBill)
+	paymentGroup.Get("/:id/print/receipt", c.authMiddleware.RequirePermission(vo.PermPaymentManage), c.PrintPaymentReceipt)
+	paymentGroup.Post("/:id/refund", c.authMiddleware.RequirePermission(vo.PermPaymentManage), idempotent, c.RefundPayment)
+}
+
+// RegisterRoutes registers the routes for the approval controller
+func (c *ApprovalController) RegisterRoutes(router fiber.Router) {
+	approvalGroup := router.Group("/approvals", c.authMiddleware.RequirePermission(vo.PermOrderRead))
+	grant := c.authMiddleware.RequirePermission(vo.PermApprovalGrant)
+
+	approvalGroup.Post("/", c.authMiddleware.RequirePermission(vo.PermOrderManage), c.RequestApproval)
+	approvalGroup.Get("/pending", grant, c.ListPendingApprovals)
+	approvalGroup.Get("/:id", c.GetApproval)
+	approvalGroup.Put("/:id/approve", grant, c.ApproveRequest)
+	approvalGroup.Put("/:id/reject", grant, c.RejectRequest)
+}
+
+// RegisterRoutes registers the routes for the API key controller
+func (c *APIKeyController) RegisterRoutes(router fiber.Router) {
+	apiKeyGroup := router.Group("/api-keys", c.authMiddleware.RequirePermission(vo.PermAPIKeyManage))
+
+	apiKeyGroup.Post("/", c.CreateAPIKey)
+	apiKeyGroup.Get("/", c.ListAPIKeys)
+	apiKeyGroup.Put("/:id/rotate", c.RotateAPIKey)
+	apiKeyGroup.Put("/:id/revoke", c.RevokeAPIKey)
+}
+
+// RegisterRoutes registers the routes for the shift controller
+func (c *ShiftController) RegisterRoutes(router fiber.Router) {
+	shiftGroup := router.Group("/shifts", c.authMiddleware.RequireAuth())
+
+	// Own shift
+	shiftGroup.Post("/clock-in", c.ClockIn)
+	shiftGroup.Post("/clock-out", c.ClockOut)
+	shiftGroup.Post("/breaks/start", c.StartBreak)
+	shiftGroup.Post("/breaks/end", c.EndBreak)
+	shiftGroup.Get("/me", c.GetCurrentShift)
+
+	// Staff shifts
+	manage := c.authMiddleware.RequirePermission(vo.PermShiftManage)
+	shiftGroup.Get("/", manage, c.ListShifts)
+	shiftGroup.Get("/:id", manage, c.GetShift)
+	shiftGroup.Get("/:id/summary", manage, c.GetShiftSummary)
+	shiftGroup.Put("/:id/close", manage, c.CloseShift)
+}
+
+// RegisterRoutes registers the routes for the audit controller
+func (c *AuditController) RegisterRoutes(router fiber.Router) {
+	auditGroup := router.Group("/audit-logs", c.authMiddleware.RequirePermission(vo.PermAuditRead))
+
+	auditGroup.Get("/", c.ListAuditLogs) // GET /audit-logs?action=payment.delete&actor_id=3&from=2024-01-01&to=2024-01-31
 }
 
 // RegisterRoutes registers the routes for the revenue controller
 func (c *RevenueController) RegisterRoutes(router fiber.Router) {
-	revenueGroup := router.Group("/revenue")
+	revenueGroup := router.Group("/revenue", c.authMiddleware.RequirePermission(vo.PermRevenueRead))
 
 	// Daily revenue routes
 	revenueGroup.Get("/daily", c.GetDailyRevenue)            // GET /revenue/daily?date=2024-01-01
@@ -87,18 +173,32 @@ func (c *RevenueController) RegisterRoutes(router fiber.Router) {
 
 	// Total revenue route
 	revenueGroup.Get("/total", c.GetTotalRevenue) // GET /revenue/total?start_date=2024-01-01&end_date=2024-12-31
+
+	// Revenue per order type
+	revenueGroup.Get("/order-types", c.GetRevenueByOrderType) // GET /revenue/order-types?start_date=2024-01-01&end_date=2024-12-31&order_type=delivery
+
+	// Discount given per promotion
+	revenueGroup.Get("/promotions", c.GetPromotionRevenue)        // GET /revenue/promotions?start_date=2024-01-01&end_date=2024-12-31
+	revenueGroup.Get("/item-discounts", c.GetItemDiscountRevenue) // GET /revenue/item-discounts?start_date=2024-01-01&end_date=2024-12-31
+
+	// Value of items voided and comped per reason code
+	revenueGroup.Get("/item-adjustments", c.GetItemAdjustmentRevenue) // GET /revenue/item-adjustments?start_date=2024-01-01&end_date=2024-12-31
+
+	// Guests served and average spend per cover
+	revenueGroup.Get("/covers", c.GetCoverRevenue) // GET /revenue/covers?start_date=2024-01-01&end_date=2024-12-31
 }
 
 // RegisterRoutes registers the routes for the table controller
 func (c *TableController) RegisterRoutes(router fiber.Router) {
-	tableGroup := router.Group("/tables")
+	tableGroup := router.Group("/tables", c.authMiddleware.RequirePermission(vo.PermTableRead))
+	manage := c.authMiddleware.RequirePermission(vo.PermTableManage)
 
 	// Table CRUD operations
-	tableGroup.Post("/", c.CreateTable)
+	tableGroup.Post("/", manage, c.CreateTable)
 	tableGroup.Get("/", c.ListTables)
 	tableGroup.Get("/:id", c.GetTable)
-	tableGroup.Put("/:id", c.UpdateTable)
-	tableGroup.Delete("/:id", c.DeleteTable)
+	tableGroup.Put("/:id", manage, c.UpdateTable)
+	tableGroup.Delete("/:id", manage, c.DeleteTable)
 
 	// Table by number
 	tableGroup.Get("/number/:number", c.GetTableByNumber)
@@ -119,10 +219,11 @@ func (c *MenuOptionController) RegisterRoutes(router fiber.Router) {
 	menuOptionGroup.Get("/search", c.GetMenuOptionsByType) // GET /menu-options/search?type=spice_level
 	menuOptionGroup.Get("/:id", c.GetMenuOption)
 
-	// Admin routes
-	menuOptionGroup.Post("/", c.CreateMenuOption)
-	menuOptionGroup.Put("/:id", c.UpdateMenuOption)
-	menuOptionGroup.Delete("/:id", c.DeleteMenuOption)
+	// Management routes
+	manage := c.authMiddleware.RequirePermission(vo.PermMenuManage)
+	menuOptionGroup.Post("/", manage, c.CreateMenuOption)
+	menuOptionGroup.Put("/:id", manage, c.UpdateMenuOption)
+	menuOptionGroup.Delete("/:id", manage, c.DeleteMenuOption)
 }
 
 // RegisterRoutes registers the routes for the option value controller
@@ -133,10 +234,11 @@ func (c *OptionValueController) RegisterRoutes(router fiber.Router) {
 	optionValueGroup.Get("/:id", c.GetOptionValue)
 	optionValueGroup.Get("/option/:optionId", c.GetOptionValuesByOptionID) // GET /option-values/option/1
 
-	// Admin routes
-	optionValueGroup.Post("/", c.CreateOptionValue)
-	optionValueGroup.Put("/:id", c.UpdateOptionValue)
-	optionValueGroup.Delete("/:id", c.DeleteOptionValue)
+	// Management routes
+	manage := c.authMiddleware.RequirePermission(vo.PermMenuManage)
+	optionValueGroup.Post("/", manage, c.CreateOptionValue)
+	optionValueGroup.Put("/:id", manage, c.UpdateOptionValue)
+	optionValueGroup.Delete("/:id", manage, c.DeleteOptionValue)
 }
 
 // RegisterRoutes registers the routes for the menu item option controller
@@ -144,22 +246,24 @@ func (c *MenuItemOptionController) RegisterRoutes(router fiber.Router) {
 	menuItemOptionGroup := router.Group("/menu-item-options")
 
 	// Routes
-	menuItemOptionGroup.Get("/item/:itemId", c.GetMenuItemOptions)                           // GET /menu-item-options/item/1
-	menuItemOptionGroup.Post("/", c.AddOptionToMenuItem)                                     // POST /menu-item-options
-	menuItemOptionGroup.Delete("/item/:itemId/option/:optionId", c.RemoveOptionFromMenuItem) // DELETE /menu-item-options/item/1/option/2
+	manage := c.authMiddleware.RequirePermission(vo.PermMenuManage)
+	menuItemOptionGroup.Get("/item/:itemId", c.GetMenuItemOptions)                                   // GET /menu-item-options/item/1
+	menuItemOptionGroup.Post("/", manage, c.AddOptionToMenuItem)                                     // POST /menu-item-options
+	menuItemOptionGroup.Delete("/item/:itemId/option/:optionId", manage, c.RemoveOptionFromMenuItem) // DELETE /menu-item-options/item/1/option/2
 }
 
 // RegisterRoutes registers the routes for the kitchen controller
 func (c *KitchenController) RegisterRoutes(router fiber.Router) {
-	kitchenGroup := router.Group("/kitchen")
+	kitchenGroup := router.Group("/kitchen", c.authMiddleware.RequirePermission(vo.PermKitchenAccess))
+	manage := c.authMiddleware.RequirePermission(vo.PermKitchenManage)
 
 	// Kitchen routes
 	kitchenGroup.Get("/", c.ListKitchenStatations)
-	kitchenGroup.Post("/", c.CreateKitchenStatation)
-	kitchenGroup.Put("/:id", c.UpdateKitchenStatation)
-	kitchenGroup.Delete("/", c.DeleteKitchenStatation)
+	kitchenGroup.Post("/", manage, c.CreateKitchenStatation)
+	kitchenGroup.Put("/:id", manage, c.UpdateKitchenStatation)
+	kitchenGroup.Delete("/", manage, c.DeleteKitchenStatation)
 
-	kitchenGroup.Get("/queue", c.GetKitchenQueue)                           // GET /kitchen/queue
+	kitchenGroup.Get("/queue", c.GetKitchenQueue)                           // GET /kitchen/queue?order_type=takeaway
 	kitchenGroup.Get("/items", c.GetOrderItemsByStatus)                     // GET /kitchen/items?status=preparing
 	kitchenGroup.Get("/station/orders", c.GetKitchenOrdersByStation)        // GET /kitchen/station?station=grill
 	kitchenGroup.Put("/items/:orderItemId/status", c.UpdateOrderItemStatus) // PUT /kitchen/items/1/status
@@ -174,12 +278,13 @@ func (c *CustomerController) RegisterRoutes(router fiber.Router) {
 	customerGroup.Get("/menu/items/:id", c.GetMenuItem)
 	customerGroup.Get("/menu/search", c.SearchMenuItems)
 	customerGroup.Get("/category", c.ListCategory)
-	// order
-
-	customerGroup.Post("/orders/items", c.ManageOrderItemList)
-	customerGroup.Get("/orders/status", c.GetOrderDetailByStatus)
-	customerGroup.Get("/orders", c.ListOrderItems)
-	customerGroup.Get("/orders/:id", c.GetOrderDetailWithOptions)
+	// order, scoped to the QR code sent in the X-Order-Token header
+	orderGroup := customerGroup.Group("/orders", c.customerMiddleware.RequireOrderToken())
+	orderGroup.Post("/items", c.idempotencyMiddleware.Guard(), c.ManageOrderItemList) // changes the cart
+	orderGroup.Post("/submit", c.idempotencyMiddleware.Guard(), c.SubmitRound)        // sends the cart to the kitchen as a round
+	orderGroup.Post("/repeat", c.idempotencyMiddleware.Guard(), c.RepeatOrderItems)   // copies a round or items into the cart, "same again"
+	orderGroup.Get("/", c.GetCurrentOrder)
+	orderGroup.Get("/:id", c.GetOrderDetailWithOptions)
 
 }
 
@@ -190,17 +295,29 @@ func (c *MenuWithOptionsController) RegisterRoutes(router fiber.Router) {
 	// Menu Items with Options
 	menuGroup := router.Group("/menu-with-options")
 
+	manage := c.authMiddleware.RequirePermission(vo.PermMenuManage)
+
 	// Menu Item Management with Options
-	menuGroup.Post("/items", c.CreateMenuItemWithOptions)                // POST /menu-with-options/items
-	menuGroup.Put("/items/:id", c.UpdateMenuItemWithOptions)             // PUT /menu-with-options/items/1
-	menuGroup.Get("/items/:id", c.GetMenuItemWithOptions)                // GET /menu-with-options/items/1
-	menuGroup.Get("/items", c.ListMenuItemsWithOptions)                  // GET /menu-with-options/items
-	menuGroup.Post("/items/bulk-assign", c.BulkAssignOptionsToMenuItems) // POST /menu-with-options/items/bulk-assign
+	menuGroup.Post("/items", manage, c.CreateMenuItemWithOptions)                // POST /menu-with-options/items
+	menuGroup.Put("/items/:id", manage, c.UpdateMenuItemWithOptions)             // PUT /menu-with-options/items/1
+	menuGroup.Get("/items/:id", c.GetMenuItemWithOptions)                        // GET /menu-with-options/items/1
+	menuGroup.Get("/items", c.ListMenuItemsWithOptions)                          // GET /menu-with-options/items
+	menuGroup.Post("/items/bulk-assign", manage, c.BulkAssignOptionsToMenuItems) // POST /menu-with-options/items/bulk-assign
 
 	// Option Management with Values
-	menuGroup.Post("/options", c.CreateOptionWithValues)       // POST /menu-with-options/options
-	menuGroup.Put("/options/:id", c.UpdateOptionWithValues)    // PUT /menu-with-options/options/1
-	menuGroup.Get("/options/:id", c.GetOptionWithValues)       // GET /menu-with-options/options/1
-	menuGroup.Get("/options", c.ListOptionsWithValues)         // GET /menu-with-options/options
-	menuGroup.Delete("/options/:id", c.DeleteOptionWithValues) // DELETE /menu-with-options/options/1
+	menuGroup.Post("/options", manage, c.CreateOptionWithValues)       // POST /menu-with-options/options
+	menuGroup.Put("/options/:id", manage, c.UpdateOptionWithValues)    // PUT /menu-with-options/options/1
+	menuGroup.Get("/options/:id", c.GetOptionWithValues)               // GET /menu-with-options/options/1
+	menuGroup.Get("/options", c.ListOptionsWithValues)                 // GET /menu-with-options/options
+	menuGroup.Delete("/options/:id", manage, c.DeleteOptionWithValues) // DELETE /menu-with-options/options/1
+}
+
+// RegisterRoutes registers the routes for the terminal controller
+func (c *TerminalController) RegisterRoutes(router fiber.Router) {
+	terminalGroup := router.Group("/terminals", c.authMiddleware.RequirePermission(vo.PermTerminalManage))
+
+	terminalGroup.Post("/", c.RegisterTerminal)
+	terminalGroup.Get("/", c.ListTerminals)
+	terminalGroup.Put("/:id/deactivate", c.DeactivateTerminal)
+	terminalGroup.Delete("/:id", c.DeleteTerminal)
 }
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+)
+
+// ShiftController handles HTTP requests for staff shifts
+type ShiftController struct {
+	shiftUseCase   usecase.ShiftUsecase
+	authMiddleware *middleware.AuthMiddleware
+	errorPresenter presenter.ErrorPresenter
+}
+
+// NewShiftController creates a new instance of ShiftController
+func NewShiftController(shiftUseCase usecase.ShiftUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *ShiftController {
+	return &ShiftController{
+		shiftUseCase:   shiftUseCase,
+		authMiddleware: authMiddleware,
+		errorPresenter: errorPresenter,
+	}
+}
+
+// ClockIn handles the current staff member clocking in
+func (c *ShiftController) ClockIn(ctx *fiber.Ctx) error {
+	var req usecase.ClockInRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return HandleError(ctx, err, c.errorPresenter)
+		}
+	}
+
+	response, err := c.shiftUseCase.ClockIn(ctx.Context(), &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "Clocked in successfully", response)
+}
+
+// ClockOut handles the current staff member clocking out
+func (c *ShiftController) ClockOut(ctx *fiber.Ctx) error {
+	var req usecase.ClockOutRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return HandleError(ctx, err, c.errorPresenter)
+		}
+	}
+
+	response, err := c.shiftUseCase.ClockOut(ctx.Context(), &req)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Clocked out successfully", response)
+}
+
+// StartBreak handles the current staff member going on break
+func (c *ShiftController) StartBreak(ctx *fiber.Ctx) error {
+	response, err := c.shiftUseCase.StartBreak(ctx.Context())
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Break started successfully", response)
+}
+
+// EndBreak handles the current staff member returning from break
+func (c *ShiftController) EndBreak(ctx *fiber.Ctx) error {
+	response, err := c.shiftUseCase.EndBreak(ctx.Context())
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Break ended successfully", response)
+}
+
+// GetCurrentShift handles getting the current staff member's open shift
+func (c *ShiftController) GetCurrentShift(ctx *fiber.Ctx) error {
+	response, err := c.shiftUseCase.GetCurrentShift(ctx.Context())
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Shift retrieved successfully", response)
+}
+
+// GetShift handles getting a shift by ID
+func (c *ShiftController) GetShift(ctx *fiber.Ctx) error {
+	shiftID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Shift ID format",
+		})
+	}
+
+	response, err := c.shiftUseCase.GetShift(ctx.Context(), shiftID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Shift retrieved successfully", response)
+}
+
+// GetShiftSummary handles getting the summary of a shift
+func (c *ShiftController) GetShiftSummary(ctx *fiber.Ctx) error {
+	shiftID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Shift ID format",
+		})
+	}
+
+	response, err := c.shiftUseCase.GetShiftSummary(ctx.Context(), shiftID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Shift summary retrieved successfully", response)
+}
+
+// CloseShift handles a manager clocking out another staff member's shift
+func (c *ShiftController) CloseShift(ctx *fiber.Ctx) error {
+	shiftID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid Shift ID format",
+		})
+	}
+
+	response, err := c.shiftUseCase.CloseShift(ctx.Context(), shiftID)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Shift closed successfully", response)
+}
+
+// ListShifts handles listing shifts filtered by staff member and date
+func (c *ShiftController) ListShifts(ctx *fiber.Ctx) error {
+	req := &usecase.ShiftFilterRequest{}
+
+	if userIDParam := ctx.Query("user_id"); userIDParam != "" {
+		userID, err := strconv.Atoi(userIDParam)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid user_id format",
+			})
+		}
+		req.UserID = &userID
+	}
+
+	if fromParam := ctx.Query("from"); fromParam != "" {
+		from, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid from format. Use YYYY-MM-DD",
+			})
+		}
+		req.From = &from
+	}
+
+	if toParam := ctx.Query("to"); toParam != "" {
+		to, err := time.Parse("2006-01-02", toParam)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid to format. Use YYYY-MM-DD",
+			})
+		}
+		// include the whole end day
+		to = to.Add(24*time.Hour - time.Nanosecond)
+		req.To = &to
+	}
+
+	limit, _ := strconv.Atoi(ctx.Query("limit", "50"))
+	offset, _ := strconv.Atoi(ctx.Query("offset", "0"))
+
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	response, err := c.shiftUseCase.ListShifts(ctx.Context(), req, limit, offset)
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Shifts retrieved successfully", response)
+}
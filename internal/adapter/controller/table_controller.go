@@ -5,6 +5,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
 )
@@ -13,14 +14,16 @@ import (
 type TableController struct {
 	tableUsecase   usecase.TableUsecase
 	qrCodeUsecase  usecase.QRCodeUsecase
+	authMiddleware *middleware.AuthMiddleware
 	errorPresenter presenter.ErrorPresenter
 }
 
 // NewTableController creates a new instance of TableController
-func NewTableController(tableUsecase usecase.TableUsecase, errorPresenter presenter.ErrorPresenter) *TableController {
+func NewTableController(tableUsecase usecase.TableUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *TableController {
 	return &TableController{
 		tableUsecase:   tableUsecase,
 		errorPresenter: errorPresenter,
+		authMiddleware: authMiddleware,
 	}
 }
 
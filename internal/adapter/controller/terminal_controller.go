@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+)
+
+// TerminalController handles HTTP requests related to shared POS terminals
+type TerminalController struct {
+	terminalUseCase usecase.TerminalUsecase
+	authMiddleware  *middleware.AuthMiddleware
+	errorPresenter  presenter.ErrorPresenter
+}
+
+// NewTerminalController creates a new instance of TerminalController
+func NewTerminalController(terminalUseCase usecase.TerminalUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *TerminalController {
+	return &TerminalController{
+		terminalUseCase: terminalUseCase,
+		authMiddleware:  authMiddleware,
+		errorPresenter:  errorPresenter,
+	}
+}
+
+// RegisterTerminal handles registering a shared terminal
+func (c *TerminalController) RegisterTerminal(ctx *fiber.Ctx) error {
+	var req dto.RegisterTerminalRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.Name == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Name is required",
+		})
+	}
+
+	response, err := c.terminalUseCase.RegisterTerminal(ctx.Context(), &usecase.RegisterTerminalRequest{
+		Name: req.Name,
+	})
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusCreated, "Terminal registered successfully", response)
+}
+
+// ListTerminals handles listing registered terminals
+func (c *TerminalController) ListTerminals(ctx *fiber.Ctx) error {
+	response, err := c.terminalUseCase.ListTerminals(ctx.Context())
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Terminals retrieved successfully", response)
+}
+
+// DeactivateTerminal handles deactivating a terminal
+func (c *TerminalController) DeactivateTerminal(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid terminal ID format",
+		})
+	}
+
+	if err := c.terminalUseCase.DeactivateTerminal(ctx.Context(), id); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Terminal deactivated successfully", nil)
+}
+
+// DeleteTerminal handles deleting a terminal
+func (c *TerminalController) DeleteTerminal(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid terminal ID format",
+		})
+	}
+
+	if err := c.terminalUseCase.DeleteTerminal(ctx.Context(), id); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Terminal deleted successfully", nil)
+}
@@ -5,20 +5,24 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/dto"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/middleware"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
 	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
 // UserController handles HTTP requests related to user operations
 type UserController struct {
 	userUseCase    usecase.UserUsecase
+	authMiddleware *middleware.AuthMiddleware
 	errorPresenter presenter.ErrorPresenter
 }
 
 // NewUserController creates a new instance of UserController
-func NewUserController(userUseCase usecase.UserUsecase, errorPresenter presenter.ErrorPresenter) *UserController {
+func NewUserController(userUseCase usecase.UserUsecase, authMiddleware *middleware.AuthMiddleware, errorPresenter presenter.ErrorPresenter) *UserController {
 	return &UserController{
 		userUseCase:    userUseCase,
+		authMiddleware: authMiddleware,
 		errorPresenter: errorPresenter,
 	}
 }
@@ -28,23 +32,33 @@ func (c *UserController) RegisterRoutes(router fiber.Router) {
 	userGroup := router.Group("/users")
 
 	// Public routes
-	userGroup.Post("/register", c.Register)
 	userGroup.Post("/login", c.Login)
+	userGroup.Post("/pin-login", c.PinLogin)
+	userGroup.Post("/refresh", c.Refresh)
+	userGroup.Post("/verify-email", c.VerifyEmail)
+	userGroup.Post("/forgot-password", c.ForgotPassword)
+	userGroup.Post("/reset-password", c.ResetPassword)
 
 	// Protected routes (require authentication)
-	userGroup.Get("/me", c.GetMe)
-	userGroup.Put("/me", c.UpdateMe)
-	userGroup.Put("/me/password", c.ChangeMyPassword)
-
-	// Admin routes (require admin role)
-	userGroup.Get("/", c.GetUsersByRole)
-	userGroup.Get("/:id", c.GetProfile)
-	userGroup.Put("/:id", c.UpdateProfile)
-	userGroup.Put("/:id/password", c.ChangePassword)
-	userGroup.Put("/:id/verify-email", c.VerifyEmail)
-	userGroup.Put("/:id/deactivate", c.DeactivateUser)
-	userGroup.Put("/:id/activate", c.ActivateUser)
-	userGroup.Delete("/:id", c.DeleteUser)
+	requireAuth := c.authMiddleware.RequireAuth()
+	userGroup.Post("/logout", requireAuth, c.Logout)
+	userGroup.Get("/me", requireAuth, c.GetMe)
+	userGroup.Put("/me", requireAuth, c.UpdateMe)
+	userGroup.Put("/me/password", requireAuth, c.ChangeMyPassword)
+	userGroup.Put("/me/pin", requireAuth, c.SetMyPin)
+	userGroup.Post("/me/verify-email", requireAuth, c.SendMyEmailVerification)
+
+	// Staff administration routes
+	manage := c.authMiddleware.RequirePermission(vo.PermUserManage)
+	userGroup.Post("/register", manage, c.Register)
+	userGroup.Get("/", manage, c.GetUsersByRole)
+	userGroup.Get("/:id", manage, c.GetProfile)
+	userGroup.Put("/:id", manage, c.UpdateProfile)
+	userGroup.Put("/:id/password", manage, c.ChangePassword)
+	userGroup.Post("/:id/verify-email", manage, c.SendEmailVerification)
+	userGroup.Put("/:id/deactivate", manage, c.DeactivateUser)
+	userGroup.Put("/:id/activate", manage, c.ActivateUser)
+	userGroup.Delete("/:id", manage, c.DeleteUser)
 }
 
 // Register handles user registration
@@ -88,8 +102,35 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 	}
 
 	response, err := c.userUseCase.Login(ctx.Context(), &usecase.LoginRequest{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		IPAddress: ctx.IP(),
+	})
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Login successful", response)
+}
+
+// PinLogin handles PIN authentication on a registered shared terminal
+func (c *UserController) PinLogin(ctx *fiber.Ctx) error {
+	var req dto.PinLoginRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.TerminalToken == "" || req.UserID <= 0 || req.Pin == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "TerminalToken, UserID, and Pin are required",
+		})
+	}
+
+	response, err := c.userUseCase.PinLogin(ctx.Context(), &usecase.PinLoginRequest{
+		TerminalToken: req.TerminalToken,
+		UserID:        req.UserID,
+		Pin:           req.Pin,
 	})
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
@@ -98,6 +139,47 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 	return SuccessResp(ctx, fiber.StatusOK, "Login successful", response)
 }
 
+// Refresh handles exchanging a refresh token for a new token pair
+func (c *UserController) Refresh(ctx *fiber.Ctx) error {
+	var req dto.RefreshTokenRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.RefreshToken == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "RefreshToken is required",
+		})
+	}
+
+	response, err := c.userUseCase.RefreshToken(ctx.Context(), &usecase.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Token refreshed successfully", response)
+}
+
+// Logout handles revoking the current session
+func (c *UserController) Logout(ctx *fiber.Ctx) error {
+	sessionID, ok := ctx.Locals(middleware.LocalSessionID).(string)
+	if !ok || sessionID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
+			Status:  fiber.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	if err := c.userUseCase.Logout(ctx.Context(), sessionID); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Logout successful", nil)
+}
+
 // GetProfile handles getting user profile
 func (c *UserController) GetProfile(ctx *fiber.Ctx) error {
 	userIDParam := ctx.Params("id")
@@ -199,8 +281,8 @@ func (c *UserController) ChangePassword(ctx *fiber.Ctx) error {
 	return SuccessResp(ctx, fiber.StatusOK, "Password changed successfully", nil)
 }
 
-// VerifyEmail handles email verification
-func (c *UserController) VerifyEmail(ctx *fiber.Ctx) error {
+// SendEmailVerification handles sending a user an email verification link
+func (c *UserController) SendEmailVerification(ctx *fiber.Ctx) error {
 	userIDParam := ctx.Params("id")
 	if userIDParam == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
@@ -217,14 +299,106 @@ func (c *UserController) VerifyEmail(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err = c.userUseCase.VerifyEmail(ctx.Context(), userID)
+	err = c.userUseCase.SendEmailVerification(ctx.Context(), userID)
 	if err != nil {
 		return HandleError(ctx, err, c.errorPresenter)
 	}
 
+	return SuccessResp(ctx, fiber.StatusOK, "Verification email sent successfully", nil)
+}
+
+// SendMyEmailVerification handles sending the current user an email verification link
+func (c *UserController) SendMyEmailVerification(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(middleware.LocalUserID).(int)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
+			Status:  fiber.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	if err := c.userUseCase.SendEmailVerification(ctx.Context(), userID); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Verification email sent successfully", nil)
+}
+
+// VerifyEmail handles redeeming an email verification link
+func (c *UserController) VerifyEmail(ctx *fiber.Ctx) error {
+	var req dto.VerifyEmailRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.Token == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Token is required",
+		})
+	}
+
+	if err := c.userUseCase.VerifyEmail(ctx.Context(), &usecase.VerifyEmailRequest{Token: req.Token}); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
 	return SuccessResp(ctx, fiber.StatusOK, "Email verified successfully", nil)
 }
 
+// ForgotPassword handles requesting a password reset link
+func (c *UserController) ForgotPassword(ctx *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.Email == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Email is required",
+		})
+	}
+
+	if err := c.userUseCase.ForgotPassword(ctx.Context(), &usecase.ForgotPasswordRequest{Email: req.Email}); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "If the email has an account, a password reset link has been sent", nil)
+}
+
+// ResetPassword handles setting a new password with a password reset link
+func (c *UserController) ResetPassword(ctx *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.Token == "" || req.NewPassword == "" || req.ConfirmPassword == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Token, NewPassword, and ConfirmPassword are required",
+		})
+	}
+
+	if req.NewPassword != req.ConfirmPassword {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "NewPassword and ConfirmPassword do not match",
+		})
+	}
+
+	err := c.userUseCase.ResetPassword(ctx.Context(), &usecase.ResetPasswordRequest{
+		Token:           req.Token,
+		NewPassword:     req.NewPassword,
+		ConfirmPassword: req.ConfirmPassword,
+	})
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "Password reset successfully", nil)
+}
+
 // DeactivateUser handles user deactivation
 func (c *UserController) DeactivateUser(ctx *fiber.Ctx) error {
 	userIDParam := ctx.Params("id")
@@ -342,8 +516,7 @@ func (c *UserController) DeleteUser(ctx *fiber.Ctx) error {
 
 // GetMe handles getting current user profile (from JWT token)
 func (c *UserController) GetMe(ctx *fiber.Ctx) error {
-	// Assuming you have middleware that sets user ID in context
-	userID := ctx.Locals("userID")
+	userID := ctx.Locals(middleware.LocalUserID)
 	if userID == nil {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
 			Status:  fiber.StatusUnauthorized,
@@ -369,8 +542,7 @@ func (c *UserController) GetMe(ctx *fiber.Ctx) error {
 
 // UpdateMe handles updating current user profile
 func (c *UserController) UpdateMe(ctx *fiber.Ctx) error {
-	// Assuming you have middleware that sets user ID in context
-	userID := ctx.Locals("userID")
+	userID := ctx.Locals(middleware.LocalUserID)
 	if userID == nil {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
 			Status:  fiber.StatusUnauthorized,
@@ -403,8 +575,7 @@ func (c *UserController) UpdateMe(ctx *fiber.Ctx) error {
 
 // ChangeMyPassword handles changing current user password
 func (c *UserController) ChangeMyPassword(ctx *fiber.Ctx) error {
-	// Assuming you have middleware that sets user ID in context
-	userID := ctx.Locals("userID")
+	userID := ctx.Locals(middleware.LocalUserID)
 	if userID == nil {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
 			Status:  fiber.StatusUnauthorized,
@@ -443,3 +614,36 @@ func (c *UserController) ChangeMyPassword(ctx *fiber.Ctx) error {
 
 	return SuccessResp(ctx, fiber.StatusOK, "Password changed successfully", nil)
 }
+
+// SetMyPin handles setting the current user's quick-login PIN
+func (c *UserController) SetMyPin(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(middleware.LocalUserID).(int)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
+			Status:  fiber.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	var req dto.SetPinRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	if req.CurrentPassword == "" || req.Pin == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "CurrentPassword and Pin are required",
+		})
+	}
+
+	err := c.userUseCase.SetPin(ctx.Context(), userID, &usecase.SetPinRequest{
+		CurrentPassword: req.CurrentPassword,
+		Pin:             req.Pin,
+	})
+	if err != nil {
+		return HandleError(ctx, err, c.errorPresenter)
+	}
+
+	return SuccessResp(ctx, fiber.StatusOK, "PIN set successfully", nil)
+}
@@ -37,7 +37,7 @@ type ConfirmResponse struct {
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
-	Role     string `json:"role" validate:"required,oneof=candidate company_hr admin"`
+	Role     string `json:"role" validate:"required,oneof=owner manager cashier waiter chef admin"`
 }
 
 // LoginRequest represents user login request DTO
@@ -46,6 +46,23 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// VerifyEmailRequest represents email verification request DTO
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ForgotPasswordRequest represents password reset link request DTO
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest represents password reset request DTO
+type ResetPasswordRequest struct {
+	Token           string `json:"token" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
+}
+
 // UpdateProfileRequest represents user profile update request DTO
 type UpdateProfileRequest struct {
 	Email string `json:"email,omitempty" validate:"omitempty,email"`
@@ -72,8 +89,30 @@ type UserResponse struct {
 
 // LoginResponse represents login response with token
 type LoginResponse struct {
-	User  *UserResponse `json:"user"`
-	Token string        `json:"token"`
+	User                  *UserResponse `json:"user"`
+	Token                 string        `json:"token"`
+	TokenType             string        `json:"token_type"`
+	ExpiresAt             time.Time     `json:"expires_at"`
+	RefreshToken          string        `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time     `json:"refresh_token_expires_at"`
+}
+
+// PinLoginRequest represents PIN login request DTO for shared terminals
+type PinLoginRequest struct {
+	TerminalToken string `json:"terminal_token" validate:"required"`
+	UserID        int    `json:"user_id" validate:"required,gt=0"`
+	Pin           string `json:"pin" validate:"required,numeric,min=4,max=6"`
+}
+
+// SetPinRequest represents PIN change request DTO
+type SetPinRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	Pin             string `json:"pin" validate:"required,numeric,min=4,max=6"`
+}
+
+// RefreshTokenRequest represents refresh token exchange request DTO
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 // UsersListResponse represents paginated users list response
@@ -145,11 +184,18 @@ type MenuItemListResponse struct {
 
 // Order DTOs
 type CreateOrderRequest struct {
-	TableID int `json:"table_id" validate:"required,gt=0"`
+	TableID         int    `json:"table_id" validate:"omitempty,gt=0"` // required for dine-in orders
+	OrderType       string `json:"order_type,omitempty" validate:"omitempty,oneof=dine_in takeaway delivery pickup"`
+	CustomerName    string `json:"customer_name,omitempty" validate:"max=100"`
+	CustomerPhone   string `json:"customer_phone,omitempty" validate:"max=20"`
+	DeliveryAddress string `json:"delivery_address,omitempty" validate:"max=500"`
+	GuestCount      int    `json:"guest_count,omitempty" validate:"gte=0"`
+	OverrideSeating bool   `json:"override_seating,omitempty"`
 }
 
 type UpdateOrderRequest struct {
-	Status string `json:"status" validate:"required,oneof=open closed"`
+	Status  string `json:"status" validate:"required,oneof=open ordered completed cancelled"`
+	Version *int   `json:"version,omitempty"`
 }
 
 type OrderResponse struct {
@@ -194,6 +240,22 @@ type AddOrderItemRequest struct {
 type UpdateOrderItemRequest struct {
 	Quantity int    `json:"quantity" validate:"required,gt=0"`
 	Notes    string `json:"notes,omitempty"`
+	Version  *int   `json:"version,omitempty"`
+}
+
+type RemoveOrderItemRequest struct {
+	ReasonCode string           `json:"reason_code,omitempty"` // required to void a submitted item
+	Approval   *ApprovalRequest `json:"approval,omitempty"`
+	Version    *int             `json:"version,omitempty"`
+}
+
+// ApprovalRequest carries a manager's sign-off for voids, comps, discounts, reopens and refunds
+type ApprovalRequest struct {
+	ApprovalID *int   `json:"approval_id,omitempty"`
+	ManagerID  int    `json:"manager_id,omitempty"`
+	ManagerPin string `json:"manager_pin,omitempty"`
+	ReasonCode string `json:"reason_code,omitempty"`
+	Note       string `json:"note,omitempty"`
 }
 
 type OrderItemResponse struct {
@@ -252,9 +314,10 @@ type QRCodeScanResponse struct {
 
 // Payment DTOs
 type ProcessPaymentRequest struct {
-	OrderID int     `json:"order_id" validate:"required,gt=0"`
-	Amount  float64 `json:"amount" validate:"required,gt=0"`
-	Method  string  `json:"method" validate:"required,oneof=cash credit_card wallet"`
+	OrderID      int     `json:"order_id" validate:"required,gt=0"`
+	Amount       float64 `json:"amount" validate:"required,gt=0"`
+	Method       string  `json:"method" validate:"required,oneof=cash credit_card wallet"`
+	OrderItemIDs []int   `json:"order_item_ids,omitempty"`
 }
 
 type PaymentResponse struct {
@@ -320,3 +383,8 @@ type UpdateKitchenStatationRequest struct {
 	Name        string `json:"name" validate:"required,min=1,max=100"`
 	IsAvailable bool   `json:"is_available"`
 }
+
+// Terminal DTOs
+type RegisterTerminalRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// Keys used to store the authenticated user in fiber locals
+const (
+	LocalUserID     = usecase.CtxKeyUserID
+	LocalUserRole   = usecase.CtxKeyUserRole
+	LocalSessionID  = usecase.CtxKeySessionID
+	LocalTerminalID = usecase.CtxKeyTerminalID
+	LocalAPIKeyID   = usecase.CtxKeyAPIKeyID
+	LocalScopes     = usecase.CtxKeyScopes
+)
+
+// HeaderAPIKey carries the API key of an integration instead of a bearer token
+const HeaderAPIKey = "X-API-Key"
+
+// AuthMiddleware authenticates requests using bearer access tokens or API keys
+type AuthMiddleware struct {
+	userUseCase    usecase.UserUsecase
+	apiKeyUseCase  usecase.APIKeyUsecase
+	errorPresenter presenter.ErrorPresenter
+}
+
+// NewAuthMiddleware creates a new instance of AuthMiddleware
+func NewAuthMiddleware(userUseCase usecase.UserUsecase, apiKeyUseCase usecase.APIKeyUsecase, errorPresenter presenter.ErrorPresenter) *AuthMiddleware {
+	return &AuthMiddleware{
+		userUseCase:    userUseCase,
+		apiKeyUseCase:  apiKeyUseCase,
+		errorPresenter: errorPresenter,
+	}
+}
+
+// RequireAuth rejects requests without a valid access token and stores the
+// authenticated user in the request locals
+func (m *AuthMiddleware) RequireAuth() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if err := m.authenticate(ctx); err != nil {
+			return m.presentError(ctx, err)
+		}
+		return ctx.Next()
+	}
+}
+
+// RequirePermission authenticates the request and rejects it unless the
+// caller's role, or the API key's scopes, grant at least one of the permissions
+func (m *AuthMiddleware) RequirePermission(permissions ...vo.Permission) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if err := m.authenticate(ctx); err != nil {
+			return m.presentError(ctx, err)
+		}
+
+		role, _ := ctx.Locals(LocalUserRole).(string)
+		scopes, isAPIKey := ctx.Locals(LocalScopes).([]vo.Permission)
+		if isAPIKey {
+			role = "api_key"
+		}
+		userRole := vo.UserRole(role)
+		for _, permission := range permissions {
+			if isAPIKey && slices.Contains(scopes, permission) {
+				return ctx.Next()
+			}
+			if !isAPIKey && userRole.HasPermission(permission) {
+				return ctx.Next()
+			}
+		}
+
+		required := make([]string, len(permissions))
+		for i, permission := range permissions {
+			required[i] = permission.String()
+		}
+		return m.presentError(ctx, errs.ErrPermissionDeniedWithContext(role, strings.Join(required, ",")))
+	}
+}
+
+// authenticate resolves the caller from the access token or API key once per request
+func (m *AuthMiddleware) authenticate(ctx *fiber.Ctx) error {
+	if _, ok := ctx.Locals(LocalUserID).(int); ok {
+		return nil
+	}
+	if _, ok := ctx.Locals(LocalAPIKeyID).(int); ok {
+		return nil
+	}
+
+	if apiKey := ctx.Get(HeaderAPIKey); apiKey != "" {
+		authKey, err := m.apiKeyUseCase.AuthenticateAPIKey(ctx.Context(), apiKey)
+		if err != nil {
+			return err
+		}
+
+		ctx.Locals(LocalAPIKeyID, authKey.APIKeyID)
+		ctx.Locals(LocalScopes, authKey.Scopes)
+		return nil
+	}
+
+	authUser, err := m.userUseCase.Authenticate(ctx.Context(), bearerToken(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.Locals(LocalUserID, authUser.UserID)
+	ctx.Locals(LocalUserRole, authUser.Role)
+	ctx.Locals(LocalSessionID, authUser.SessionID)
+	if authUser.TerminalID != 0 {
+		ctx.Locals(LocalTerminalID, authUser.TerminalID)
+	}
+	return nil
+}
+
+func (m *AuthMiddleware) presentError(ctx *fiber.Ctx, err error) error {
+	errorResp := m.errorPresenter.PresentError(err)
+	return ctx.Status(errorResp.Status).JSON(errorResp)
+}
+
+// bearerToken extracts the token from the Authorization header
+func bearerToken(ctx *fiber.Ctx) string {
+	header := ctx.Get(fiber.HeaderAuthorization)
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
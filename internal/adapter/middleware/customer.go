@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	usecase "github.com/hydr0g3nz/poc_pos_restuarant/internal/application"
+)
+
+const (
+	// LocalOrderID holds the order the customer's QR code grants access to
+	LocalOrderID = "orderID"
+	// HeaderOrderToken carries the order QR code on customer requests
+	HeaderOrderToken = "X-Order-Token"
+)
+
+// CustomerMiddleware authenticates diners by the QR code of their order
+type CustomerMiddleware struct {
+	orderUseCase   usecase.OrderUsecase
+	errorPresenter presenter.ErrorPresenter
+}
+
+// NewCustomerMiddleware creates a new instance of CustomerMiddleware
+func NewCustomerMiddleware(orderUseCase usecase.OrderUsecase, errorPresenter presenter.ErrorPresenter) *CustomerMiddleware {
+	return &CustomerMiddleware{
+		orderUseCase:   orderUseCase,
+		errorPresenter: errorPresenter,
+	}
+}
+
+// RequireOrderToken rejects requests without a valid order QR code and
+// stores the resolved order ID in the request locals
+func (m *CustomerMiddleware) RequireOrderToken() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		orderID, err := m.orderUseCase.AuthenticateOrderToken(ctx.Context(), ctx.Get(HeaderOrderToken))
+		if err != nil {
+			errorResp := m.errorPresenter.PresentError(err)
+			return ctx.Status(errorResp.Status).JSON(errorResp)
+		}
+
+		ctx.Locals(LocalOrderID, orderID)
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/config"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+)
+
+const (
+	// HeaderIdempotencyKey carries the client's key for a request it may retry
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed marks responses replayed from an earlier request
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyMiddleware replays the original response to requests retried
+// with the same Idempotency-Key header
+type IdempotencyMiddleware struct {
+	store          infra.IdempotencyStore
+	config         config.IdempotencyConfig
+	logger         infra.Logger
+	errorPresenter presenter.ErrorPresenter
+}
+
+// NewIdempotencyMiddleware creates a new instance of IdempotencyMiddleware
+func NewIdempotencyMiddleware(store infra.IdempotencyStore, cfg config.IdempotencyConfig, logger infra.Logger, errorPresenter presenter.ErrorPresenter) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		store:          store,
+		config:         cfg,
+		logger:         logger,
+		errorPresenter: errorPresenter,
+	}
+}
+
+// Guard runs a request sent with an Idempotency-Key once. Retries get the
+// stored response, while a retry that arrives before the first request
+// finished is rejected. Requests without the header run as usual. Keys are
+// scoped to the caller, so callers never see each other's responses.
+func (m *IdempotencyMiddleware) Guard() fiber.Handler {
+	ttl := time.Duration(m.config.TTL) * time.Hour
+	lockTimeout := time.Duration(m.config.LockTimeout) * time.Second
+
+	return func(ctx *fiber.Ctx) error {
+		key := strings.TrimSpace(ctx.Get(HeaderIdempotencyKey))
+		if key == "" || ttl <= 0 {
+			return ctx.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return m.reject(ctx, errs.ErrInvalidIdempotencyKey)
+		}
+
+		storeKey := "idempotency:" + callerKey(ctx) + ":" + key
+		fingerprint := requestFingerprint(ctx)
+
+		record, err := m.store.Claim(ctx.Context(), storeKey, fingerprint, lockTimeout)
+		if err != nil {
+			// Keep serving when the key store is unavailable
+			m.logger.Error("Error claiming idempotency key", "error", err, "path", ctx.Path())
+			return ctx.Next()
+		}
+		if record != nil {
+			switch {
+			case record.Fingerprint != fingerprint:
+				return m.reject(ctx, errs.ErrIdempotencyKeyReused)
+			case !record.Completed:
+				return m.reject(ctx, errs.ErrIdempotencyKeyInFlight)
+			}
+			ctx.Set(HeaderIdempotentReplayed, "true")
+			ctx.Set(fiber.HeaderContentType, record.ContentType)
+			return ctx.Status(record.StatusCode).Send(record.Body)
+		}
+
+		if err := ctx.Next(); err != nil {
+			m.release(ctx, storeKey)
+			return err
+		}
+
+		// Failures on our side are not stored, so the client may retry them
+		status := ctx.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			m.release(ctx, storeKey)
+			return nil
+		}
+
+		err = m.store.Complete(ctx.Context(), storeKey, &infra.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			StatusCode:  status,
+			ContentType: string(ctx.Response().Header.ContentType()),
+			Body:        append([]byte(nil), ctx.Response().Body()...),
+		}, ttl)
+		if err != nil {
+			m.logger.Error("Error storing idempotent response", "error", err, "path", ctx.Path())
+		}
+		return nil
+	}
+}
+
+func (m *IdempotencyMiddleware) reject(ctx *fiber.Ctx, err error) error {
+	errorResp := m.errorPresenter.PresentError(err)
+	return ctx.Status(errorResp.Status).JSON(errorResp)
+}
+
+func (m *IdempotencyMiddleware) release(ctx *fiber.Ctx, storeKey string) {
+	if err := m.store.Release(ctx.Context(), storeKey); err != nil {
+		m.logger.Error("Error releasing idempotency key", "error", err, "path", ctx.Path())
+	}
+}
+
+// callerKey identifies who sent the request: the signed-in user or API key,
+// the order of a customer's QR code, or else the client IP
+func callerKey(ctx *fiber.Ctx) string {
+	if userID, ok := ctx.Locals(LocalUserID).(int); ok {
+		return "user:" + strconv.Itoa(userID)
+	}
+	if apiKeyID, ok := ctx.Locals(LocalAPIKeyID).(int); ok {
+		return "api_key:" + strconv.Itoa(apiKeyID)
+	}
+	if orderID, ok := ctx.Locals(LocalOrderID).(int); ok {
+		return "order:" + strconv.Itoa(orderID)
+	}
+	return "ip:" + ctx.IP()
+}
+
+// requestFingerprint hashes the method, path and body of the request
+func requestFingerprint(ctx *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(ctx.Method() + " " + ctx.OriginalURL() + "\n"))
+	hash.Write(ctx.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hydr0g3nz/poc_pos_restuarant/config"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/presenter"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+)
+
+// RateLimitMiddleware limits how many requests each client may send
+type RateLimitMiddleware struct {
+	rateLimiter    infra.RateLimiter
+	logger         infra.Logger
+	errorPresenter presenter.ErrorPresenter
+}
+
+// NewRateLimitMiddleware creates a new instance of RateLimitMiddleware
+func NewRateLimitMiddleware(rateLimiter infra.RateLimiter, logger infra.Logger, errorPresenter presenter.ErrorPresenter) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		rateLimiter:    rateLimiter,
+		logger:         logger,
+		errorPresenter: errorPresenter,
+	}
+}
+
+// Limit allows each client IP quota.Requests requests per quota.Window on
+// the routes it guards. Groups with the same name share one quota.
+func (m *RateLimitMiddleware) Limit(name string, quota config.RateLimitQuota) fiber.Handler {
+	window := time.Duration(quota.Window) * time.Second
+
+	return func(ctx *fiber.Ctx) error {
+		if quota.Requests <= 0 || window <= 0 {
+			return ctx.Next()
+		}
+
+		result, err := m.rateLimiter.Allow(ctx.Context(), "rate_limit:"+name+":"+ctx.IP(), quota.Requests, window)
+		if err != nil {
+			// Keep serving when the limiter store is unavailable
+			m.logger.Error("Error checking rate limit", "error", err, "group", name)
+			return ctx.Next()
+		}
+
+		ctx.Set("X-RateLimit-Limit", strconv.Itoa(quota.Requests))
+		ctx.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		if !result.Allowed {
+			retryAfter := int(result.RetryAfter.Seconds() + 0.5)
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+			errorResp := m.errorPresenter.PresentError(errs.ErrRateLimitExceededWithRetry(result.RetryAfter))
+			return ctx.Status(errorResp.Status).JSON(errorResp)
+		}
+
+		return ctx.Next()
+	}
+}
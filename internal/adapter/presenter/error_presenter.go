@@ -3,6 +3,7 @@ package presenter
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -52,7 +53,8 @@ func (p *errorPresenter) PresentError(err error) *ErrorResponse {
 	}
 
 	// Handle domain errors
-	if domainErr, ok := err.(errs.DomainError); ok {
+	var domainErr errs.DomainError
+	if errors.As(err, &domainErr) {
 		response.Status = domainErr.HTTPStatus()
 		response.Code = domainErr.Code()
 		response.Message = domainErr.Message()
@@ -0,0 +1,133 @@
+// internal/adapter/repository/api_key_repository.go
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	baseRepository
+}
+
+func NewAPIKeyRepository(db *gorm.DB) repository.APIKeyRepository {
+	return &apiKeyRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	dbKey := r.entityToModel(key)
+
+	if err := getDB(r.db, ctx).Create(dbKey).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbKey), nil
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, id int) (*entity.APIKey, error) {
+	var dbKey model.APIKey
+
+	if err := getDB(r.db, ctx).First(&dbKey, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbKey), nil
+}
+
+func (r *apiKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	var dbKey model.APIKey
+
+	if err := getDB(r.db, ctx).Where("key_hash = ?", keyHash).First(&dbKey).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbKey), nil
+}
+
+func (r *apiKeyRepository) Update(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	dbKey := r.entityToModel(key)
+
+	if err := getDB(r.db, ctx).Save(dbKey).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbKey), nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*entity.APIKey, error) {
+	var dbKeys []model.APIKey
+
+	if err := getDB(r.db, ctx).Order("id").Find(&dbKeys).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]*entity.APIKey, len(dbKeys))
+	for i := range dbKeys {
+		keys[i] = r.modelToEntity(&dbKeys[i])
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, id int) error {
+	return getDB(r.db, ctx).Model(&model.APIKey{}).Where("id = ?", id).Update("last_used_at", time.Now()).Error
+}
+
+// Helper methods
+func (r *apiKeyRepository) entityToModel(key *entity.APIKey) *model.APIKey {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = scope.String()
+	}
+
+	return &model.APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		KeyHash:    key.KeyHash,
+		Scopes:     strings.Join(scopes, ","),
+		CreatedBy:  key.CreatedBy,
+		LastUsedAt: key.LastUsedAt,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+		UpdatedAt:  key.UpdatedAt,
+	}
+}
+
+func (r *apiKeyRepository) modelToEntity(dbKey *model.APIKey) *entity.APIKey {
+	var scopes []vo.Permission
+	for _, scope := range strings.Split(dbKey.Scopes, ",") {
+		if scope != "" {
+			scopes = append(scopes, vo.Permission(scope))
+		}
+	}
+
+	return &entity.APIKey{
+		ID:         dbKey.ID,
+		Name:       dbKey.Name,
+		Prefix:     dbKey.Prefix,
+		KeyHash:    dbKey.KeyHash,
+		Scopes:     scopes,
+		CreatedBy:  dbKey.CreatedBy,
+		LastUsedAt: dbKey.LastUsedAt,
+		ExpiresAt:  dbKey.ExpiresAt,
+		RevokedAt:  dbKey.RevokedAt,
+		CreatedAt:  dbKey.CreatedAt,
+		UpdatedAt:  dbKey.UpdatedAt,
+	}
+}
@@ -0,0 +1,140 @@
+// internal/adapter/repository/approval_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"gorm.io/gorm"
+)
+
+type approvalRepository struct {
+	baseRepository
+}
+
+func NewApprovalRepository(db *gorm.DB) repository.ApprovalRepository {
+	return &approvalRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *approvalRepository) Create(ctx context.Context, approval *entity.Approval) (*entity.Approval, error) {
+	dbApproval := r.entityToModel(approval)
+
+	if err := getDB(r.db, ctx).Create(dbApproval).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbApproval), nil
+}
+
+func (r *approvalRepository) GetByID(ctx context.Context, id int) (*entity.Approval, error) {
+	var dbApproval model.Approval
+
+	if err := getDB(r.db, ctx).First(&dbApproval, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbApproval), nil
+}
+
+// Update saves the approval only if it still has the status it was read
+// with, so each approval is decided and used once. When another request got
+// there first it returns ErrApprovalAlreadyDecided for a pending approval and
+// ErrApprovalNotGranted for an approved one.
+func (r *approvalRepository) Update(ctx context.Context, approval *entity.Approval, from vo.ApprovalStatus) (*entity.Approval, error) {
+	dbApproval := r.entityToModel(approval)
+
+	result := getDB(r.db, ctx).Model(dbApproval).Where("status = ?", from.String()).Select("*").Updates(dbApproval)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		if from == vo.ApprovalStatusPending {
+			return nil, errs.ErrApprovalAlreadyDecided
+		}
+		return nil, errs.ErrApprovalNotGranted
+	}
+
+	return r.modelToEntity(dbApproval), nil
+}
+
+func (r *approvalRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]*entity.Approval, error) {
+	var dbApprovals []model.Approval
+
+	query := getDB(r.db, ctx).Where("status = ?", status).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&dbApprovals).Error; err != nil {
+		return nil, err
+	}
+
+	approvals := make([]*entity.Approval, len(dbApprovals))
+	for i := range dbApprovals {
+		approvals[i] = r.modelToEntity(&dbApprovals[i])
+	}
+	return approvals, nil
+}
+
+func (r *approvalRepository) CountByStatus(ctx context.Context, status string) (int, error) {
+	var count int64
+
+	if err := getDB(r.db, ctx).Model(&model.Approval{}).Where("status = ?", status).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// Helper methods
+func (r *approvalRepository) entityToModel(approval *entity.Approval) *model.Approval {
+	return &model.Approval{
+		ID:          approval.ID,
+		Action:      approval.Action.String(),
+		Status:      approval.Status.String(),
+		OrderID:     approval.OrderID,
+		OrderItemID: approval.OrderItemID,
+		PaymentID:   approval.PaymentID,
+		ReasonCode:  approval.ReasonCode,
+		Note:        approval.Note,
+		RequestedBy: approval.RequestedBy,
+		DecidedBy:   approval.DecidedBy,
+		DecidedAt:   approval.DecidedAt,
+		UsedAt:      approval.UsedAt,
+		ExpiresAt:   approval.ExpiresAt,
+		CreatedAt:   approval.CreatedAt,
+		UpdatedAt:   approval.UpdatedAt,
+	}
+}
+
+func (r *approvalRepository) modelToEntity(dbApproval *model.Approval) *entity.Approval {
+	return &entity.Approval{
+		ID:          dbApproval.ID,
+		Action:      vo.ApprovalAction(dbApproval.Action),
+		Status:      vo.ApprovalStatus(dbApproval.Status),
+		OrderID:     dbApproval.OrderID,
+		OrderItemID: dbApproval.OrderItemID,
+		PaymentID:   dbApproval.PaymentID,
+		ReasonCode:  dbApproval.ReasonCode,
+		Note:        dbApproval.Note,
+		RequestedBy: dbApproval.RequestedBy,
+		DecidedBy:   dbApproval.DecidedBy,
+		DecidedAt:   dbApproval.DecidedAt,
+		UsedAt:      dbApproval.UsedAt,
+		ExpiresAt:   dbApproval.ExpiresAt,
+		CreatedAt:   dbApproval.CreatedAt,
+		UpdatedAt:   dbApproval.UpdatedAt,
+	}
+}
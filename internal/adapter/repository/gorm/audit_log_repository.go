@@ -0,0 +1,131 @@
+// internal/adapter/repository/audit_log_repository.go
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"gorm.io/gorm"
+)
+
+type auditLogRepository struct {
+	baseRepository
+}
+
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &auditLogRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *entity.AuditLog) (*entity.AuditLog, error) {
+	dbLog := r.entityToModel(log)
+
+	if err := getDB(r.db, ctx).Create(dbLog).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbLog), nil
+}
+
+func (r *auditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter, limit, offset int) ([]*entity.AuditLog, error) {
+	var dbLogs []model.AuditLog
+
+	query := r.applyFilter(getDB(r.db, ctx), filter).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&dbLogs).Error; err != nil {
+		return nil, err
+	}
+
+	logs := make([]*entity.AuditLog, len(dbLogs))
+	for i := range dbLogs {
+		logs[i] = r.modelToEntity(&dbLogs[i])
+	}
+	return logs, nil
+}
+
+func (r *auditLogRepository) Count(ctx context.Context, filter repository.AuditLogFilter) (int, error) {
+	var count int64
+
+	if err := r.applyFilter(getDB(r.db, ctx).Model(&model.AuditLog{}), filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *auditLogRepository) applyFilter(query *gorm.DB, filter repository.AuditLogFilter) *gorm.DB {
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID > 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	return query
+}
+
+// Helper methods
+func (r *auditLogRepository) entityToModel(log *entity.AuditLog) *model.AuditLog {
+	return &model.AuditLog{
+		ID:         log.ID,
+		ActorID:    log.ActorID,
+		Action:     log.Action.String(),
+		EntityType: log.EntityType,
+		EntityID:   log.EntityID,
+		ApprovalID: log.ApprovalID,
+		Before:     rawToString(log.Before),
+		After:      rawToString(log.After),
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+func (r *auditLogRepository) modelToEntity(dbLog *model.AuditLog) *entity.AuditLog {
+	return &entity.AuditLog{
+		ID:         dbLog.ID,
+		ActorID:    dbLog.ActorID,
+		Action:     vo.AuditAction(dbLog.Action),
+		EntityType: dbLog.EntityType,
+		EntityID:   dbLog.EntityID,
+		ApprovalID: dbLog.ApprovalID,
+		Before:     stringToRaw(dbLog.Before),
+		After:      stringToRaw(dbLog.After),
+		CreatedAt:  dbLog.CreatedAt,
+	}
+}
+
+func rawToString(raw json.RawMessage) *string {
+	if len(raw) == 0 {
+		return nil
+	}
+	s := string(raw)
+	return &s
+}
+
+func stringToRaw(s *string) json.RawMessage {
+	if s == nil {
+		return nil
+	}
+	return json.RawMessage(*s)
+}
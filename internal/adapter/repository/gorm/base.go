@@ -13,6 +13,10 @@ type baseRepository struct {
 type repositoryContainer struct {
 	db                  *gorm.DB
 	userRepo            repository.UserRepository
+	refreshTokenRepo    repository.RefreshTokenRepository
+	userTokenRepo       repository.UserTokenRepository
+	terminalRepo        repository.TerminalRepository
+	apiKeyRepo          repository.APIKeyRepository
 	categoryRepo        repository.CategoryRepository
 	menuItemRepo        repository.MenuItemRepository
 	menuOptionRepo      repository.MenuOptionRepository
@@ -22,9 +26,14 @@ type repositoryContainer struct {
 	orderRepo           repository.OrderRepository
 	orderItemRepo       repository.OrderItemRepository
 	orderItemOptionRepo repository.OrderItemOptionRepository
+	orderStatusRepo     repository.OrderStatusHistoryRepository
 	paymentRepo         repository.PaymentRepository
+	promotionRepo       repository.PromotionRepository
 	revenueRepo         repository.RevenueRepository
 	kitchenRepo         repository.KitchenStationRepository
+	auditLogRepo        repository.AuditLogRepository
+	approvalRepo        repository.ApprovalRepository
+	shiftRepo           repository.ShiftRepository
 
 	txRepo repository.TxManager
 }
@@ -33,6 +42,10 @@ func NewRepositoryContainer(db *gorm.DB) repository.Repository {
 	return &repositoryContainer{
 		db:                  db,
 		userRepo:            NewUserRepository(db),
+		refreshTokenRepo:    NewRefreshTokenRepository(db),
+		userTokenRepo:       NewUserTokenRepository(db),
+		terminalRepo:        NewTerminalRepository(db),
+		apiKeyRepo:          NewAPIKeyRepository(db),
 		categoryRepo:        NewCategoryRepository(db),
 		menuItemRepo:        NewMenuItemRepository(db),
 		menuOptionRepo:      NewMenuOptionRepository(db),
@@ -42,9 +55,14 @@ func NewRepositoryContainer(db *gorm.DB) repository.Repository {
 		orderRepo:           NewOrderRepository(db),
 		orderItemRepo:       NewOrderItemRepository(db),
 		orderItemOptionRepo: NewOrderItemOptionRepository(db),
+		orderStatusRepo:     NewOrderStatusHistoryRepository(db),
 		paymentRepo:         NewPaymentRepository(db),
+		promotionRepo:       NewPromotionRepository(db),
 		revenueRepo:         NewRevenueRepository(db),
 		kitchenRepo:         NewKitchenStationRepository(db),
+		auditLogRepo:        NewAuditLogRepository(db),
+		approvalRepo:        NewApprovalRepository(db),
+		shiftRepo:           NewShiftRepository(db),
 		txRepo:              NewTxManagerGorm(db),
 	}
 }
@@ -53,6 +71,22 @@ func (r *repositoryContainer) UserRepository() repository.UserRepository {
 	return r.userRepo
 }
 
+func (r *repositoryContainer) RefreshTokenRepository() repository.RefreshTokenRepository {
+	return r.refreshTokenRepo
+}
+
+func (r *repositoryContainer) UserTokenRepository() repository.UserTokenRepository {
+	return r.userTokenRepo
+}
+
+func (r *repositoryContainer) TerminalRepository() repository.TerminalRepository {
+	return r.terminalRepo
+}
+
+func (r *repositoryContainer) APIKeyRepository() repository.APIKeyRepository {
+	return r.apiKeyRepo
+}
+
 func (r *repositoryContainer) CategoryRepository() repository.CategoryRepository {
 	return r.categoryRepo
 }
@@ -93,6 +127,10 @@ func (r *repositoryContainer) PaymentRepository() repository.PaymentRepository {
 	return r.paymentRepo
 }
 
+func (r *repositoryContainer) PromotionRepository() repository.PromotionRepository {
+	return r.promotionRepo
+}
+
 func (r *repositoryContainer) RevenueRepository() repository.RevenueRepository {
 	return r.revenueRepo
 }
@@ -100,6 +138,22 @@ func (r *repositoryContainer) KitchenStationRepository() repository.KitchenStati
 	return r.kitchenRepo
 }
 
+func (r *repositoryContainer) AuditLogRepository() repository.AuditLogRepository {
+	return r.auditLogRepo
+}
+
+func (r *repositoryContainer) ApprovalRepository() repository.ApprovalRepository {
+	return r.approvalRepo
+}
+
+func (r *repositoryContainer) OrderStatusHistoryRepository() repository.OrderStatusHistoryRepository {
+	return r.orderStatusRepo
+}
+
+func (r *repositoryContainer) ShiftRepository() repository.ShiftRepository {
+	return r.shiftRepo
+}
+
 func (r *repositoryContainer) TxManager() repository.TxManager {
 	return r.txRepo
 }
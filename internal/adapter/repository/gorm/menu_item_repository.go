@@ -133,6 +133,8 @@ func (r *menuItemRepository) entityToModel(item *entity.MenuItem) *model.MenuIte
 		Price:           item.Price.AmountSatang(),
 		ImageURL:        item.ImageURL,
 		IsRecommended:   item.IsRecommended,
+		DiscountPercent: item.DiscountPercent,
+		IsDiscounted:    item.IsDiscounted,
 		PreparationTime: item.PreparationTime,
 		DisplayOrder:    item.DisplayOrder,
 		KitchenID:       item.KitchenID,
@@ -155,6 +157,8 @@ func (r *menuItemRepository) modelToEntity(dbItem *model.MenuItem) (*entity.Menu
 		Price:           price,
 		ImageURL:        dbItem.ImageURL,
 		IsRecommended:   dbItem.IsRecommended,
+		DiscountPercent: dbItem.DiscountPercent,
+		IsDiscounted:    dbItem.IsDiscounted,
 		PreparationTime: dbItem.PreparationTime,
 		DisplayOrder:    dbItem.DisplayOrder,
 		KitchenID:       dbItem.KitchenID,
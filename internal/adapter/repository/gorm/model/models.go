@@ -10,9 +10,10 @@ import (
 )
 
 type User struct {
-	ID            int       `gorm:"primaryKey;autoIncrement"`
-	Email         string    `gorm:"uniqueIndex;not null"`
-	PasswordHash  string    `gorm:"not null"`
+	ID            int    `gorm:"primaryKey;autoIncrement"`
+	Email         string `gorm:"uniqueIndex;not null"`
+	PasswordHash  string `gorm:"not null"`
+	PinHash       string
 	Role          string    `gorm:"not null"`
 	IsActive      bool      `gorm:"default:true"`
 	EmailVerified bool      `gorm:"default:false"`
@@ -22,6 +23,110 @@ type User struct {
 	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
 
+type RefreshToken struct {
+	ID         int       `gorm:"primaryKey;autoIncrement"`
+	UserID     int       `gorm:"not null;index"`
+	SessionID  string    `gorm:"not null;index"`
+	TerminalID *int      `gorm:"index"`
+	TokenHash  string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID"`
+}
+
+type UserToken struct {
+	ID        int       `gorm:"primaryKey;autoIncrement"`
+	UserID    int       `gorm:"not null;index"`
+	Purpose   string    `gorm:"not null"`
+	TokenHash string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// AuditLog is append-only; rows are never updated or deleted
+type AuditLog struct {
+	ID         int    `gorm:"primaryKey;autoIncrement"`
+	ActorID    *int   `gorm:"index"`
+	Action     string `gorm:"not null;index"`
+	EntityType string `gorm:"not null;index:idx_audit_entity"`
+	EntityID   int    `gorm:"not null;index:idx_audit_entity"`
+	ApprovalID *int
+	Before     *string   `gorm:"type:jsonb"`
+	After      *string   `gorm:"type:jsonb"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;index"`
+}
+
+type Approval struct {
+	ID          int    `gorm:"primaryKey;autoIncrement"`
+	Action      string `gorm:"not null"`
+	Status      string `gorm:"not null;index;default:'pending'"`
+	OrderID     int    `gorm:"not null;index"`
+	OrderItemID *int
+	PaymentID   *int
+	ReasonCode  string `gorm:"not null"`
+	Note        string
+	RequestedBy *int
+	DecidedBy   *int
+	DecidedAt   *time.Time
+	UsedAt      *time.Time
+	ExpiresAt   time.Time `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+type Shift struct {
+	ID         int       `gorm:"primaryKey;autoIncrement"`
+	UserID     int       `gorm:"not null;index;uniqueIndex:idx_shift_open_user,where:clock_out_at IS NULL"`
+	ClockInAt  time.Time `gorm:"not null;index"`
+	ClockOutAt *time.Time
+	ClosedBy   *int
+	Note       string
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+
+	// Relationships
+	Breaks []ShiftBreak `gorm:"foreignKey:ShiftID"`
+}
+
+type ShiftBreak struct {
+	ID        int       `gorm:"primaryKey;autoIncrement"`
+	ShiftID   int       `gorm:"not null;index"`
+	StartedAt time.Time `gorm:"not null"`
+	EndedAt   *time.Time
+}
+
+type Terminal struct {
+	ID         int    `gorm:"primaryKey;autoIncrement"`
+	Name       string `gorm:"not null"`
+	TokenHash  string `gorm:"uniqueIndex;not null"`
+	IsActive   bool   `gorm:"default:true"`
+	LastUsedAt *time.Time
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+}
+
+type APIKey struct {
+	ID         int    `gorm:"primaryKey;autoIncrement"`
+	Name       string `gorm:"not null"`
+	Prefix     string `gorm:"not null"`
+	KeyHash    string `gorm:"uniqueIndex;not null"`
+	Scopes     string `gorm:"not null"` // comma-separated permissions
+	CreatedBy  *int
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
 type Category struct {
 	ID           int    `gorm:"primaryKey;autoIncrement"`
 	Name         string `gorm:"uniqueIndex;not null"`
@@ -119,17 +224,31 @@ type Table struct {
 type Order struct {
 	ID                  int    `gorm:"primaryKey;autoIncrement"`
 	OrderNumber         int    `gorm:"uniqueIndex;autoIncrement"`
-	TableID             int    `gorm:"not null;index"`
+	TableID             int    `gorm:"not null;index"` // zero for orders without a table
+	OrderType           string `gorm:"size:20;not null;default:'dine_in';index"`
+	CustomerName        string
+	CustomerPhone       string `gorm:"size:20"`
+	DeliveryAddress     string
+	QueueNumber         int
 	OrderStatus         string `gorm:"not null;default:'open'"`
 	PaymentStatus       string `gorm:"not null;default:'unpaid'"`
 	QRCode              string `gorm:"uniqueIndex"`
 	Notes               string
 	SpecialInstructions string
-	Subtotal            int64     `gorm:"default:0"` // stored in satang
-	Discount            int64     `gorm:"default:0"` // stored in satang
-	TaxAmount           int64     `gorm:"default:0"` // stored in satang
-	ServiceCharge       int64     `gorm:"default:0"` // stored in satang
-	Total               int64     `gorm:"default:0"` // stored in satang
+	Subtotal            int64  `gorm:"default:0"` // stored in satang
+	Discount            int64  `gorm:"default:0"` // stored in satang
+	TaxAmount           int64  `gorm:"default:0"` // stored in satang
+	ServiceCharge       int64  `gorm:"default:0"` // stored in satang
+	Total               int64  `gorm:"default:0"` // stored in satang
+	PaidAmount          int64  `gorm:"default:0"` // stored in satang
+	CouponCode          string `gorm:"size:50"`
+	CreatedBy           *int   `gorm:"index"`
+	UpdatedBy           *int
+	ClosedBy            *int
+	MergedIntoID        *int      `gorm:"index"`
+	RoundCount          int       `gorm:"not null;default:0"`
+	GuestCount          int       `gorm:"not null;default:0"`
+	Version             int       `gorm:"not null;default:1"`
 	CreatedAt           time.Time `gorm:"autoCreateTime"`
 	UpdatedAt           time.Time `gorm:"autoUpdateTime"`
 	ClosedAt            *time.Time
@@ -145,25 +264,54 @@ func (*Order) TableName() string {
 	return "orders"
 }
 
+// QueueCounter holds the last queue number handed out on a given day
+type QueueCounter struct {
+	Day        string `gorm:"primaryKey;type:date"`
+	LastNumber int    `gorm:"not null;default:0"`
+}
+
+type OrderStatusChange struct {
+	ID         int    `gorm:"primaryKey;autoIncrement"`
+	OrderID    int    `gorm:"not null;index"`
+	FromStatus string `gorm:"size:20"`
+	ToStatus   string `gorm:"size:20;not null"`
+	ChangedBy  *int
+	ChangedAt  time.Time `gorm:"not null"`
+}
+
 type OrderItem struct {
-	ID              int    `gorm:"primaryKey;autoIncrement"`
-	OrderID         int    `gorm:"not null;index"`
-	ItemID          int    `gorm:"not null;index"`
-	Quantity        int    `gorm:"not null"`
-	UnitPrice       int64  `gorm:"not null"` // stored in satang
-	Name            string `gorm:"not null"`
-	Discount        int64  `gorm:"default:0"` // stored in satang
-	Total           int64  `gorm:"not null"`  // stored in satang
-	SpecialReq      string
-	ItemStatus      string `gorm:"not null;default:'pending'"`
-	OrderNumber     string
-	KitchenTicketID int
-	KitchenStation  string
-	KitchenNotes    string
-	ServedAt        *time.Time
-	CreatedAt       time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt       time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt       gorm.DeletedAt `gorm:"index"`
+	ID               int     `gorm:"primaryKey;autoIncrement"`
+	OrderID          int     `gorm:"not null;index"`
+	ItemID           int     `gorm:"not null;index"`
+	Quantity         int     `gorm:"not null"`
+	UnitPrice        int64   `gorm:"not null"` // stored in satang
+	Name             string  `gorm:"not null"`
+	DiscountPercent  float64 `gorm:"default:0"` // menu markdown captured when the item was added
+	Discount         int64   `gorm:"default:0"` // stored in satang
+	Total            int64   `gorm:"not null"`  // stored in satang
+	SpecialReq       string
+	ItemStatus       string `gorm:"not null;default:'pending'"`
+	Course           int    `gorm:"not null;default:0"`
+	Seat             int    `gorm:"not null;default:0"`
+	Round            int    `gorm:"not null;default:0"`
+	HoldOnSubmit     bool   `gorm:"not null;default:false"`
+	SubmittedAt      *time.Time
+	OrderNumber      string
+	KitchenTicketID  int
+	KitchenStation   string
+	KitchenNotes     string
+	ServedAt         *time.Time
+	Adjustment       string // void or comp; empty for items billed as usual
+	AdjustmentReason string
+	AdjustedAmount   int64 `gorm:"default:0"` // stored in satang
+	AdjustedBy       *int
+	AdjustedAt       *time.Time `gorm:"index"`
+	CreatedBy        *int
+	UpdatedBy        *int
+	Version          int            `gorm:"not null;default:1"`
+	CreatedAt        time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
 
 	// Relationships
 	Order            Order             `gorm:"foreignKey:OrderID"`
@@ -184,20 +332,62 @@ type OrderItemOption struct {
 }
 
 type Payment struct {
-	ID        int    `gorm:"primaryKey;autoIncrement"`
-	OrderID   int    `gorm:"not null;index"`
-	Amount    int64  `gorm:"not null"` // stored in satang
-	Method    string `gorm:"not null"`
-	Reference string
-	PaidAt    time.Time      `gorm:"autoCreateTime"`
-	CreatedAt time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID           int    `gorm:"primaryKey;autoIncrement"`
+	OrderID      int    `gorm:"not null;index"`
+	Amount       int64  `gorm:"not null"` // stored in satang
+	Method       string `gorm:"not null"`
+	Reference    string
+	ProcessedBy  *int   `gorm:"index"`
+	ShiftID      *int   `gorm:"index"`
+	OrderItemIDs string // comma-separated items covered when the bill is split by item
+	RefundedAt   *time.Time
+	RefundedBy   *int
+	PaidAt       time.Time      `gorm:"autoCreateTime"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
 
 	// Relationships
 	Order Order `gorm:"foreignKey:OrderID"`
 }
 
+type Promotion struct {
+	ID             int     `gorm:"primaryKey;autoIncrement"`
+	Name           string  `gorm:"not null"`
+	Code           *string `gorm:"uniqueIndex;size:50"` // nil for automatic promotions
+	Type           string  `gorm:"size:20;not null"`
+	PercentOff     float64 `gorm:"default:0"`
+	AmountOff      int64   `gorm:"default:0"` // stored in satang
+	BuyQuantity    int     `gorm:"default:0"`
+	FreeQuantity   int     `gorm:"default:0"`
+	MenuItemIDs    string  // comma-separated eligible menu items
+	CategoryIDs    string  // comma-separated eligible categories
+	MinSpend       int64   `gorm:"default:0"` // stored in satang
+	StartsAt       *time.Time
+	EndsAt         *time.Time
+	HappyHourStart string `gorm:"size:5"`
+	HappyHourEnd   string `gorm:"size:5"`
+	Priority       int    `gorm:"default:0"`
+	Stackable      bool   `gorm:"default:false"`
+	UsageLimit     int    `gorm:"default:0"`
+	IsActive       bool   `gorm:"index"`
+	CreatedBy      *int
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// OrderPromotion is a promotion line on the final bill of an order
+type OrderPromotion struct {
+	ID          int       `gorm:"primaryKey;autoIncrement"`
+	OrderID     int       `gorm:"not null;index"`
+	PromotionID int       `gorm:"not null;index"`
+	Name        string    `gorm:"not null"`
+	Code        string    `gorm:"size:50"`
+	Amount      int64     `gorm:"not null"` // stored in satang
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
 type KitchenStation struct {
 	ID          int `gorm:"primaryKey;autoIncrement"`
 	Name        string
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 	"gorm.io/gorm"
@@ -23,6 +24,7 @@ func NewOrderItemRepository(db *gorm.DB) repository.OrderItemRepository {
 
 func (r *orderItemRepository) Create(ctx context.Context, item *entity.OrderItem) (*entity.OrderItem, error) {
 	dbItem := r.entityToModel(item)
+	dbItem.Version = 1
 	db := getDB(r.db, ctx)
 	if err := db.WithContext(ctx).Create(dbItem).Error; err != nil {
 		return nil, err
@@ -45,24 +47,46 @@ func (r *orderItemRepository) GetByID(ctx context.Context, id int) (*entity.Orde
 	return r.modelToEntity(&dbItem)
 }
 
+// Update saves the item only if nobody saved it since it was read, bumping
+// its version; otherwise it returns ErrOrderItemVersionConflict
 func (r *orderItemRepository) Update(ctx context.Context, item *entity.OrderItem) (*entity.OrderItem, error) {
 	dbItem := r.entityToModel(item)
+	dbItem.Version = item.Version + 1
 	db := getDB(r.db, ctx)
-	if err := db.WithContext(ctx).Save(dbItem).Error; err != nil {
-		return nil, err
+	result := db.WithContext(ctx).Model(dbItem).Where("version = ?", item.Version).Select("*").Updates(dbItem)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.ErrOrderItemVersionConflict
 	}
+	item.Version = dbItem.Version
 
 	return r.modelToEntity(dbItem)
 }
 
 func (r *orderItemRepository) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&model.OrderItem{}, id).Error
+	db := getDB(r.db, ctx)
+	return db.WithContext(ctx).Delete(&model.OrderItem{}, id).Error
 }
 
+// ListByOrder lists the items of an order submitted to the kitchen, leaving
+// out its cart
 func (r *orderItemRepository) ListByOrder(ctx context.Context, orderID int) ([]*entity.OrderItem, error) {
 	var dbItems []model.OrderItem
 	db := getDB(r.db, ctx)
-	if err := db.WithContext(ctx).Where("order_id = ?", orderID).Find(&dbItems).Error; err != nil {
+	if err := db.WithContext(ctx).Where("order_id = ? AND item_status <> ?", orderID, vo.ItemStatusDraft.String()).Find(&dbItems).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelsToEntities(dbItems)
+}
+
+// ListCartByOrder lists the items in an order's cart
+func (r *orderItemRepository) ListCartByOrder(ctx context.Context, orderID int) ([]*entity.OrderItem, error) {
+	var dbItems []model.OrderItem
+	db := getDB(r.db, ctx)
+	if err := db.WithContext(ctx).Where("order_id = ? AND item_status = ?", orderID, vo.ItemStatusDraft.String()).Order("id").Find(&dbItems).Error; err != nil {
 		return nil, err
 	}
 
@@ -74,6 +98,22 @@ func (r *orderItemRepository) DeleteByOrder(ctx context.Context, orderID int) er
 	return db.WithContext(ctx).Where("order_id = ?", orderID).Delete(&model.OrderItem{}).Error
 }
 
+// MoveToOrder moves the submitted items of one order to another, adding the
+// offsets to their round and seat numbers so they follow the target's own;
+// shared items and items from before rounds were numbered keep 0. Item
+// options are keyed by the order item, so they follow their items.
+func (r *orderItemRepository) MoveToOrder(ctx context.Context, fromOrderID, toOrderID, roundOffset, seatOffset int) error {
+	db := getDB(r.db, ctx)
+	return db.WithContext(ctx).Model(&model.OrderItem{}).
+		Where("order_id = ? AND item_status <> ?", fromOrderID, vo.ItemStatusDraft.String()).
+		Updates(map[string]interface{}{
+			"order_id": toOrderID,
+			"round":    gorm.Expr("CASE WHEN round > 0 THEN round + ? ELSE 0 END", roundOffset),
+			"seat":     gorm.Expr("CASE WHEN seat > 0 THEN seat + ? ELSE 0 END", seatOffset),
+			"version":  gorm.Expr("version + 1"),
+		}).Error
+}
+
 func (r *orderItemRepository) GetByOrderAndItem(ctx context.Context, orderID, itemID int) (*entity.OrderItem, error) {
 	var dbItem model.OrderItem
 
@@ -91,23 +131,37 @@ func (r *orderItemRepository) GetByOrderAndItem(ctx context.Context, orderID, it
 // Helper methods
 func (r *orderItemRepository) entityToModel(item *entity.OrderItem) *model.OrderItem {
 	return &model.OrderItem{
-		ID:              item.ID,
-		OrderID:         item.OrderID,
-		ItemID:          item.ItemID,
-		Quantity:        item.Quantity,
-		UnitPrice:       item.UnitPrice.AmountSatang(),
-		Name:            item.Name,
-		Discount:        item.Discount.AmountSatang(),
-		Total:           item.Total.AmountSatang(),
-		SpecialReq:      item.SpecialReq,
-		ItemStatus:      item.ItemStatus.String(),
-		OrderNumber:     item.OrderNumber,
-		KitchenTicketID: item.KitchenTicketID,
-		KitchenStation:  item.KitchenStation,
-		KitchenNotes:    item.KitchenNotes,
-		ServedAt:        item.ServedAt,
-		CreatedAt:       item.CreatedAt,
-		UpdatedAt:       item.UpdatedAt,
+		ID:               item.ID,
+		OrderID:          item.OrderID,
+		ItemID:           item.ItemID,
+		Quantity:         item.Quantity,
+		UnitPrice:        item.UnitPrice.AmountSatang(),
+		Name:             item.Name,
+		DiscountPercent:  item.DiscountPercent,
+		Discount:         item.Discount.AmountSatang(),
+		Total:            item.Total.AmountSatang(),
+		SpecialReq:       item.SpecialReq,
+		ItemStatus:       item.ItemStatus.String(),
+		Course:           item.Course,
+		Seat:             item.Seat,
+		Round:            item.Round,
+		HoldOnSubmit:     item.HoldOnSubmit,
+		SubmittedAt:      item.SubmittedAt,
+		OrderNumber:      item.OrderNumber,
+		KitchenTicketID:  item.KitchenTicketID,
+		KitchenStation:   item.KitchenStation,
+		KitchenNotes:     item.KitchenNotes,
+		ServedAt:         item.ServedAt,
+		Adjustment:       item.Adjustment.String(),
+		AdjustmentReason: item.AdjustmentReason,
+		AdjustedAmount:   item.AdjustedAmount.AmountSatang(),
+		AdjustedBy:       item.AdjustedBy,
+		AdjustedAt:       item.AdjustedAt,
+		CreatedBy:        item.CreatedBy,
+		UpdatedBy:        item.UpdatedBy,
+		Version:          item.Version,
+		CreatedAt:        item.CreatedAt,
+		UpdatedAt:        item.UpdatedAt,
 	}
 }
 
@@ -127,29 +181,48 @@ func (r *orderItemRepository) modelToEntity(dbItem *model.OrderItem) (*entity.Or
 		return nil, err
 	}
 
+	adjustedAmount, err := vo.NewMoneyFromSatang(dbItem.AdjustedAmount)
+	if err != nil {
+		return nil, err
+	}
+
 	itemStatus, err := vo.NewItemStatus(dbItem.ItemStatus)
 	if err != nil {
 		return nil, err
 	}
 
 	return &entity.OrderItem{
-		ID:              dbItem.ID,
-		OrderID:         dbItem.OrderID,
-		ItemID:          dbItem.ItemID,
-		Quantity:        dbItem.Quantity,
-		UnitPrice:       unitPrice,
-		Name:            dbItem.Name,
-		Discount:        discount,
-		Total:           total,
-		SpecialReq:      dbItem.SpecialReq,
-		ItemStatus:      itemStatus,
-		OrderNumber:     dbItem.OrderNumber,
-		KitchenTicketID: dbItem.KitchenTicketID,
-		KitchenStation:  dbItem.KitchenStation,
-		KitchenNotes:    dbItem.KitchenNotes,
-		ServedAt:        dbItem.ServedAt,
-		CreatedAt:       dbItem.CreatedAt,
-		UpdatedAt:       dbItem.UpdatedAt,
+		ID:               dbItem.ID,
+		OrderID:          dbItem.OrderID,
+		ItemID:           dbItem.ItemID,
+		Quantity:         dbItem.Quantity,
+		UnitPrice:        unitPrice,
+		Name:             dbItem.Name,
+		DiscountPercent:  dbItem.DiscountPercent,
+		Discount:         discount,
+		Total:            total,
+		SpecialReq:       dbItem.SpecialReq,
+		ItemStatus:       itemStatus,
+		Course:           dbItem.Course,
+		Seat:             dbItem.Seat,
+		Round:            dbItem.Round,
+		HoldOnSubmit:     dbItem.HoldOnSubmit,
+		SubmittedAt:      dbItem.SubmittedAt,
+		OrderNumber:      dbItem.OrderNumber,
+		KitchenTicketID:  dbItem.KitchenTicketID,
+		KitchenStation:   dbItem.KitchenStation,
+		KitchenNotes:     dbItem.KitchenNotes,
+		ServedAt:         dbItem.ServedAt,
+		Adjustment:       vo.ItemAdjustment(dbItem.Adjustment),
+		AdjustmentReason: dbItem.AdjustmentReason,
+		AdjustedAmount:   adjustedAmount,
+		AdjustedBy:       dbItem.AdjustedBy,
+		AdjustedAt:       dbItem.AdjustedAt,
+		CreatedBy:        dbItem.CreatedBy,
+		UpdatedBy:        dbItem.UpdatedBy,
+		Version:          dbItem.Version,
+		CreatedAt:        dbItem.CreatedAt,
+		UpdatedAt:        dbItem.UpdatedAt,
 	}, nil
 }
 
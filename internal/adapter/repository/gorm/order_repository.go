@@ -7,9 +7,11 @@ import (
 
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type orderRepository struct {
@@ -24,6 +26,7 @@ func NewOrderRepository(db *gorm.DB) repository.OrderRepository {
 
 func (r *orderRepository) Create(ctx context.Context, order *entity.Order) (*entity.Order, error) {
 	dbOrder := r.entityToModel(order)
+	dbOrder.Version = 1
 	db := getDB(r.db, ctx)
 	if err := db.WithContext(ctx).Create(dbOrder).Error; err != nil {
 		return nil, err
@@ -46,11 +49,27 @@ func (r *orderRepository) GetByID(ctx context.Context, id int) (*entity.Order, e
 	return r.modelToEntity(&dbOrder)
 }
 
+// GetByIDForUpdate reads the order and locks its row until the transaction
+// ends, so money taken against it is worked out by one request at a time
+func (r *orderRepository) GetByIDForUpdate(ctx context.Context, id int) (*entity.Order, error) {
+	var dbOrder model.Order
+
+	db := getDB(r.db, ctx)
+	if err := db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&dbOrder, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbOrder)
+}
+
 func (r *orderRepository) GetByIDWithItems(ctx context.Context, id int) (*entity.Order, error) {
 	var dbOrder model.Order
 
 	db := getDB(r.db, ctx)
-	if err := db.WithContext(ctx).Preload("OrderItems").First(&dbOrder, id).Error; err != nil {
+	if err := db.WithContext(ctx).Preload("OrderItems", "item_status <> ?", vo.ItemStatusDraft.String()).First(&dbOrder, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -60,12 +79,20 @@ func (r *orderRepository) GetByIDWithItems(ctx context.Context, id int) (*entity
 	return r.modelToEntityWithItems(&dbOrder)
 }
 
+// Update saves the order only if nobody saved it since it was read, bumping
+// its version; otherwise it returns ErrOrderVersionConflict
 func (r *orderRepository) Update(ctx context.Context, order *entity.Order) (*entity.Order, error) {
 	dbOrder := r.entityToModel(order)
+	dbOrder.Version = order.Version + 1
 	db := getDB(r.db, ctx)
-	if err := db.WithContext(ctx).Save(dbOrder).Error; err != nil {
-		return nil, err
+	result := db.WithContext(ctx).Model(dbOrder).Where("version = ?", order.Version).Select("*").Updates(dbOrder)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.ErrOrderVersionConflict
 	}
+	order.Version = dbOrder.Version
 
 	return r.modelToEntity(dbOrder)
 }
@@ -105,7 +132,7 @@ func (r *orderRepository) ListWithItems(ctx context.Context, limit, offset int)
 		query = query.Offset(offset)
 	}
 
-	if err := query.Preload("OrderItems").Find(&dbOrders).Error; err != nil {
+	if err := query.Preload("OrderItems", "item_status <> ?", vo.ItemStatusDraft.String()).Find(&dbOrders).Error; err != nil {
 		return nil, err
 	}
 
@@ -131,11 +158,13 @@ func (r *orderRepository) ListByTable(ctx context.Context, tableID int, limit, o
 	return r.modelsToEntities(dbOrders)
 }
 
+// GetOpenOrderByTable returns the order still being served at the table,
+// whether open or ordered
 func (r *orderRepository) GetOpenOrderByTable(ctx context.Context, tableID int) (*entity.Order, error) {
 	var dbOrder model.Order
 
 	db := getDB(r.db, ctx)
-	if err := db.WithContext(ctx).Where("table_id = ? AND order_status = ?", tableID, "open").First(&dbOrder).Error; err != nil {
+	if err := db.WithContext(ctx).Where("table_id = ? AND order_status IN ?", tableID, []string{vo.OrderStatusOpen.String(), vo.OrderStatusOrdered.String()}).First(&dbOrder).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -177,6 +206,43 @@ func (r *orderRepository) ListByStatus(ctx context.Context, status string, limit
 	return r.modelsToEntities(dbOrders)
 }
 
+func (r *orderRepository) ListByOrderType(ctx context.Context, orderType string, limit, offset int) ([]*entity.Order, error) {
+	var dbOrders []model.Order
+
+	db := getDB(r.db, ctx)
+	query := db.WithContext(ctx).Where("order_type = ?", orderType).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&dbOrders).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelsToEntities(dbOrders)
+}
+
+// NextQueueNumber returns the next pickup/queue number for the day; numbers
+// start again from 1 every day. The number comes from a per-day counter row
+// bumped in a single upsert, so concurrent orders never share a number
+func (r *orderRepository) NextQueueNumber(ctx context.Context, day time.Time) (int, error) {
+	var next int
+	err := getDB(r.db, ctx).WithContext(ctx).Raw(
+		`INSERT INTO queue_counters (day, last_number) VALUES (?, 1)
+		ON CONFLICT (day) DO UPDATE SET last_number = queue_counters.last_number + 1
+		RETURNING last_number`,
+		day.Format("2006-01-02"),
+	).Scan(&next).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return next, nil
+}
+
 func (r *orderRepository) ListByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) ([]*entity.Order, error) {
 	var dbOrders []model.Order
 
@@ -202,6 +268,11 @@ func (r *orderRepository) entityToModel(order *entity.Order) *model.Order {
 		ID:                  order.ID,
 		OrderNumber:         order.OrderNumber,
 		TableID:             order.TableID,
+		OrderType:           order.OrderType.String(),
+		CustomerName:        order.CustomerName,
+		CustomerPhone:       order.CustomerPhone,
+		DeliveryAddress:     order.DeliveryAddress,
+		QueueNumber:         order.QueueNumber,
 		OrderStatus:         order.OrderStatus.String(),
 		PaymentStatus:       order.PaymentStatus.String(),
 		QRCode:              order.QRCode,
@@ -212,6 +283,15 @@ func (r *orderRepository) entityToModel(order *entity.Order) *model.Order {
 		TaxAmount:           order.TaxAmount.AmountSatang(),
 		ServiceCharge:       order.ServiceCharge.AmountSatang(),
 		Total:               order.Total.AmountSatang(),
+		PaidAmount:          order.PaidAmount.AmountSatang(),
+		CouponCode:          order.CouponCode,
+		CreatedBy:           order.CreatedBy,
+		UpdatedBy:           order.UpdatedBy,
+		ClosedBy:            order.ClosedBy,
+		MergedIntoID:        order.MergedIntoID,
+		RoundCount:          order.RoundCount,
+		GuestCount:          order.GuestCount,
+		Version:             order.Version,
 		CreatedAt:           order.CreatedAt,
 		UpdatedAt:           order.UpdatedAt,
 		ClosedAt:            order.ClosedAt,
@@ -254,10 +334,25 @@ func (r *orderRepository) modelToEntity(dbOrder *model.Order) (*entity.Order, er
 		return nil, err
 	}
 
+	paidAmount, err := vo.NewMoneyFromSatang(dbOrder.PaidAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	orderType, err := vo.NewOrderType(dbOrder.OrderType)
+	if err != nil {
+		return nil, err
+	}
+
 	return &entity.Order{
 		ID:                  dbOrder.ID,
 		OrderNumber:         dbOrder.OrderNumber,
 		TableID:             dbOrder.TableID,
+		OrderType:           orderType,
+		CustomerName:        dbOrder.CustomerName,
+		CustomerPhone:       dbOrder.CustomerPhone,
+		DeliveryAddress:     dbOrder.DeliveryAddress,
+		QueueNumber:         dbOrder.QueueNumber,
 		OrderStatus:         orderStatus,
 		PaymentStatus:       paymentStatus,
 		QRCode:              dbOrder.QRCode,
@@ -268,6 +363,15 @@ func (r *orderRepository) modelToEntity(dbOrder *model.Order) (*entity.Order, er
 		TaxAmount:           taxAmount,
 		ServiceCharge:       serviceCharge,
 		Total:               total,
+		PaidAmount:          paidAmount,
+		CouponCode:          dbOrder.CouponCode,
+		CreatedBy:           dbOrder.CreatedBy,
+		UpdatedBy:           dbOrder.UpdatedBy,
+		ClosedBy:            dbOrder.ClosedBy,
+		MergedIntoID:        dbOrder.MergedIntoID,
+		RoundCount:          dbOrder.RoundCount,
+		GuestCount:          dbOrder.GuestCount,
+		Version:             dbOrder.Version,
 		CreatedAt:           dbOrder.CreatedAt,
 		UpdatedAt:           dbOrder.UpdatedAt,
 		ClosedAt:            dbOrder.ClosedAt,
@@ -310,29 +414,48 @@ func (r *orderRepository) orderItemModelToEntity(dbItem *model.OrderItem) (*enti
 		return nil, err
 	}
 
+	adjustedAmount, err := vo.NewMoneyFromSatang(dbItem.AdjustedAmount)
+	if err != nil {
+		return nil, err
+	}
+
 	itemStatus, err := vo.NewItemStatus(dbItem.ItemStatus)
 	if err != nil {
 		return nil, err
 	}
 
 	return &entity.OrderItem{
-		ID:              dbItem.ID,
-		OrderID:         dbItem.OrderID,
-		ItemID:          dbItem.ItemID,
-		Quantity:        dbItem.Quantity,
-		UnitPrice:       unitPrice,
-		Name:            dbItem.Name,
-		Discount:        discount,
-		Total:           total,
-		SpecialReq:      dbItem.SpecialReq,
-		ItemStatus:      itemStatus,
-		OrderNumber:     dbItem.OrderNumber,
-		KitchenTicketID: dbItem.KitchenTicketID,
-		KitchenStation:  dbItem.KitchenStation,
-		KitchenNotes:    dbItem.KitchenNotes,
-		ServedAt:        dbItem.ServedAt,
-		CreatedAt:       dbItem.CreatedAt,
-		UpdatedAt:       dbItem.UpdatedAt,
+		ID:               dbItem.ID,
+		OrderID:          dbItem.OrderID,
+		ItemID:           dbItem.ItemID,
+		Quantity:         dbItem.Quantity,
+		UnitPrice:        unitPrice,
+		Name:             dbItem.Name,
+		DiscountPercent:  dbItem.DiscountPercent,
+		Discount:         discount,
+		Total:            total,
+		SpecialReq:       dbItem.SpecialReq,
+		ItemStatus:       itemStatus,
+		Course:           dbItem.Course,
+		Seat:             dbItem.Seat,
+		Round:            dbItem.Round,
+		HoldOnSubmit:     dbItem.HoldOnSubmit,
+		SubmittedAt:      dbItem.SubmittedAt,
+		OrderNumber:      dbItem.OrderNumber,
+		KitchenTicketID:  dbItem.KitchenTicketID,
+		KitchenStation:   dbItem.KitchenStation,
+		KitchenNotes:     dbItem.KitchenNotes,
+		ServedAt:         dbItem.ServedAt,
+		Adjustment:       vo.ItemAdjustment(dbItem.Adjustment),
+		AdjustmentReason: dbItem.AdjustmentReason,
+		AdjustedAmount:   adjustedAmount,
+		AdjustedBy:       dbItem.AdjustedBy,
+		AdjustedAt:       dbItem.AdjustedAt,
+		CreatedBy:        dbItem.CreatedBy,
+		UpdatedBy:        dbItem.UpdatedBy,
+		Version:          dbItem.Version,
+		CreatedAt:        dbItem.CreatedAt,
+		UpdatedAt:        dbItem.UpdatedAt,
 	}, nil
 }
 
@@ -403,3 +526,17 @@ func (r *orderRepository) GetOrderIDByQRCode(ctx context.Context, qrCode string)
 
 	return dbOrder.ID, nil
 }
+
+func (r *orderRepository) ListIDsHandledBy(ctx context.Context, userID int, startDate, endDate time.Time) ([]int, error) {
+	var ids []int
+
+	err := getDB(r.db, ctx).Model(&model.Order{}).
+		Where("(created_by = ? AND created_at BETWEEN ? AND ?) OR (closed_by = ? AND closed_at BETWEEN ? AND ?)",
+			userID, startDate, endDate, userID, startDate, endDate).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,69 @@
+// internal/adapter/repository/order_status_history_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"gorm.io/gorm"
+)
+
+type orderStatusHistoryRepository struct {
+	baseRepository
+}
+
+func NewOrderStatusHistoryRepository(db *gorm.DB) repository.OrderStatusHistoryRepository {
+	return &orderStatusHistoryRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *orderStatusHistoryRepository) Create(ctx context.Context, change *entity.OrderStatusChange) (*entity.OrderStatusChange, error) {
+	dbChange := r.entityToModel(change)
+
+	if err := getDB(r.db, ctx).Create(dbChange).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbChange), nil
+}
+
+func (r *orderStatusHistoryRepository) ListByOrder(ctx context.Context, orderID int) ([]*entity.OrderStatusChange, error) {
+	var dbChanges []model.OrderStatusChange
+
+	if err := getDB(r.db, ctx).Where("order_id = ?", orderID).Order("changed_at, id").Find(&dbChanges).Error; err != nil {
+		return nil, err
+	}
+
+	changes := make([]*entity.OrderStatusChange, len(dbChanges))
+	for i := range dbChanges {
+		changes[i] = r.modelToEntity(&dbChanges[i])
+	}
+	return changes, nil
+}
+
+// Helper methods
+func (r *orderStatusHistoryRepository) entityToModel(change *entity.OrderStatusChange) *model.OrderStatusChange {
+	return &model.OrderStatusChange{
+		ID:         change.ID,
+		OrderID:    change.OrderID,
+		FromStatus: change.FromStatus.String(),
+		ToStatus:   change.ToStatus.String(),
+		ChangedBy:  change.ChangedBy,
+		ChangedAt:  change.ChangedAt,
+	}
+}
+
+func (r *orderStatusHistoryRepository) modelToEntity(dbChange *model.OrderStatusChange) *entity.OrderStatusChange {
+	return &entity.OrderStatusChange{
+		ID:         dbChange.ID,
+		OrderID:    dbChange.OrderID,
+		FromStatus: vo.OrderStatus(dbChange.FromStatus),
+		ToStatus:   vo.OrderStatus(dbChange.ToStatus),
+		ChangedBy:  dbChange.ChangedBy,
+		ChangedAt:  dbChange.ChangedAt,
+	}
+}
@@ -3,13 +3,17 @@ package repository
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type paymentRepository struct {
@@ -25,7 +29,7 @@ func NewPaymentRepository(db *gorm.DB) repository.PaymentRepository {
 func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment) (*entity.Payment, error) {
 	dbPayment := r.entityToModel(payment)
 
-	if err := r.db.WithContext(ctx).Create(dbPayment).Error; err != nil {
+	if err := getDB(r.db, ctx).Create(dbPayment).Error; err != nil {
 		return nil, err
 	}
 
@@ -35,7 +39,7 @@ func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment)
 func (r *paymentRepository) GetByID(ctx context.Context, id int) (*entity.Payment, error) {
 	var dbPayment model.Payment
 
-	if err := r.db.WithContext(ctx).First(&dbPayment, id).Error; err != nil {
+	if err := getDB(r.db, ctx).First(&dbPayment, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -45,10 +49,21 @@ func (r *paymentRepository) GetByID(ctx context.Context, id int) (*entity.Paymen
 	return r.modelToEntity(&dbPayment)
 }
 
-func (r *paymentRepository) GetByOrderID(ctx context.Context, orderID int) (*entity.Payment, error) {
+func (r *paymentRepository) ListByOrderID(ctx context.Context, orderID int) ([]*entity.Payment, error) {
+	var dbPayments []model.Payment
+
+	if err := getDB(r.db, ctx).Where("order_id = ?", orderID).Order("paid_at, id").Find(&dbPayments).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelsToEntities(dbPayments)
+}
+
+// GetByIDForUpdate reads the payment and locks its row until the transaction ends
+func (r *paymentRepository) GetByIDForUpdate(ctx context.Context, id int) (*entity.Payment, error) {
 	var dbPayment model.Payment
 
-	if err := r.db.WithContext(ctx).Where("order_id = ?", orderID).First(&dbPayment).Error; err != nil {
+	if err := getDB(r.db, ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&dbPayment, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -58,24 +73,30 @@ func (r *paymentRepository) GetByOrderID(ctx context.Context, orderID int) (*ent
 	return r.modelToEntity(&dbPayment)
 }
 
-func (r *paymentRepository) Update(ctx context.Context, payment *entity.Payment) (*entity.Payment, error) {
-	dbPayment := r.entityToModel(payment)
-
-	if err := r.db.WithContext(ctx).Save(dbPayment).Error; err != nil {
-		return nil, err
+// Refund records the refund of the payment, only if it was not refunded
+// already; otherwise it returns ErrPaymentAlreadyRefunded
+func (r *paymentRepository) Refund(ctx context.Context, payment *entity.Payment) (*entity.Payment, error) {
+	result := getDB(r.db, ctx).Model(&model.Payment{}).
+		Where("id = ? AND refunded_at IS NULL", payment.ID).
+		Updates(map[string]interface{}{"refunded_at": payment.RefundedAt, "refunded_by": payment.RefundedBy})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.ErrPaymentAlreadyRefunded
 	}
 
-	return r.modelToEntity(dbPayment)
+	return r.GetByID(ctx, payment.ID)
 }
 
 func (r *paymentRepository) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&model.Payment{}, id).Error
+	return getDB(r.db, ctx).Delete(&model.Payment{}, id).Error
 }
 
 func (r *paymentRepository) List(ctx context.Context, limit, offset int) ([]*entity.Payment, error) {
 	var dbPayments []model.Payment
 
-	query := r.db.WithContext(ctx)
+	query := getDB(r.db, ctx)
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -93,7 +114,7 @@ func (r *paymentRepository) List(ctx context.Context, limit, offset int) ([]*ent
 func (r *paymentRepository) ListByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) ([]*entity.Payment, error) {
 	var dbPayments []model.Payment
 
-	query := r.db.WithContext(ctx).Where("paid_at BETWEEN ? AND ?", startDate, endDate)
+	query := getDB(r.db, ctx).Where("paid_at BETWEEN ? AND ?", startDate, endDate)
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -111,7 +132,7 @@ func (r *paymentRepository) ListByDateRange(ctx context.Context, startDate, endD
 func (r *paymentRepository) ListByMethod(ctx context.Context, method string, limit, offset int) ([]*entity.Payment, error) {
 	var dbPayments []model.Payment
 
-	query := r.db.WithContext(ctx).Where("method = ?", method)
+	query := getDB(r.db, ctx).Where("method = ?", method)
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -126,15 +147,36 @@ func (r *paymentRepository) ListByMethod(ctx context.Context, method string, lim
 	return r.modelsToEntities(dbPayments)
 }
 
+func (r *paymentRepository) ListByShift(ctx context.Context, shiftID int) ([]*entity.Payment, error) {
+	var dbPayments []model.Payment
+
+	if err := getDB(r.db, ctx).Where("shift_id = ?", shiftID).Order("paid_at").Find(&dbPayments).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelsToEntities(dbPayments)
+}
+
+func (r *paymentRepository) MoveToOrder(ctx context.Context, fromOrderID, toOrderID int) error {
+	return getDB(r.db, ctx).Model(&model.Payment{}).
+		Where("order_id = ?", fromOrderID).
+		Update("order_id", toOrderID).Error
+}
+
 // Helper methods
 func (r *paymentRepository) entityToModel(payment *entity.Payment) *model.Payment {
 	return &model.Payment{
-		ID:        payment.ID,
-		OrderID:   payment.OrderID,
-		Amount:    payment.Amount.AmountSatang(),
-		Method:    payment.Method.String(),
-		Reference: payment.Reference,
-		PaidAt:    payment.PaidAt,
+		ID:           payment.ID,
+		OrderID:      payment.OrderID,
+		Amount:       payment.Amount.AmountSatang(),
+		Method:       payment.Method.String(),
+		Reference:    payment.Reference,
+		ProcessedBy:  payment.ProcessedBy,
+		ShiftID:      payment.ShiftID,
+		OrderItemIDs: joinIDs(payment.OrderItemIDs),
+		RefundedAt:   payment.RefundedAt,
+		RefundedBy:   payment.RefundedBy,
+		PaidAt:       payment.PaidAt,
 	}
 }
 
@@ -150,12 +192,17 @@ func (r *paymentRepository) modelToEntity(dbPayment *model.Payment) (*entity.Pay
 	}
 
 	return &entity.Payment{
-		ID:        dbPayment.ID,
-		OrderID:   dbPayment.OrderID,
-		Amount:    amount,
-		Method:    method,
-		Reference: dbPayment.Reference,
-		PaidAt:    dbPayment.PaidAt,
+		ID:           dbPayment.ID,
+		OrderID:      dbPayment.OrderID,
+		Amount:       amount,
+		Method:       method,
+		Reference:    dbPayment.Reference,
+		ProcessedBy:  dbPayment.ProcessedBy,
+		ShiftID:      dbPayment.ShiftID,
+		OrderItemIDs: splitIDs(dbPayment.OrderItemIDs),
+		RefundedAt:   dbPayment.RefundedAt,
+		RefundedBy:   dbPayment.RefundedBy,
+		PaidAt:       dbPayment.PaidAt,
 	}, nil
 }
 
@@ -170,3 +217,26 @@ func (r *paymentRepository) modelsToEntities(dbPayments []model.Payment) ([]*ent
 	}
 	return entities, nil
 }
+
+// joinIDs stores a list of IDs as a comma-separated column
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+// splitIDs reads a comma-separated column of IDs, skipping malformed entries
+func splitIDs(value string) []int {
+	if value == "" {
+		return nil
+	}
+	var ids []int
+	for _, part := range strings.Split(value, ",") {
+		if id, err := strconv.Atoi(part); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,264 @@
+// internal/adapter/repository/promotion_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type promotionRepository struct {
+	baseRepository
+}
+
+func NewPromotionRepository(db *gorm.DB) repository.PromotionRepository {
+	return &promotionRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *entity.Promotion) (*entity.Promotion, error) {
+	dbPromotion := r.entityToModel(promotion)
+
+	if err := getDB(r.db, ctx).Create(dbPromotion).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbPromotion), nil
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id int) (*entity.Promotion, error) {
+	var dbPromotion model.Promotion
+
+	if err := getDB(r.db, ctx).First(&dbPromotion, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	promotion := r.modelToEntity(&dbPromotion)
+	if err := r.fillUsage(ctx, []*entity.Promotion{promotion}); err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) GetByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	var dbPromotion model.Promotion
+
+	if err := getDB(r.db, ctx).Where("code = ?", code).First(&dbPromotion).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	promotion := r.modelToEntity(&dbPromotion)
+	if err := r.fillUsage(ctx, []*entity.Promotion{promotion}); err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) Update(ctx context.Context, promotion *entity.Promotion) (*entity.Promotion, error) {
+	dbPromotion := r.entityToModel(promotion)
+
+	if err := getDB(r.db, ctx).Save(dbPromotion).Error; err != nil {
+		return nil, err
+	}
+
+	updated := r.modelToEntity(dbPromotion)
+	updated.UsageCount = promotion.UsageCount
+	return updated, nil
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, id int) error {
+	return getDB(r.db, ctx).Delete(&model.Promotion{}, id).Error
+}
+
+func (r *promotionRepository) List(ctx context.Context, onlyActive bool, limit, offset int) ([]*entity.Promotion, error) {
+	var dbPromotions []model.Promotion
+
+	query := getDB(r.db, ctx).Order("priority DESC, id")
+	if onlyActive {
+		query = query.Where("is_active = ?", true)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Find(&dbPromotions).Error; err != nil {
+		return nil, err
+	}
+
+	promotions := make([]*entity.Promotion, len(dbPromotions))
+	for i := range dbPromotions {
+		promotions[i] = r.modelToEntity(&dbPromotions[i])
+	}
+	if err := r.fillUsage(ctx, promotions); err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) CountUsage(ctx context.Context, promotionID, excludeOrderID int) (int, error) {
+	var count int64
+
+	err := r.usedBy(ctx, promotionID).
+		Where("orders.id <> ?", excludeOrderID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *promotionRepository) LockUsage(ctx context.Context, promotionID int) error {
+	return getDB(r.db, ctx).Model(&model.Promotion{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", promotionID).
+		Take(&model.Promotion{}).Error
+}
+
+func (r *promotionRepository) ReplaceOrderPromotions(ctx context.Context, orderID int, promotions []entity.AppliedPromotion) error {
+	db := getDB(r.db, ctx)
+	if err := db.Where("order_id = ?", orderID).Delete(&model.OrderPromotion{}).Error; err != nil {
+		return err
+	}
+	if len(promotions) == 0 {
+		return nil
+	}
+
+	dbLines := make([]model.OrderPromotion, len(promotions))
+	for i, promotion := range promotions {
+		dbLines[i] = model.OrderPromotion{
+			OrderID:     orderID,
+			PromotionID: promotion.PromotionID,
+			Name:        promotion.Name,
+			Code:        promotion.Code,
+			Amount:      promotion.Amount.AmountSatang(),
+		}
+	}
+	return db.Create(&dbLines).Error
+}
+
+func (r *promotionRepository) ListOrderPromotions(ctx context.Context, orderID int) ([]entity.AppliedPromotion, error) {
+	var dbLines []model.OrderPromotion
+
+	if err := getDB(r.db, ctx).Where("order_id = ?", orderID).Order("id").Find(&dbLines).Error; err != nil {
+		return nil, err
+	}
+
+	promotions := make([]entity.AppliedPromotion, len(dbLines))
+	for i, dbLine := range dbLines {
+		amount, err := vo.NewMoneyFromSatang(dbLine.Amount)
+		if err != nil {
+			return nil, err
+		}
+		promotions[i] = entity.AppliedPromotion{
+			PromotionID: dbLine.PromotionID,
+			Name:        dbLine.Name,
+			Code:        dbLine.Code,
+			Amount:      amount,
+		}
+	}
+	return promotions, nil
+}
+
+// usedBy scopes orders to those using a promotion: completed orders whose
+// bill has it, and orders still in service holding its code, so a coupon is
+// taken from the moment it is applied
+func (r *promotionRepository) usedBy(ctx context.Context, promotionID int) *gorm.DB {
+	return getDB(r.db, ctx).Model(&model.Order{}).
+		Where("(orders.order_status = ? AND EXISTS (SELECT 1 FROM order_promotions WHERE order_promotions.order_id = orders.id AND order_promotions.promotion_id = ?))"+
+			" OR (orders.order_status NOT IN ? AND orders.coupon_code <> '' AND orders.coupon_code = (SELECT promotions.code FROM promotions WHERE promotions.id = ?))",
+			vo.OrderStatusCompleted.String(), promotionID,
+			[]string{vo.OrderStatusCompleted.String(), vo.OrderCancelled.String()}, promotionID)
+}
+
+// fillUsage sets how many orders use each promotion
+func (r *promotionRepository) fillUsage(ctx context.Context, promotions []*entity.Promotion) error {
+	for _, promotion := range promotions {
+		count, err := r.CountUsage(ctx, promotion.ID, 0)
+		if err != nil {
+			return err
+		}
+		promotion.UsageCount = count
+	}
+	return nil
+}
+
+// Helper methods
+func (r *promotionRepository) entityToModel(promotion *entity.Promotion) *model.Promotion {
+	dbPromotion := &model.Promotion{
+		ID:             promotion.ID,
+		Name:           promotion.Name,
+		Type:           promotion.Type.String(),
+		PercentOff:     promotion.PercentOff,
+		AmountOff:      promotion.AmountOff.AmountSatang(),
+		BuyQuantity:    promotion.BuyQuantity,
+		FreeQuantity:   promotion.FreeQuantity,
+		MenuItemIDs:    joinIDs(promotion.MenuItemIDs),
+		CategoryIDs:    joinIDs(promotion.CategoryIDs),
+		MinSpend:       promotion.MinSpend.AmountSatang(),
+		StartsAt:       promotion.StartsAt,
+		EndsAt:         promotion.EndsAt,
+		HappyHourStart: promotion.HappyHourStart,
+		HappyHourEnd:   promotion.HappyHourEnd,
+		Priority:       promotion.Priority,
+		Stackable:      promotion.Stackable,
+		UsageLimit:     promotion.UsageLimit,
+		IsActive:       promotion.IsActive,
+		CreatedBy:      promotion.CreatedBy,
+		CreatedAt:      promotion.CreatedAt,
+		UpdatedAt:      promotion.UpdatedAt,
+	}
+	if promotion.Code != "" {
+		code := promotion.Code
+		dbPromotion.Code = &code
+	}
+	return dbPromotion
+}
+
+func (r *promotionRepository) modelToEntity(dbPromotion *model.Promotion) *entity.Promotion {
+	amountOff, _ := vo.NewMoneyFromSatang(dbPromotion.AmountOff)
+	minSpend, _ := vo.NewMoneyFromSatang(dbPromotion.MinSpend)
+
+	promotion := &entity.Promotion{
+		ID:             dbPromotion.ID,
+		Name:           dbPromotion.Name,
+		Type:           vo.PromotionType(dbPromotion.Type),
+		PercentOff:     dbPromotion.PercentOff,
+		AmountOff:      amountOff,
+		BuyQuantity:    dbPromotion.BuyQuantity,
+		FreeQuantity:   dbPromotion.FreeQuantity,
+		MenuItemIDs:    splitIDs(dbPromotion.MenuItemIDs),
+		CategoryIDs:    splitIDs(dbPromotion.CategoryIDs),
+		MinSpend:       minSpend,
+		StartsAt:       dbPromotion.StartsAt,
+		EndsAt:         dbPromotion.EndsAt,
+		HappyHourStart: dbPromotion.HappyHourStart,
+		HappyHourEnd:   dbPromotion.HappyHourEnd,
+		Priority:       dbPromotion.Priority,
+		Stackable:      dbPromotion.Stackable,
+		UsageLimit:     dbPromotion.UsageLimit,
+		IsActive:       dbPromotion.IsActive,
+		CreatedBy:      dbPromotion.CreatedBy,
+		CreatedAt:      dbPromotion.CreatedAt,
+		UpdatedAt:      dbPromotion.UpdatedAt,
+	}
+	if dbPromotion.Code != nil {
+		promotion.Code = *dbPromotion.Code
+	}
+	return promotion
+}
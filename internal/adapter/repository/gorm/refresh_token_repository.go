@@ -0,0 +1,117 @@
+// internal/adapter/repository/refresh_token_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepository struct {
+	baseRepository
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	dbToken := r.entityToModel(token)
+
+	if err := getDB(r.db, ctx).Create(dbToken).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbToken), nil
+}
+
+func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	var dbToken model.RefreshToken
+
+	if err := getDB(r.db, ctx).Where("token_hash = ?", tokenHash).First(&dbToken).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbToken), nil
+}
+
+// Revoke revokes the token; it returns ErrTokenRevoked when the token was
+// already revoked, e.g. by a concurrent refresh with the same token
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id int) error {
+	result := getDB(r.db, ctx).Model(&model.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrTokenRevoked
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeBySession(ctx context.Context, sessionID string) error {
+	return getDB(r.db, ctx).Model(&model.RefreshToken{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *refreshTokenRepository) RevokeByTerminal(ctx context.Context, terminalID int) error {
+	return getDB(r.db, ctx).Model(&model.RefreshToken{}).
+		Where("terminal_id = ? AND revoked_at IS NULL", terminalID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *refreshTokenRepository) RevokeAllByUser(ctx context.Context, userID int) error {
+	return getDB(r.db, ctx).Model(&model.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *refreshTokenRepository) HasActiveSession(ctx context.Context, sessionID string) (bool, error) {
+	var count int64
+	err := getDB(r.db, ctx).Model(&model.RefreshToken{}).
+		Where("session_id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Helper methods
+func (r *refreshTokenRepository) entityToModel(token *entity.RefreshToken) *model.RefreshToken {
+	return &model.RefreshToken{
+		ID:         token.ID,
+		UserID:     token.UserID,
+		SessionID:  token.SessionID,
+		TerminalID: token.TerminalID,
+		TokenHash:  token.TokenHash,
+		ExpiresAt:  token.ExpiresAt,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+func (r *refreshTokenRepository) modelToEntity(dbToken *model.RefreshToken) *entity.RefreshToken {
+	return &entity.RefreshToken{
+		ID:         dbToken.ID,
+		UserID:     dbToken.UserID,
+		SessionID:  dbToken.SessionID,
+		TerminalID: dbToken.TerminalID,
+		TokenHash:  dbToken.TokenHash,
+		ExpiresAt:  dbToken.ExpiresAt,
+		RevokedAt:  dbToken.RevokedAt,
+		CreatedAt:  dbToken.CreatedAt,
+	}
+}
@@ -12,6 +12,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// partialAmountSQL sums the payments taken against orders that are not yet paid in full
+const partialAmountSQL = "COALESCE(SUM(CASE WHEN orders.payment_status = 'partial' THEN payments.amount ELSE 0 END), 0)"
+
 type revenueRepository struct {
 	baseRepository
 }
@@ -22,30 +25,47 @@ func NewRevenueRepository(db *gorm.DB) repository.RevenueRepository {
 	}
 }
 
+// settledPayments scopes a query to the payments that have not been refunded,
+// joined to their orders
+func (r *revenueRepository) settledPayments(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Joins("LEFT JOIN orders ON orders.id = payments.order_id").
+		Where("payments.refunded_at IS NULL")
+}
+
 func (r *revenueRepository) GetDailyRevenue(ctx context.Context, date time.Time) (*entity.DailyRevenue, error) {
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
-	var totalAmount int64
+	var result struct {
+		Amount        int64
+		PartialAmount int64
+	}
 
-	err := r.db.WithContext(ctx).
-		Model(&model.Payment{}).
-		Where("paid_at >= ? AND paid_at < ?", startOfDay, endOfDay).
-		Select("COALESCE(SUM(amount), 0)").
-		Scan(&totalAmount).Error
+	err := r.settledPayments(ctx).
+		Where("payments.paid_at >= ? AND payments.paid_at < ?", startOfDay, endOfDay).
+		Select("COALESCE(SUM(payments.amount), 0) as amount, " + partialAmountSQL + " as partial_amount").
+		Scan(&result).Error
 
 	if err != nil {
 		return nil, err
 	}
 
-	revenue, err := vo.NewMoneyFromSatang(totalAmount)
+	revenue, err := vo.NewMoneyFromSatang(result.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	partial, err := vo.NewMoneyFromSatang(result.PartialAmount)
 	if err != nil {
 		return nil, err
 	}
 
 	return &entity.DailyRevenue{
-		Date:         startOfDay,
-		TotalRevenue: revenue,
+		Date:           startOfDay,
+		TotalRevenue:   revenue,
+		PartialRevenue: partial,
 	}, nil
 }
 
@@ -53,42 +73,50 @@ func (r *revenueRepository) GetMonthlyRevenue(ctx context.Context, year int, mon
 	startOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	endOfMonth := startOfMonth.AddDate(0, 1, 0)
 
-	var totalAmount int64
+	var result struct {
+		Amount        int64
+		PartialAmount int64
+	}
 
-	err := r.db.WithContext(ctx).
-		Model(&model.Payment{}).
-		Where("paid_at >= ? AND paid_at < ?", startOfMonth, endOfMonth).
-		Select("COALESCE(SUM(amount), 0)").
-		Scan(&totalAmount).Error
+	err := r.settledPayments(ctx).
+		Where("payments.paid_at >= ? AND payments.paid_at < ?", startOfMonth, endOfMonth).
+		Select("COALESCE(SUM(payments.amount), 0) as amount, " + partialAmountSQL + " as partial_amount").
+		Scan(&result).Error
 
 	if err != nil {
 		return nil, err
 	}
 
-	revenue, err := vo.NewMoneyFromSatang(totalAmount)
+	revenue, err := vo.NewMoneyFromSatang(result.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	partial, err := vo.NewMoneyFromSatang(result.PartialAmount)
 	if err != nil {
 		return nil, err
 	}
 
 	return &entity.MonthlyRevenue{
-		Month:        startOfMonth,
-		TotalRevenue: revenue,
+		Month:          startOfMonth,
+		TotalRevenue:   revenue,
+		PartialRevenue: partial,
 	}, nil
 }
 
 func (r *revenueRepository) GetDailyRevenueRange(ctx context.Context, startDate, endDate time.Time) ([]*entity.DailyRevenue, error) {
 	type DailyRevenueResult struct {
-		Date   time.Time
-		Amount int64
+		Date          time.Time
+		Amount        int64
+		PartialAmount int64
 	}
 
 	var results []DailyRevenueResult
 
-	err := r.db.WithContext(ctx).
-		Model(&model.Payment{}).
-		Select("DATE(paid_at) as date, COALESCE(SUM(amount), 0) as amount").
-		Where("paid_at >= ? AND paid_at <= ?", startDate, endDate).
-		Group("DATE(paid_at)").
+	err := r.settledPayments(ctx).
+		Select("DATE(payments.paid_at) as date, COALESCE(SUM(payments.amount), 0) as amount, "+partialAmountSQL+" as partial_amount").
+		Where("payments.paid_at >= ? AND payments.paid_at <= ?", startDate, endDate).
+		Group("DATE(payments.paid_at)").
 		Order("date").
 		Scan(&results).Error
 
@@ -103,9 +131,15 @@ func (r *revenueRepository) GetDailyRevenueRange(ctx context.Context, startDate,
 			return nil, err
 		}
 
+		partial, err := vo.NewMoneyFromSatang(result.PartialAmount)
+		if err != nil {
+			return nil, err
+		}
+
 		revenues[i] = &entity.DailyRevenue{
-			Date:         result.Date,
-			TotalRevenue: revenue,
+			Date:           result.Date,
+			TotalRevenue:   revenue,
+			PartialRevenue: partial,
 		}
 	}
 
@@ -114,17 +148,17 @@ func (r *revenueRepository) GetDailyRevenueRange(ctx context.Context, startDate,
 
 func (r *revenueRepository) GetMonthlyRevenueRange(ctx context.Context, startDate, endDate time.Time) ([]*entity.MonthlyRevenue, error) {
 	type MonthlyRevenueResult struct {
-		Month  time.Time
-		Amount int64
+		Month         time.Time
+		Amount        int64
+		PartialAmount int64
 	}
 
 	var results []MonthlyRevenueResult
 
-	err := r.db.WithContext(ctx).
-		Model(&model.Payment{}).
-		Select("DATE_TRUNC('month', paid_at) as month, COALESCE(SUM(amount), 0) as amount").
-		Where("paid_at >= ? AND paid_at <= ?", startDate, endDate).
-		Group("DATE_TRUNC('month', paid_at)").
+	err := r.settledPayments(ctx).
+		Select("DATE_TRUNC('month', payments.paid_at) as month, COALESCE(SUM(payments.amount), 0) as amount, "+partialAmountSQL+" as partial_amount").
+		Where("payments.paid_at >= ? AND payments.paid_at <= ?", startDate, endDate).
+		Group("DATE_TRUNC('month', payments.paid_at)").
 		Order("month").
 		Scan(&results).Error
 
@@ -139,9 +173,15 @@ func (r *revenueRepository) GetMonthlyRevenueRange(ctx context.Context, startDat
 			return nil, err
 		}
 
+		partial, err := vo.NewMoneyFromSatang(result.PartialAmount)
+		if err != nil {
+			return nil, err
+		}
+
 		revenues[i] = &entity.MonthlyRevenue{
-			Month:        result.Month,
-			TotalRevenue: revenue,
+			Month:          result.Month,
+			TotalRevenue:   revenue,
+			PartialRevenue: partial,
 		}
 	}
 
@@ -151,10 +191,9 @@ func (r *revenueRepository) GetMonthlyRevenueRange(ctx context.Context, startDat
 func (r *revenueRepository) GetTotalRevenue(ctx context.Context, startDate, endDate time.Time) (float64, error) {
 	var totalAmount int64
 
-	err := r.db.WithContext(ctx).
-		Model(&model.Payment{}).
-		Where("paid_at >= ? AND paid_at <= ?", startDate, endDate).
-		Select("COALESCE(SUM(amount), 0)").
+	err := r.settledPayments(ctx).
+		Where("payments.paid_at >= ? AND payments.paid_at <= ?", startDate, endDate).
+		Select("COALESCE(SUM(payments.amount), 0)").
 		Scan(&totalAmount).Error
 
 	if err != nil {
@@ -168,3 +207,227 @@ func (r *revenueRepository) GetTotalRevenue(ctx context.Context, startDate, endD
 
 	return revenue.AmountBaht(), nil
 }
+
+func (r *revenueRepository) GetPartialRevenue(ctx context.Context, startDate, endDate time.Time) (float64, error) {
+	var partialAmount int64
+
+	err := r.settledPayments(ctx).
+		Where("payments.paid_at >= ? AND payments.paid_at <= ?", startDate, endDate).
+		Select(partialAmountSQL).
+		Scan(&partialAmount).Error
+
+	if err != nil {
+		return 0, err
+	}
+
+	partial, err := vo.NewMoneyFromSatang(partialAmount)
+	if err != nil {
+		return 0, err
+	}
+
+	return partial.AmountBaht(), nil
+}
+
+func (r *revenueRepository) GetRevenueByOrderType(ctx context.Context, startDate, endDate time.Time) ([]*entity.OrderTypeRevenue, error) {
+	type OrderTypeRevenueResult struct {
+		OrderType  string
+		Amount     int64
+		OrderCount int
+	}
+
+	var results []OrderTypeRevenueResult
+
+	err := r.settledPayments(ctx).
+		Select("COALESCE(orders.order_type, 'dine_in') as order_type, COALESCE(SUM(payments.amount), 0) as amount, COUNT(DISTINCT payments.order_id) as order_count").
+		Where("payments.paid_at >= ? AND payments.paid_at <= ?", startDate, endDate).
+		Group("COALESCE(orders.order_type, 'dine_in')").
+		Order("order_type").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	revenues := make([]*entity.OrderTypeRevenue, len(results))
+	for i, result := range results {
+		revenue, err := vo.NewMoneyFromSatang(result.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		revenues[i] = &entity.OrderTypeRevenue{
+			OrderType:    vo.OrderType(result.OrderType),
+			TotalRevenue: revenue,
+			OrderCount:   result.OrderCount,
+		}
+	}
+
+	return revenues, nil
+}
+
+func (r *revenueRepository) GetPromotionRevenue(ctx context.Context, startDate, endDate time.Time) ([]*entity.PromotionRevenue, error) {
+	type PromotionRevenueResult struct {
+		PromotionID int
+		Name        string
+		Code        string
+		Amount      int64
+		OrderCount  int
+	}
+
+	var results []PromotionRevenueResult
+
+	// Promotion lines of orders completed in the range
+	err := r.db.WithContext(ctx).Model(&model.OrderPromotion{}).
+		Select("order_promotions.promotion_id, MAX(order_promotions.name) as name, MAX(order_promotions.code) as code, COALESCE(SUM(order_promotions.amount), 0) as amount, COUNT(DISTINCT order_promotions.order_id) as order_count").
+		Joins("JOIN orders ON orders.id = order_promotions.order_id AND orders.deleted_at IS NULL").
+		Where("orders.order_status = ? AND orders.closed_at >= ? AND orders.closed_at <= ?", vo.OrderStatusCompleted.String(), startDate, endDate).
+		Group("order_promotions.promotion_id").
+		Order("amount DESC").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	revenues := make([]*entity.PromotionRevenue, len(results))
+	for i, result := range results {
+		discount, err := vo.NewMoneyFromSatang(result.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		revenues[i] = &entity.PromotionRevenue{
+			PromotionID:    result.PromotionID,
+			Name:           result.Name,
+			Code:           result.Code,
+			DiscountAmount: discount,
+			OrderCount:     result.OrderCount,
+		}
+	}
+
+	return revenues, nil
+}
+
+func (r *revenueRepository) GetItemDiscountRevenue(ctx context.Context, startDate, endDate time.Time) ([]*entity.ItemDiscountRevenue, error) {
+	type ItemDiscountRevenueResult struct {
+		MenuItemID int
+		Name       string
+		Quantity   int
+		Markdown   int64
+		Discount   int64
+	}
+
+	var results []ItemDiscountRevenueResult
+
+	// Discounted items of orders completed in the range, rounding each
+	// markdown the way the bill did
+	err := r.db.WithContext(ctx).Model(&model.OrderItem{}).
+		Select("order_items.item_id as menu_item_id, MAX(order_items.name) as name, COALESCE(SUM(order_items.quantity), 0) as quantity, COALESCE(SUM(ROUND(CAST(order_items.unit_price * order_items.quantity * order_items.discount_percent / 100 AS NUMERIC))), 0) as markdown, COALESCE(SUM(order_items.discount), 0) as discount").
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("orders.order_status = ? AND orders.closed_at >= ? AND orders.closed_at <= ?", vo.OrderStatusCompleted.String(), startDate, endDate).
+		Where("order_items.item_status NOT IN ? AND (order_items.discount_percent > 0 OR order_items.discount > 0)", []string{vo.ItemStatusCancelled.String(), vo.ItemStatusDraft.String()}).
+		Group("order_items.item_id").
+		Order("quantity DESC").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	revenues := make([]*entity.ItemDiscountRevenue, len(results))
+	for i, result := range results {
+		markdown, err := vo.NewMoneyFromSatang(result.Markdown)
+		if err != nil {
+			return nil, err
+		}
+		discount, err := vo.NewMoneyFromSatang(result.Discount)
+		if err != nil {
+			return nil, err
+		}
+
+		revenues[i] = &entity.ItemDiscountRevenue{
+			MenuItemID:     result.MenuItemID,
+			Name:           result.Name,
+			Quantity:       result.Quantity,
+			MarkdownAmount: markdown,
+			DiscountAmount: discount,
+		}
+	}
+
+	return revenues, nil
+}
+
+func (r *revenueRepository) GetItemAdjustmentRevenue(ctx context.Context, startDate, endDate time.Time) ([]*entity.ItemAdjustmentRevenue, error) {
+	type ItemAdjustmentRevenueResult struct {
+		Adjustment       string
+		AdjustmentReason string
+		ItemCount        int
+		Quantity         int
+		Amount           int64
+	}
+
+	var results []ItemAdjustmentRevenueResult
+
+	// Items voided or comped in the range, whether or not their order has
+	// been completed yet
+	err := r.db.WithContext(ctx).Model(&model.OrderItem{}).
+		Select("order_items.adjustment, order_items.adjustment_reason, COUNT(*) as item_count, COALESCE(SUM(order_items.quantity), 0) as quantity, COALESCE(SUM(order_items.adjusted_amount), 0) as amount").
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("order_items.adjustment <> '' AND order_items.adjusted_at >= ? AND order_items.adjusted_at <= ?", startDate, endDate).
+		Group("order_items.adjustment, order_items.adjustment_reason").
+		Order("order_items.adjustment, amount DESC").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	revenues := make([]*entity.ItemAdjustmentRevenue, len(results))
+	for i, result := range results {
+		amount, err := vo.NewMoneyFromSatang(result.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		revenues[i] = &entity.ItemAdjustmentRevenue{
+			Adjustment: vo.ItemAdjustment(result.Adjustment),
+			ReasonCode: result.AdjustmentReason,
+			ItemCount:  result.ItemCount,
+			Quantity:   result.Quantity,
+			Amount:     amount,
+		}
+	}
+
+	return revenues, nil
+}
+
+func (r *revenueRepository) GetCoverRevenue(ctx context.Context, startDate, endDate time.Time) (*entity.CoverRevenue, error) {
+	var result struct {
+		Covers     int
+		OrderCount int
+		Amount     int64
+	}
+
+	// Only orders that recorded their guests count towards the spend per
+	// cover, so orders without covers do not inflate it
+	err := r.db.WithContext(ctx).Model(&model.Order{}).
+		Select("COALESCE(SUM(orders.guest_count), 0) as covers, COUNT(*) as order_count, COALESCE(SUM(orders.total), 0) as amount").
+		Where("orders.order_status = ? AND orders.closed_at >= ? AND orders.closed_at <= ?", vo.OrderStatusCompleted.String(), startDate, endDate).
+		Where("orders.guest_count > 0").
+		Scan(&result).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	revenue, err := vo.NewMoneyFromSatang(result.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CoverRevenue{
+		Covers:       result.Covers,
+		OrderCount:   result.OrderCount,
+		TotalRevenue: revenue,
+	}, nil
+}
@@ -0,0 +1,184 @@
+// internal/adapter/repository/shift_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type shiftRepository struct {
+	baseRepository
+}
+
+func NewShiftRepository(db *gorm.DB) repository.ShiftRepository {
+	return &shiftRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *shiftRepository) Create(ctx context.Context, shift *entity.Shift) (*entity.Shift, error) {
+	dbShift := r.entityToModel(shift)
+
+	if err := getDB(r.db, ctx).Omit("Breaks").Create(dbShift).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbShift), nil
+}
+
+func (r *shiftRepository) GetByID(ctx context.Context, id int) (*entity.Shift, error) {
+	var dbShift model.Shift
+
+	if err := getDB(r.db, ctx).Preload("Breaks", orderBreaks).First(&dbShift, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbShift), nil
+}
+
+func (r *shiftRepository) GetOpenByUser(ctx context.Context, userID int) (*entity.Shift, error) {
+	var dbShift model.Shift
+
+	err := getDB(r.db, ctx).Preload("Breaks", orderBreaks).
+		Where("user_id = ? AND clock_out_at IS NULL", userID).
+		Order("clock_in_at DESC").
+		First(&dbShift).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbShift), nil
+}
+
+func (r *shiftRepository) Update(ctx context.Context, shift *entity.Shift) (*entity.Shift, error) {
+	dbShift := r.entityToModel(shift)
+
+	if err := getDB(r.db, ctx).Omit("Breaks").Save(dbShift).Error; err != nil {
+		return nil, err
+	}
+
+	updated := r.modelToEntity(dbShift)
+	updated.Breaks = shift.Breaks
+	return updated, nil
+}
+
+func (r *shiftRepository) List(ctx context.Context, filter repository.ShiftFilter, limit, offset int) ([]*entity.Shift, error) {
+	var dbShifts []model.Shift
+
+	query := r.applyFilter(getDB(r.db, ctx), filter).Preload("Breaks", orderBreaks).Order("clock_in_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&dbShifts).Error; err != nil {
+		return nil, err
+	}
+
+	shifts := make([]*entity.Shift, len(dbShifts))
+	for i := range dbShifts {
+		shifts[i] = r.modelToEntity(&dbShifts[i])
+	}
+	return shifts, nil
+}
+
+func (r *shiftRepository) Count(ctx context.Context, filter repository.ShiftFilter) (int, error) {
+	var count int64
+
+	if err := r.applyFilter(getDB(r.db, ctx).Model(&model.Shift{}), filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *shiftRepository) SaveBreak(ctx context.Context, shiftBreak *entity.ShiftBreak) (*entity.ShiftBreak, error) {
+	dbBreak := r.breakToModel(shiftBreak)
+
+	if err := getDB(r.db, ctx).Save(dbBreak).Error; err != nil {
+		return nil, err
+	}
+
+	shiftBreak.ID = dbBreak.ID
+	return shiftBreak, nil
+}
+
+func (r *shiftRepository) applyFilter(query *gorm.DB, filter repository.ShiftFilter) *gorm.DB {
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.From != nil {
+		query = query.Where("clock_in_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("clock_in_at <= ?", *filter.To)
+	}
+	return query
+}
+
+func orderBreaks(db *gorm.DB) *gorm.DB {
+	return db.Order("started_at")
+}
+
+// Helper methods
+func (r *shiftRepository) entityToModel(shift *entity.Shift) *model.Shift {
+	return &model.Shift{
+		ID:         shift.ID,
+		UserID:     shift.UserID,
+		ClockInAt:  shift.ClockInAt,
+		ClockOutAt: shift.ClockOutAt,
+		ClosedBy:   shift.ClosedBy,
+		Note:       shift.Note,
+		CreatedAt:  shift.CreatedAt,
+		UpdatedAt:  shift.UpdatedAt,
+	}
+}
+
+func (r *shiftRepository) modelToEntity(dbShift *model.Shift) *entity.Shift {
+	breaks := make([]*entity.ShiftBreak, len(dbShift.Breaks))
+	for i := range dbShift.Breaks {
+		breaks[i] = r.breakToEntity(&dbShift.Breaks[i])
+	}
+
+	return &entity.Shift{
+		ID:         dbShift.ID,
+		UserID:     dbShift.UserID,
+		ClockInAt:  dbShift.ClockInAt,
+		ClockOutAt: dbShift.ClockOutAt,
+		ClosedBy:   dbShift.ClosedBy,
+		Note:       dbShift.Note,
+		Breaks:     breaks,
+		CreatedAt:  dbShift.CreatedAt,
+		UpdatedAt:  dbShift.UpdatedAt,
+	}
+}
+
+func (r *shiftRepository) breakToModel(shiftBreak *entity.ShiftBreak) *model.ShiftBreak {
+	return &model.ShiftBreak{
+		ID:        shiftBreak.ID,
+		ShiftID:   shiftBreak.ShiftID,
+		StartedAt: shiftBreak.StartedAt,
+		EndedAt:   shiftBreak.EndedAt,
+	}
+}
+
+func (r *shiftRepository) breakToEntity(dbBreak *model.ShiftBreak) *entity.ShiftBreak {
+	return &entity.ShiftBreak{
+		ID:        dbBreak.ID,
+		ShiftID:   dbBreak.ShiftID,
+		StartedAt: dbBreak.StartedAt,
+		EndedAt:   dbBreak.EndedAt,
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type tableRepository struct {
@@ -44,6 +45,20 @@ func (r *tableRepository) GetByID(ctx context.Context, id int) (*entity.Table, e
 	return r.modelToEntity(&dbTable), nil
 }
 
+// GetByIDForUpdate reads the table and locks its row until the transaction ends
+func (r *tableRepository) GetByIDForUpdate(ctx context.Context, id int) (*entity.Table, error) {
+	var dbTable model.Table
+
+	if err := getDB(r.db, ctx).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&dbTable, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbTable), nil
+}
+
 func (r *tableRepository) GetByNumber(ctx context.Context, number int) (*entity.Table, error) {
 	var dbTable model.Table
 
@@ -0,0 +1,115 @@
+// internal/adapter/repository/terminal_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type terminalRepository struct {
+	baseRepository
+}
+
+func NewTerminalRepository(db *gorm.DB) repository.TerminalRepository {
+	return &terminalRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *terminalRepository) Create(ctx context.Context, terminal *entity.Terminal) (*entity.Terminal, error) {
+	dbTerminal := r.entityToModel(terminal)
+
+	if err := getDB(r.db, ctx).Create(dbTerminal).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbTerminal), nil
+}
+
+func (r *terminalRepository) GetByID(ctx context.Context, id int) (*entity.Terminal, error) {
+	var dbTerminal model.Terminal
+
+	if err := getDB(r.db, ctx).First(&dbTerminal, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbTerminal), nil
+}
+
+func (r *terminalRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Terminal, error) {
+	var dbTerminal model.Terminal
+
+	if err := getDB(r.db, ctx).Where("token_hash = ?", tokenHash).First(&dbTerminal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbTerminal), nil
+}
+
+func (r *terminalRepository) Update(ctx context.Context, terminal *entity.Terminal) (*entity.Terminal, error) {
+	dbTerminal := r.entityToModel(terminal)
+
+	if err := getDB(r.db, ctx).Save(dbTerminal).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbTerminal), nil
+}
+
+func (r *terminalRepository) Delete(ctx context.Context, id int) error {
+	return getDB(r.db, ctx).Delete(&model.Terminal{}, id).Error
+}
+
+func (r *terminalRepository) List(ctx context.Context) ([]*entity.Terminal, error) {
+	var dbTerminals []model.Terminal
+
+	if err := getDB(r.db, ctx).Order("id").Find(&dbTerminals).Error; err != nil {
+		return nil, err
+	}
+
+	terminals := make([]*entity.Terminal, len(dbTerminals))
+	for i := range dbTerminals {
+		terminals[i] = r.modelToEntity(&dbTerminals[i])
+	}
+	return terminals, nil
+}
+
+func (r *terminalRepository) UpdateLastUsed(ctx context.Context, id int) error {
+	return getDB(r.db, ctx).Model(&model.Terminal{}).Where("id = ?", id).Update("last_used_at", time.Now()).Error
+}
+
+// Helper methods
+func (r *terminalRepository) entityToModel(terminal *entity.Terminal) *model.Terminal {
+	return &model.Terminal{
+		ID:         terminal.ID,
+		Name:       terminal.Name,
+		TokenHash:  terminal.TokenHash,
+		IsActive:   terminal.IsActive,
+		LastUsedAt: terminal.LastUsedAt,
+		CreatedAt:  terminal.CreatedAt,
+		UpdatedAt:  terminal.UpdatedAt,
+	}
+}
+
+func (r *terminalRepository) modelToEntity(dbTerminal *model.Terminal) *entity.Terminal {
+	return &entity.Terminal{
+		ID:         dbTerminal.ID,
+		Name:       dbTerminal.Name,
+		TokenHash:  dbTerminal.TokenHash,
+		IsActive:   dbTerminal.IsActive,
+		LastUsedAt: dbTerminal.LastUsedAt,
+		CreatedAt:  dbTerminal.CreatedAt,
+		UpdatedAt:  dbTerminal.UpdatedAt,
+	}
+}
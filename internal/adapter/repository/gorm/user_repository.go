@@ -62,7 +62,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.
 func (r *userRepository) Update(ctx context.Context, user *entity.User) (*entity.User, error) {
 	dbUser := r.entityToModel(user)
 
-	if err := r.db.WithContext(ctx).Save(dbUser).Error; err != nil {
+	if err := getDB(r.db, ctx).Save(dbUser).Error; err != nil {
 		return nil, err
 	}
 
@@ -120,6 +120,7 @@ func (r *userRepository) entityToModel(user *entity.User) *model.User {
 		ID:            user.ID,
 		Email:         user.Email,
 		PasswordHash:  user.PasswordHash,
+		PinHash:       user.PinHash,
 		Role:          user.Role.String(),
 		IsActive:      user.IsActive,
 		EmailVerified: user.EmailVerified,
@@ -139,6 +140,7 @@ func (r *userRepository) modelToEntity(dbUser *model.User) (*entity.User, error)
 		ID:            dbUser.ID,
 		Email:         dbUser.Email,
 		PasswordHash:  dbUser.PasswordHash,
+		PinHash:       dbUser.PinHash,
 		Role:          role,
 		IsActive:      dbUser.IsActive,
 		EmailVerified: dbUser.EmailVerified,
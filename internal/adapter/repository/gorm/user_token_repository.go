@@ -0,0 +1,92 @@
+// internal/adapter/repository/user_token_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/adapter/repository/gorm/model"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"gorm.io/gorm"
+)
+
+type userTokenRepository struct {
+	baseRepository
+}
+
+func NewUserTokenRepository(db *gorm.DB) repository.UserTokenRepository {
+	return &userTokenRepository{
+		baseRepository: baseRepository{db: db},
+	}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, token *entity.UserToken) (*entity.UserToken, error) {
+	dbToken := r.entityToModel(token)
+
+	if err := getDB(r.db, ctx).Create(dbToken).Error; err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(dbToken)
+}
+
+func (r *userTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.UserToken, error) {
+	var dbToken model.UserToken
+
+	if err := getDB(r.db, ctx).Where("token_hash = ?", tokenHash).First(&dbToken).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.modelToEntity(&dbToken)
+}
+
+func (r *userTokenRepository) MarkUsed(ctx context.Context, id int) (bool, error) {
+	result := getDB(r.db, ctx).Model(&model.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *userTokenRepository) InvalidateByUser(ctx context.Context, userID int, purpose vo.TokenPurpose) error {
+	return getDB(r.db, ctx).Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose.String()).
+		Update("used_at", time.Now()).Error
+}
+
+// Helper methods
+func (r *userTokenRepository) entityToModel(token *entity.UserToken) *model.UserToken {
+	return &model.UserToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Purpose:   token.Purpose.String(),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+func (r *userTokenRepository) modelToEntity(dbToken *model.UserToken) (*entity.UserToken, error) {
+	purpose, err := vo.NewTokenPurpose(dbToken.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.UserToken{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		Purpose:   purpose,
+		TokenHash: dbToken.TokenHash,
+		ExpiresAt: dbToken.ExpiresAt,
+		UsedAt:    dbToken.UsedAt,
+		CreatedAt: dbToken.CreatedAt,
+	}, nil
+}
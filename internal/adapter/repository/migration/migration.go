@@ -9,6 +9,10 @@ import (
 func AutoMigrate(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&model.User{},
+		&model.RefreshToken{},
+		&model.UserToken{},
+		&model.Terminal{},
+		&model.APIKey{},
 		&model.Category{},
 		&model.MenuItem{},
 		&model.MenuOption{},
@@ -16,9 +20,17 @@ func AutoMigrate(db *gorm.DB) error {
 		&model.MenuItemOption{},
 		&model.Table{},
 		&model.Order{},
+		&model.QueueCounter{},
+		&model.OrderStatusChange{},
 		&model.OrderItem{},
 		&model.OrderItemOption{},
 		&model.Payment{},
+		&model.Promotion{},
+		&model.OrderPromotion{},
 		&model.KitchenStation{},
+		&model.AuditLog{},
+		&model.Approval{},
+		&model.Shift{},
+		&model.ShiftBreak{},
 	)
 }
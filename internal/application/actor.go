@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// Request context keys under which the auth middleware stores the caller.
+// Fiber locals are visible through the request context by these keys.
+const (
+	CtxKeyUserID     = "userID"
+	CtxKeyUserRole   = "userRole"
+	CtxKeySessionID  = "sessionID"
+	CtxKeyTerminalID = "terminalID"
+	CtxKeyAPIKeyID   = "apiKeyID"
+	CtxKeyScopes     = "apiKeyScopes"
+)
+
+// actorIDFromContext returns the ID of the staff member performing the
+// request, or nil for unauthenticated (e.g. customer QR) requests
+func actorIDFromContext(ctx context.Context) *int {
+	if userID, ok := ctx.Value(CtxKeyUserID).(int); ok && userID > 0 {
+		return &userID
+	}
+	return nil
+}
+
+// actorRoleFromContext returns the role of the staff member performing the
+// request, or an empty role when there is none
+func actorRoleFromContext(ctx context.Context) vo.UserRole {
+	role, _ := ctx.Value(CtxKeyUserRole).(string)
+	return vo.UserRole(role)
+}
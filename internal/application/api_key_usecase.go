@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/config"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"github.com/hydr0g3nz/poc_pos_restuarant/utils"
+)
+
+const (
+	// apiKeyPrefix marks API keys so they are recognisable in configs and logs
+	apiKeyPrefix = "pos_"
+	// apiKeyShownLength is how much of a key is kept in the clear to tell keys apart
+	apiKeyShownLength = len(apiKeyPrefix) + 8
+	// apiKeyLastUsedInterval throttles last-used updates on busy keys
+	apiKeyLastUsedInterval = time.Minute
+)
+
+// apiKeyUsecase implements APIKeyUsecase interface
+type apiKeyUsecase struct {
+	apiKeyRepo repository.APIKeyRepository
+	logger     infra.Logger
+	config     *config.Config
+}
+
+// NewAPIKeyUsecase creates a new API key usecase
+func NewAPIKeyUsecase(
+	apiKeyRepo repository.APIKeyRepository,
+	logger infra.Logger,
+	config *config.Config,
+) APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+		config:     config,
+	}
+}
+
+// CreateAPIKey issues an API key and returns it; the key is only shown once
+func (u *apiKeyUsecase) CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*APIKeyCreatedResponse, error) {
+	u.logger.Info("Creating API key", "name", req.Name, "scopes", req.Scopes)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errs.ErrInvalidAPIKeyName
+	}
+
+	scopes, err := parseAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		u.logger.Error("Error generating API key", "error", err)
+		return nil, err
+	}
+
+	apiKey := &entity.APIKey{
+		Name:      name,
+		Prefix:    key[:apiKeyShownLength],
+		KeyHash:   utils.HashSha256([]byte(key)),
+		Scopes:    scopes,
+		CreatedBy: actorIDFromContext(ctx),
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	createdKey, err := u.apiKeyRepo.Create(ctx, apiKey)
+	if err != nil {
+		u.logger.Error("Error creating API key", "error", err, "name", name)
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	u.logger.Info("API key created successfully", "apiKeyID", createdKey.ID, "name", createdKey.Name)
+
+	return &APIKeyCreatedResponse{
+		APIKey: u.toAPIKeyResponse(createdKey),
+		Key:    key,
+	}, nil
+}
+
+// ListAPIKeys retrieves all API keys, including revoked ones
+func (u *apiKeyUsecase) ListAPIKeys(ctx context.Context) ([]*APIKeyResponse, error) {
+	u.logger.Debug("Listing API keys")
+
+	keys, err := u.apiKeyRepo.List(ctx)
+	if err != nil {
+		u.logger.Error("Error listing API keys", "error", err)
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	responses := make([]*APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = u.toAPIKeyResponse(key)
+	}
+	return responses, nil
+}
+
+// RotateAPIKey replaces the secret of an API key, keeping its name and
+// scopes. The previous key stops working immediately.
+func (u *apiKeyUsecase) RotateAPIKey(ctx context.Context, id int) (*APIKeyCreatedResponse, error) {
+	u.logger.Info("Rotating API key", "apiKeyID", id)
+
+	apiKey, err := u.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	if apiKey == nil {
+		return nil, errs.ErrAPIKeyNotFound
+	}
+	if apiKey.IsRevoked() {
+		return nil, errs.ErrAPIKeyRevoked
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		u.logger.Error("Error generating API key", "error", err)
+		return nil, err
+	}
+
+	apiKey.Prefix = key[:apiKeyShownLength]
+	apiKey.KeyHash = utils.HashSha256([]byte(key))
+	apiKey.LastUsedAt = nil
+	apiKey.UpdatedAt = time.Now()
+
+	rotatedKey, err := u.apiKeyRepo.Update(ctx, apiKey)
+	if err != nil {
+		u.logger.Error("Error rotating API key", "error", err, "apiKeyID", id)
+		return nil, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	u.logger.Info("API key rotated successfully", "apiKeyID", id)
+
+	return &APIKeyCreatedResponse{
+		APIKey: u.toAPIKeyResponse(rotatedKey),
+		Key:    key,
+	}, nil
+}
+
+// RevokeAPIKey stops an API key from being accepted
+func (u *apiKeyUsecase) RevokeAPIKey(ctx context.Context, id int) error {
+	u.logger.Info("Revoking API key", "apiKeyID", id)
+
+	apiKey, err := u.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+	if apiKey == nil {
+		return errs.ErrAPIKeyNotFound
+	}
+	if apiKey.IsRevoked() {
+		return nil
+	}
+
+	apiKey.Revoke()
+	if _, err := u.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		u.logger.Error("Error revoking API key", "error", err, "apiKeyID", id)
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	u.logger.Info("API key revoked successfully", "apiKeyID", id)
+	return nil
+}
+
+// AuthenticateAPIKey resolves the integration calling with an API key
+func (u *apiKeyUsecase) AuthenticateAPIKey(ctx context.Context, key string) (*AuthUser, error) {
+	if key == "" {
+		return nil, errs.ErrMissingToken
+	}
+
+	apiKey, err := u.apiKeyRepo.GetByKeyHash(ctx, utils.HashSha256([]byte(key)))
+	if err != nil {
+		u.logger.Error("Error getting API key", "error", err)
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	if apiKey == nil || !apiKey.IsUsable() {
+		u.logger.Warn("Rejected API key")
+		return nil, errs.ErrInvalidAPIKey
+	}
+
+	if apiKey.LastUsedAt == nil || time.Since(*apiKey.LastUsedAt) > apiKeyLastUsedInterval {
+		if err := u.apiKeyRepo.UpdateLastUsed(ctx, apiKey.ID); err != nil {
+			// Don't reject the request for this error
+			u.logger.Error("Error updating API key last used", "error", err, "apiKeyID", apiKey.ID)
+		}
+	}
+
+	return &AuthUser{
+		APIKeyID: apiKey.ID,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
+
+// parseAPIKeyScopes validates the permissions requested for an API key
+func parseAPIKeyScopes(requested []string) ([]vo.Permission, error) {
+	scopes := make([]vo.Permission, 0, len(requested))
+	seen := make(map[vo.Permission]bool, len(requested))
+	for _, scope := range requested {
+		permission, err := vo.ParsePermission(scope)
+		if err != nil {
+			return nil, errs.ErrInvalidAPIKeyScopes.WithField("scope", scope)
+		}
+		if !permission.IsAPIKeyScope() {
+			return nil, errs.ErrInvalidAPIKeyScopes.WithField("scope", scope)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			scopes = append(scopes, permission)
+		}
+	}
+
+	if len(scopes) == 0 {
+		return nil, errs.ErrInvalidAPIKeyScopes
+	}
+	return scopes, nil
+}
+
+// generateAPIKey returns a new random API key
+func generateAPIKey() (string, error) {
+	secret, err := utils.RandomHex(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return apiKeyPrefix + secret, nil
+}
+
+// toAPIKeyResponse converts entity to response
+func (u *apiKeyUsecase) toAPIKeyResponse(apiKey *entity.APIKey) *APIKeyResponse {
+	scopes := make([]string, len(apiKey.Scopes))
+	for i, scope := range apiKey.Scopes {
+		scopes[i] = scope.String()
+	}
+
+	return &APIKeyResponse{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     scopes,
+		CreatedBy:  apiKey.CreatedBy,
+		LastUsedAt: apiKey.LastUsedAt,
+		ExpiresAt:  apiKey.ExpiresAt,
+		RevokedAt:  apiKey.RevokedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/config"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// approvalUsecase implements ApprovalUsecase interface
+type approvalUsecase struct {
+	approvalRepo  repository.ApprovalRepository
+	userRepo      repository.UserRepository
+	orderRepo     repository.OrderRepository
+	orderItemRepo repository.OrderItemRepository
+	paymentRepo   repository.PaymentRepository
+	logger        infra.Logger
+	config        *config.Config
+	lockout       *loginLockout
+}
+
+// NewApprovalUsecase creates a new approval usecase
+func NewApprovalUsecase(
+	approvalRepo repository.ApprovalRepository,
+	userRepo repository.UserRepository,
+	orderRepo repository.OrderRepository,
+	orderItemRepo repository.OrderItemRepository,
+	paymentRepo repository.PaymentRepository,
+	cache infra.CacheService,
+	rateLimiter infra.RateLimiter,
+	logger infra.Logger,
+	config *config.Config,
+) ApprovalUsecase {
+	return &approvalUsecase{
+		approvalRepo:  approvalRepo,
+		userRepo:      userRepo,
+		orderRepo:     orderRepo,
+		orderItemRepo: orderItemRepo,
+		paymentRepo:   paymentRepo,
+		logger:        logger,
+		config:        config,
+		lockout:       newLoginLockout(cache, rateLimiter, logger, config),
+	}
+}
+
+// RequestApproval creates a pending approval for a manager to decide remotely
+func (u *approvalUsecase) RequestApproval(ctx context.Context, req *RequestApprovalRequest) (*ApprovalResponse, error) {
+	u.logger.Info("Requesting approval", "action", req.Action, "orderID", req.OrderID)
+
+	action, err := vo.NewApprovalAction(req.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	target := entity.ApprovalTarget{
+		Action:      action,
+		OrderID:     req.OrderID,
+		OrderItemID: req.OrderItemID,
+		PaymentID:   req.PaymentID,
+	}
+	if err := u.validateTarget(ctx, target); err != nil {
+		return nil, err
+	}
+
+	approval, err := entity.NewApproval(target, req.ReasonCode, req.Note, actorIDFromContext(ctx), u.expiration())
+	if err != nil {
+		return nil, err
+	}
+
+	createdApproval, err := u.approvalRepo.Create(ctx, approval)
+	if err != nil {
+		u.logger.Error("Error creating approval", "error", err, "orderID", req.OrderID)
+		return nil, fmt.Errorf("failed to create approval: %w", err)
+	}
+
+	u.logger.Info("Approval requested", "approvalID", createdApproval.ID, "action", action)
+
+	return u.toApprovalResponse(createdApproval), nil
+}
+
+// GetApproval retrieves an approval, e.g. for the requesting device to poll
+func (u *approvalUsecase) GetApproval(ctx context.Context, id int) (*ApprovalResponse, error) {
+	approval, err := u.approvalRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting approval", "error", err, "approvalID", id)
+		return nil, fmt.Errorf("failed to get approval: %w", err)
+	}
+	if approval == nil {
+		return nil, errs.ErrApprovalNotFound
+	}
+
+	return u.toApprovalResponse(approval), nil
+}
+
+// ListPendingApprovals lists approvals waiting for a manager's decision
+func (u *approvalUsecase) ListPendingApprovals(ctx context.Context, limit, offset int) (*ApprovalListResponse, error) {
+	status := vo.ApprovalStatusPending.String()
+
+	approvals, err := u.approvalRepo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		u.logger.Error("Error listing pending approvals", "error", err)
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+
+	total, err := u.approvalRepo.CountByStatus(ctx, status)
+	if err != nil {
+		u.logger.Error("Error counting pending approvals", "error", err)
+		return nil, fmt.Errorf("failed to count approvals: %w", err)
+	}
+
+	responses := make([]*ApprovalResponse, len(approvals))
+	for i, approval := range approvals {
+		responses[i] = u.toApprovalResponse(approval)
+	}
+
+	return &ApprovalListResponse{
+		Approvals: responses,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}, nil
+}
+
+// ApproveRequest grants a pending approval as the calling manager
+func (u *approvalUsecase) ApproveRequest(ctx context.Context, id int) (*ApprovalResponse, error) {
+	return u.decide(ctx, id, (*entity.Approval).Approve)
+}
+
+// RejectRequest declines a pending approval as the calling manager
+func (u *approvalUsecase) RejectRequest(ctx context.Context, id int) (*ApprovalResponse, error) {
+	return u.decide(ctx, id, (*entity.Approval).Reject)
+}
+
+// AuthorizeAction consumes a manager approval for the target change. A
+// remotely granted approval is marked used; a manager PIN creates an approval
+// that is granted and used at once.
+func (u *approvalUsecase) AuthorizeAction(ctx context.Context, input *ApprovalInput, target entity.ApprovalTarget) (int, error) {
+	if input == nil || (input.ApprovalID == nil && input.ManagerPin == "") {
+		return 0, errs.ErrApprovalRequired
+	}
+
+	if input.ApprovalID != nil {
+		approval, err := u.approvalRepo.GetByID(ctx, *input.ApprovalID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get approval: %w", err)
+		}
+		if approval == nil {
+			return 0, errs.ErrApprovalNotFound
+		}
+		if err := approval.Use(target); err != nil {
+			u.logger.Warn("Approval cannot be used", "error", err, "approvalID", approval.ID, "action", target.Action)
+			return 0, err
+		}
+		// Only one request may use the approval, however many present it at once
+		if _, err := u.approvalRepo.Update(ctx, approval, vo.ApprovalStatusApproved); err != nil {
+			if errors.Is(err, errs.ErrApprovalNotGranted) {
+				u.logger.Warn("Approval already used", "approvalID", approval.ID, "action", target.Action)
+				return 0, err
+			}
+			return 0, fmt.Errorf("failed to update approval: %w", err)
+		}
+		return approval.ID, nil
+	}
+
+	manager, err := u.verifyManagerPin(ctx, input.ManagerID, input.ManagerPin)
+	if err != nil {
+		return 0, err
+	}
+
+	approval, err := entity.NewApproval(target, input.ReasonCode, input.Note, actorIDFromContext(ctx), u.expiration())
+	if err != nil {
+		return 0, err
+	}
+	if err := approval.Approve(manager.ID); err != nil {
+		return 0, err
+	}
+	if err := approval.Use(target); err != nil {
+		return 0, err
+	}
+
+	createdApproval, err := u.approvalRepo.Create(ctx, approval)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create approval: %w", err)
+	}
+
+	u.logger.Info("Action approved by manager PIN", "approvalID", createdApproval.ID, "managerID", manager.ID, "action", target.Action)
+
+	return createdApproval.ID, nil
+}
+
+func (u *approvalUsecase) decide(ctx context.Context, id int, decision func(*entity.Approval, int) error) (*ApprovalResponse, error) {
+	managerID := actorIDFromContext(ctx)
+	if managerID == nil {
+		return nil, errs.ErrNotAnApprover
+	}
+
+	approval, err := u.approvalRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting approval", "error", err, "approvalID", id)
+		return nil, fmt.Errorf("failed to get approval: %w", err)
+	}
+	if approval == nil {
+		return nil, errs.ErrApprovalNotFound
+	}
+
+	if err := decision(approval, *managerID); err != nil {
+		return nil, err
+	}
+
+	updatedApproval, err := u.approvalRepo.Update(ctx, approval, vo.ApprovalStatusPending)
+	if errors.Is(err, errs.ErrApprovalAlreadyDecided) {
+		u.logger.Warn("Approval decided by someone else", "approvalID", id)
+		return nil, err
+	}
+	if err != nil {
+		u.logger.Error("Error updating approval", "error", err, "approvalID", id)
+		return nil, fmt.Errorf("failed to update approval: %w", err)
+	}
+
+	u.logger.Info("Approval decided", "approvalID", id, "status", updatedApproval.Status, "managerID", *managerID)
+
+	return u.toApprovalResponse(updatedApproval), nil
+}
+
+// verifyManagerPin checks the PIN of a staff member allowed to grant
+// approvals. Failures count towards the same lockout as PIN logins.
+func (u *approvalUsecase) verifyManagerPin(ctx context.Context, managerID int, pin string) (*entity.User, error) {
+	subject := pinSubject(managerID, u.config)
+	if err := u.lockout.check(ctx, subject); err != nil {
+		return nil, err
+	}
+
+	manager, err := u.userRepo.GetByID(ctx, managerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manager: %w", err)
+	}
+	if manager == nil || !manager.IsActive || !manager.HasPin() ||
+		bcrypt.CompareHashAndPassword([]byte(manager.PinHash), []byte(pin)) != nil {
+		u.logger.Warn("Invalid manager PIN", "managerID", managerID)
+		return nil, u.lockout.recordFailure(ctx, errs.ErrInvalidManagerPin, subject)
+	}
+	u.lockout.reset(ctx, subject)
+	if !manager.Role.HasPermission(vo.PermApprovalGrant) {
+		u.logger.Warn("Approval attempted by non-manager", "userID", managerID, "role", manager.Role)
+		return nil, errs.ErrNotAnApprover
+	}
+	return manager, nil
+}
+
+// validateTarget checks that the order, item and payment of a request belong together
+func (u *approvalUsecase) validateTarget(ctx context.Context, target entity.ApprovalTarget) error {
+	order, err := u.orderRepo.GetByID(ctx, target.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return errs.ErrOrderNotFound
+	}
+
+	if target.OrderItemID != nil {
+		item, err := u.orderItemRepo.GetByID(ctx, *target.OrderItemID)
+		if err != nil {
+			return fmt.Errorf("failed to get order item: %w", err)
+		}
+		if item == nil || item.OrderID != target.OrderID {
+			return errs.ErrOrderItemNotFound
+		}
+	}
+
+	if target.PaymentID != nil {
+		payment, err := u.paymentRepo.GetByID(ctx, *target.PaymentID)
+		if err != nil {
+			return fmt.Errorf("failed to get payment: %w", err)
+		}
+		if payment == nil || payment.OrderID != target.OrderID {
+			return errs.ErrPaymentNotFound
+		}
+	}
+
+	return nil
+}
+
+func (u *approvalUsecase) expiration() time.Duration {
+	return time.Duration(u.config.App.ApprovalExpiration) * time.Minute
+}
+
+// toApprovalResponse converts entity to response
+func (u *approvalUsecase) toApprovalResponse(approval *entity.Approval) *ApprovalResponse {
+	return &ApprovalResponse{
+		ID:          approval.ID,
+		Action:      approval.Action.String(),
+		Status:      approval.Status.String(),
+		OrderID:     approval.OrderID,
+		OrderItemID: approval.OrderItemID,
+		PaymentID:   approval.PaymentID,
+		ReasonCode:  approval.ReasonCode,
+		Note:        approval.Note,
+		RequestedBy: approval.RequestedBy,
+		DecidedBy:   approval.DecidedBy,
+		DecidedAt:   approval.DecidedAt,
+		UsedAt:      approval.UsedAt,
+		ExpiresAt:   approval.ExpiresAt,
+		CreatedAt:   approval.CreatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// recordAudit appends an audit log entry for the staff member on the request.
+// Callers pass their transaction context so the entry is committed or rolled
+// back together with the change it describes.
+func recordAudit(ctx context.Context, auditRepo repository.AuditLogRepository, action vo.AuditAction, entityType string, entityID int, before, after any) error {
+	return writeAudit(ctx, auditRepo, nil, action, entityType, entityID, before, after)
+}
+
+// recordApprovedAudit is recordAudit for a change made under a manager
+// approval; the entry links the approval and so its reason code
+func recordApprovedAudit(ctx context.Context, auditRepo repository.AuditLogRepository, approvalID int, action vo.AuditAction, entityType string, entityID int, before, after any) error {
+	return writeAudit(ctx, auditRepo, &approvalID, action, entityType, entityID, before, after)
+}
+
+func writeAudit(ctx context.Context, auditRepo repository.AuditLogRepository, approvalID *int, action vo.AuditAction, entityType string, entityID int, before, after any) error {
+	auditLog, err := entity.NewAuditLog(actorIDFromContext(ctx), action, entityType, entityID, before, after)
+	if err != nil {
+		return err
+	}
+	auditLog.ApprovalID = approvalID
+
+	if _, err := auditRepo.Create(ctx, auditLog); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
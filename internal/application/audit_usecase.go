@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/config"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// auditUsecase implements AuditUsecase interface
+type auditUsecase struct {
+	auditLogRepo repository.AuditLogRepository
+	logger       infra.Logger
+	config       *config.Config
+}
+
+// NewAuditUsecase creates a new audit usecase
+func NewAuditUsecase(
+	auditLogRepo repository.AuditLogRepository,
+	logger infra.Logger,
+	config *config.Config,
+) AuditUsecase {
+	return &auditUsecase{
+		auditLogRepo: auditLogRepo,
+		logger:       logger,
+		config:       config,
+	}
+}
+
+// ListAuditLogs retrieves audit log entries matching the filter, newest first
+func (u *auditUsecase) ListAuditLogs(ctx context.Context, req *AuditLogFilterRequest, limit, offset int) (*AuditLogListResponse, error) {
+	u.logger.Debug("Listing audit logs", "action", req.Action, "entityType", req.EntityType, "entityID", req.EntityID)
+
+	if req.Action != "" {
+		if _, err := vo.NewAuditAction(req.Action); err != nil {
+			return nil, err
+		}
+	}
+
+	filter := repository.AuditLogFilter{
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		From:       req.From,
+		To:         req.To,
+	}
+
+	logs, err := u.auditLogRepo.List(ctx, filter, limit, offset)
+	if err != nil {
+		u.logger.Error("Error listing audit logs", "error", err)
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	total, err := u.auditLogRepo.Count(ctx, filter)
+	if err != nil {
+		u.logger.Error("Error counting audit logs", "error", err)
+		return nil, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	return &AuditLogListResponse{
+		AuditLogs: u.toAuditLogResponses(logs),
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}, nil
+}
+
+// toAuditLogResponses converts slice of entities to responses
+func (u *auditUsecase) toAuditLogResponses(logs []*entity.AuditLog) []*AuditLogResponse {
+	responses := make([]*AuditLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = &AuditLogResponse{
+			ID:         log.ID,
+			ActorID:    log.ActorID,
+			Action:     log.Action.String(),
+			EntityType: log.EntityType,
+			EntityID:   log.EntityID,
+			ApprovalID: log.ApprovalID,
+			Before:     log.Before,
+			After:      log.After,
+			CreatedAt:  log.CreatedAt,
+		}
+	}
+	return responses
+}
@@ -4,6 +4,8 @@ package usecase
 import (
 	"context"
 	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
 )
 
 // CategoryUsecase handles category business logic
@@ -45,25 +47,40 @@ type OrderUsecase interface {
 	GetOrderWithItems(ctx context.Context, id int) (*OrderWithItemsResponse, error)
 	UpdateOrder(ctx context.Context, id int, req *UpdateOrderRequest) (*OrderResponse, error)
 	CloseOrder(ctx context.Context, id int) (*OrderResponse, error)
+	ReopenOrder(ctx context.Context, id int, req *ReopenOrderRequest) (*OrderResponse, error)
+	TransferOrder(ctx context.Context, id int, req *TransferOrderRequest) (*OrderResponse, error)
+	MergeOrders(ctx context.Context, id int, req *MergeOrderRequest) (*OrderWithItemsResponse, error)
+	UpdateGuestCount(ctx context.Context, id int, req *UpdateGuestCountRequest) (*OrderResponse, error)
 	ListOrders(ctx context.Context, limit, offset int) (*OrderListResponse, error)
 	ListOrdersWithItems(ctx context.Context, limit, offset int) (*OrderWithItemsListResponse, error)
 	ListOrdersByTable(ctx context.Context, tableID int, limit, offset int) (*OrderListResponse, error)
 	GetOpenOrderByTable(ctx context.Context, tableID int) (*OrderResponse, error)
 	GetOrdersByStatus(ctx context.Context, status string, limit, offset int) (*OrderListResponse, error)
+	GetOrdersByType(ctx context.Context, orderType string, limit, offset int) (*OrderListResponse, error)
 	GetOrdersByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) (*OrderListResponse, error)
 	PrintOrderReceipt(ctx context.Context, orderID int) error
 	PrintOrderQRCode(ctx context.Context, orderID int) error
 	GetOrderIDFromQRCode(ctx context.Context, qrCode string) (int, error)
+	AuthenticateOrderToken(ctx context.Context, qrCode string) (int, error)
 	// Order item operations
 	AddOrderItem(ctx context.Context, req *AddOrderItemRequest) (*OrderItemResponse, error)
 	AddOrderItemList(ctx context.Context, req *AddOrderItemListRequest) ([]*OrderItemResponse, error)
 	UpdateOrderItem(ctx context.Context, id int, req *UpdateOrderItemRequest) (*OrderItemResponse, error)
-	RemoveOrderItem(ctx context.Context, id int) error
+	RemoveOrderItem(ctx context.Context, id int, req *VoidOrderItemRequest) error
+	CompOrderItem(ctx context.Context, id int, req *CompOrderItemRequest) (*OrderItemResponse, error)
+	ListAdjustmentReasons(ctx context.Context) *AdjustmentReasonsResponse
+	ApplyItemDiscount(ctx context.Context, id int, req *ApplyItemDiscountRequest) (*OrderItemResponse, error)
 	ListOrderItems(ctx context.Context, orderID int) ([]*OrderItemResponse, error)
 	UpdateOrderItemList(ctx context.Context, req *UpdateOrderItemListRequest) ([]*OrderItemResponse, error)
 	ManageOrderItemList(ctx context.Context, req *ManageOrderItemListRequest) ([]*OrderItemResponse, error)
+	SubmitRound(ctx context.Context, orderID int, req *SubmitRoundRequest) (*OrderWithItemsResponse, error)
+	RepeatOrderItems(ctx context.Context, orderID int, req *RepeatOrderItemsRequest) (*RepeatOrderItemsResponse, error)
+	FireCourse(ctx context.Context, orderID int, req *FireCourseRequest) ([]*OrderItemResponse, error)
+	HoldCourse(ctx context.Context, orderID int, req *HoldCourseRequest) ([]*OrderItemResponse, error)
 	// Calculate bill
 	CalculateOrderTotal(ctx context.Context, orderID int) (*OrderTotalResponse, error)
+	ApplyCoupon(ctx context.Context, orderID int, req *ApplyCouponRequest) (*OrderTotalResponse, error)
+	RemoveCoupon(ctx context.Context, orderID int) (*OrderTotalResponse, error)
 
 	// Enhanced listing with better pagination and filters
 	ListOrdersWithCount(ctx context.Context, limit, offset int) (*OrderListResponse, error)
@@ -83,10 +100,39 @@ type OrderUsecase interface {
 type PaymentUsecase interface {
 	ProcessPayment(ctx context.Context, req *ProcessPaymentRequest) (*PaymentResponse, error)
 	GetPayment(ctx context.Context, id int) (*PaymentResponse, error)
-	GetPaymentByOrder(ctx context.Context, orderID int) (*PaymentResponse, error)
+	ListPaymentsByOrder(ctx context.Context, orderID int) (*OrderPaymentsResponse, error)
+	SplitBill(ctx context.Context, orderID int, req *SplitBillRequest) (*SplitBillResponse, error)
+	PrintPaymentReceipt(ctx context.Context, id int) error
 	ListPayments(ctx context.Context, limit, offset int) (*PaymentListResponse, error)
 	ListPaymentsByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) (*PaymentListResponse, error)
 	ListPaymentsByMethod(ctx context.Context, method string, limit, offset int) (*PaymentListResponse, error)
+	RefundPayment(ctx context.Context, id int, req *RefundPaymentRequest) (*PaymentResponse, error)
+}
+
+// ApprovalUsecase handles manager approvals of sensitive changes
+type ApprovalUsecase interface {
+	RequestApproval(ctx context.Context, req *RequestApprovalRequest) (*ApprovalResponse, error)
+	GetApproval(ctx context.Context, id int) (*ApprovalResponse, error)
+	ListPendingApprovals(ctx context.Context, limit, offset int) (*ApprovalListResponse, error)
+	ApproveRequest(ctx context.Context, id int) (*ApprovalResponse, error)
+	RejectRequest(ctx context.Context, id int) (*ApprovalResponse, error)
+	// AuthorizeAction consumes a manager approval for the target change and
+	// returns its ID; call it with the transaction context of the change
+	AuthorizeAction(ctx context.Context, input *ApprovalInput, target entity.ApprovalTarget) (int, error)
+}
+
+// AuditUsecase exposes the audit trail of sensitive actions
+type AuditUsecase interface {
+	ListAuditLogs(ctx context.Context, req *AuditLogFilterRequest, limit, offset int) (*AuditLogListResponse, error)
+}
+
+// PromotionUsecase manages promotions and coupon codes
+type PromotionUsecase interface {
+	CreatePromotion(ctx context.Context, req *PromotionRequest) (*PromotionResponse, error)
+	GetPromotion(ctx context.Context, id int) (*PromotionResponse, error)
+	UpdatePromotion(ctx context.Context, id int, req *PromotionRequest) (*PromotionResponse, error)
+	DeletePromotion(ctx context.Context, id int) error
+	ListPromotions(ctx context.Context, onlyActive bool) ([]*PromotionResponse, error)
 }
 
 // RevenueUsecase handles revenue reporting business logic
@@ -96,6 +142,11 @@ type RevenueUsecase interface {
 	GetDailyRevenueRange(ctx context.Context, startDate, endDate time.Time) ([]*DailyRevenueResponse, error)
 	GetMonthlyRevenueRange(ctx context.Context, startDate, endDate time.Time) ([]*MonthlyRevenueResponse, error)
 	GetTotalRevenue(ctx context.Context, startDate, endDate time.Time) (*TotalRevenueResponse, error)
+	GetRevenueByOrderType(ctx context.Context, startDate, endDate time.Time, orderType string) ([]*OrderTypeRevenueResponse, error)
+	GetPromotionRevenue(ctx context.Context, startDate, endDate time.Time) ([]*PromotionRevenueResponse, error)
+	GetItemDiscountRevenue(ctx context.Context, startDate, endDate time.Time) ([]*ItemDiscountRevenueResponse, error)
+	GetItemAdjustmentRevenue(ctx context.Context, startDate, endDate time.Time) ([]*ItemAdjustmentRevenueResponse, error)
+	GetCoverRevenue(ctx context.Context, startDate, endDate time.Time) (*CoverRevenueResponse, error)
 }
 
 // QRCodeUsecase handles QR code scanning and order creation
@@ -144,7 +195,7 @@ type OrderItemOptionUsecase interface {
 
 // KitchenUsecase - จัดการครัว/การเตรียมอาหาร
 type KitchenUsecase interface {
-	GetKitchenQueue(ctx context.Context) ([]*KitchenOrderResponse, error)
+	GetKitchenQueue(ctx context.Context, orderType string) ([]*KitchenOrderResponse, error)
 	UpdateOrderItemStatus(ctx context.Context, orderItemID int, status string) (*OrderItemResponse, error)
 	GetOrderItemsByStatus(ctx context.Context, status string) ([]*OrderItemResponse, error)
 	MarkOrderItemAsReady(ctx context.Context, orderItemID int) (*OrderItemResponse, error)
@@ -159,6 +210,36 @@ type KitchenStationUsecase interface {
 	ListKitchenStations(ctx context.Context, onlyAvailable bool) ([]*KitchenStationOnlyResponse, error)
 }
 
+// TerminalUsecase handles registration of shared POS terminals
+type TerminalUsecase interface {
+	RegisterTerminal(ctx context.Context, req *RegisterTerminalRequest) (*TerminalRegistrationResponse, error)
+	ListTerminals(ctx context.Context) ([]*TerminalResponse, error)
+	DeactivateTerminal(ctx context.Context, id int) error
+	DeleteTerminal(ctx context.Context, id int) error
+}
+
+// ShiftUsecase manages staff clock-in/out, breaks and end-of-shift summaries
+type ShiftUsecase interface {
+	ClockIn(ctx context.Context, req *ClockInRequest) (*ShiftResponse, error)
+	ClockOut(ctx context.Context, req *ClockOutRequest) (*ShiftSummaryResponse, error)
+	StartBreak(ctx context.Context) (*ShiftResponse, error)
+	EndBreak(ctx context.Context) (*ShiftResponse, error)
+	GetCurrentShift(ctx context.Context) (*ShiftResponse, error)
+	GetShift(ctx context.Context, id int) (*ShiftResponse, error)
+	GetShiftSummary(ctx context.Context, id int) (*ShiftSummaryResponse, error)
+	ListShifts(ctx context.Context, req *ShiftFilterRequest, limit, offset int) (*ShiftListResponse, error)
+	CloseShift(ctx context.Context, id int) (*ShiftSummaryResponse, error)
+}
+
+// APIKeyUsecase manages API keys for machine-to-machine integrations
+type APIKeyUsecase interface {
+	CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*APIKeyCreatedResponse, error)
+	ListAPIKeys(ctx context.Context) ([]*APIKeyResponse, error)
+	RotateAPIKey(ctx context.Context, id int) (*APIKeyCreatedResponse, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*AuthUser, error)
+}
+
 // MenuWithOptionsUsecase - รวมการจัดการ menu item พร้อม options ในที่เดียว
 type MenuWithOptionsUsecase interface {
 	// Create menu item with options in one go
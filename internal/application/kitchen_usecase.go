@@ -51,9 +51,19 @@ func NewKitchenUsecase(
 	}
 }
 
-// GetKitchenQueue retrieves all orders in kitchen queue
-func (u *kitchenUsecase) GetKitchenQueue(ctx context.Context) ([]*KitchenOrderResponse, error) {
-	u.logger.Debug("Getting kitchen queue")
+// GetKitchenQueue retrieves all orders in kitchen queue, optionally of one order type
+func (u *kitchenUsecase) GetKitchenQueue(ctx context.Context, orderType string) ([]*KitchenOrderResponse, error) {
+	u.logger.Debug("Getting kitchen queue", "orderType", orderType)
+
+	var filter vo.OrderType
+	if orderType != "" {
+		t, err := vo.NewOrderType(orderType)
+		if err != nil {
+			u.logger.Error("Invalid order type", "error", err, "orderType", orderType)
+			return nil, err
+		}
+		filter = t
+	}
 
 	// Also get orders that are ordered but not completed
 	orderedOrders, err := u.orderRepo.ListByStatus(ctx, string(vo.OrderStatusOrdered), 100, 0)
@@ -62,6 +72,16 @@ func (u *kitchenUsecase) GetKitchenQueue(ctx context.Context) ([]*KitchenOrderRe
 		return nil, fmt.Errorf("failed to get ordered orders: %w", err)
 	}
 
+	if filter != "" {
+		filtered := orderedOrders[:0]
+		for _, order := range orderedOrders {
+			if order.OrderType == filter {
+				filtered = append(filtered, order)
+			}
+		}
+		orderedOrders = filtered
+	}
+
 	return u.toKitchenOrderResponses(ctx, orderedOrders), nil
 }
 
@@ -75,6 +95,10 @@ func (u *kitchenUsecase) UpdateOrderItemStatus(ctx context.Context, orderItemID
 		u.logger.Error("Invalid item status", "error", err, "status", status)
 		return nil, err
 	}
+	// Items are held, fired and submitted from the order, not the kitchen
+	if itemStatus == vo.ItemStatusHeld || itemStatus == vo.ItemStatusDraft {
+		return nil, errs.ErrInvalidItemStatus
+	}
 
 	// Get current order item
 	orderItem, err := u.orderItemRepo.GetByID(ctx, orderItemID)
@@ -85,9 +109,20 @@ func (u *kitchenUsecase) UpdateOrderItemStatus(ctx context.Context, orderItemID
 	if orderItem == nil {
 		return nil, errs.ErrOrderItemNotFound
 	}
+	if orderItem.InCart() {
+		return nil, errs.ErrItemNotSubmitted
+	}
+	if orderItem.IsHeld() {
+		return nil, errs.ErrItemOnHold
+	}
+	// A voided item stays cancelled
+	if orderItem.IsVoided() {
+		return nil, errs.ErrItemAlreadyAdjusted
+	}
 
 	// Update status
 	orderItem.ItemStatus = itemStatus
+	orderItem.UpdatedBy = actorIDFromContext(ctx)
 
 	// Update timestamps based on status
 	switch itemStatus {
@@ -199,7 +234,7 @@ func (u *kitchenUsecase) GetKitchenOrdersByStation(ctx context.Context, station
 		}
 
 		for _, item := range items {
-			if item.KitchenStation == station {
+			if item.KitchenStation == station && !item.IsHeld() {
 				stationItems = append(stationItems, u.toOrderItemResponse(item))
 			}
 		}
@@ -213,22 +248,31 @@ func (u *kitchenUsecase) GetKitchenOrdersByStation(ctx context.Context, station
 // toOrderItemResponse converts entity to response
 func (u *kitchenUsecase) toOrderItemResponse(item *entity.OrderItem) *OrderItemResponse {
 	return &OrderItemResponse{
-		ID:        item.ID,
-		OrderID:   item.OrderID,
-		ItemID:    item.ItemID,
-		Quantity:  item.Quantity,
-		UnitPrice: item.UnitPrice.AmountBaht(),
-		Subtotal:  item.CalculateSubtotal().AmountBaht(),
-		CreatedAt: item.CreatedAt,
-		Name:      item.Name,
+		ID:              item.ID,
+		OrderID:         item.OrderID,
+		ItemID:          item.ItemID,
+		Quantity:        item.Quantity,
+		UnitPrice:       item.UnitPrice.AmountBaht(),
+		DiscountPercent: item.DiscountPercent,
+		Discount:        item.DiscountAmount().AmountBaht(),
+		Subtotal:        item.CalculateSubtotal().AmountBaht(),
+		CreatedAt:       item.CreatedAt,
+		Name:            item.Name,
+		Status:          item.ItemStatus.String(),
+		Course:          item.Course,
+		Seat:            item.Seat,
+		Round:           item.Round,
+		KitchenStation:  item.KitchenStation,
+		Version:         item.Version,
 	}
 }
 
-// toKitchenOrderResponses converts orders to kitchen order responses
+// toKitchenOrderResponses converts orders to kitchen order responses, one
+// for each round the kitchen has items of
 func (u *kitchenUsecase) toKitchenOrderResponses(ctx context.Context, orders []*entity.Order) []*KitchenOrderResponse {
-	responses := make([]*KitchenOrderResponse, len(orders))
+	responses := make([]*KitchenOrderResponse, 0, len(orders))
 
-	for i, order := range orders {
+	for _, order := range orders {
 		// Get table information
 		var tableNumber *int
 		if order.TableID > 0 {
@@ -245,74 +289,101 @@ func (u *kitchenUsecase) toKitchenOrderResponses(ctx context.Context, orders []*
 			items = []*entity.OrderItem{} // Empty slice to avoid nil
 		}
 
-		kitchenItems := make([]*KitchenOrderItemResponse, len(items))
-		for j, item := range items {
-			// Get order item options
-			itemOptions, err := u.orderItemOptionRepo.GetByOrderItemID(ctx, item.ID)
-			if err != nil {
-				u.logger.Error("Error getting order item options", "error", err, "orderItemID", item.ID)
-				itemOptions = []*entity.OrderItemOption{}
+		// Held items stay off the queue until their course is fired
+		queued := items[:0]
+		for _, item := range items {
+			if !item.IsHeld() {
+				queued = append(queued, item)
 			}
+		}
 
-			// Convert options to responses
-			optionResponses := make([]*OrderItemOptionResponse, len(itemOptions))
-			for k, option := range itemOptions {
-				// Get menu option and value details
-				menuOption, _ := u.menuOptionRepo.GetByID(ctx, option.OptionID)
-				optionValue, _ := u.optionValueRepo.GetByID(ctx, option.ValueID)
-
-				optionResponses[k] = &OrderItemOptionResponse{
-					OrderItemID:     option.OrderItemID,
-					OptionID:        option.OptionID,
-					ValueID:         option.ValueID,
-					AdditionalPrice: option.AdditionalPrice.AmountBaht(),
-				}
-
-				if menuOption != nil {
-					optionResponses[k].Option = &MenuOptionResponse{
-						ID:         menuOption.ID,
-						Name:       menuOption.Name,
-						Type:       menuOption.Type.String(),
-						IsRequired: menuOption.IsRequired,
-					}
-				}
-
-				if optionValue != nil {
-					optionResponses[k].Value = &OptionValueResponse{
-						ID:              optionValue.ID,
-						OptionID:        optionValue.OptionID,
-						Name:            optionValue.Name,
-						IsDefault:       optionValue.IsDefault,
-						AdditionalPrice: optionValue.AdditionalPrice.AmountBaht(),
-						DisplayOrder:    optionValue.DisplayOrder,
-					}
-				}
+		for _, round := range entity.GroupRounds(queued) {
+			kitchenItems := make([]*KitchenOrderItemResponse, len(round.Items))
+			for j, item := range round.Items {
+				kitchenItems[j] = u.toKitchenOrderItemResponse(ctx, item)
 			}
 
-			kitchenItems[j] = &KitchenOrderItemResponse{
-				ID:             item.ID,
-				ItemID:         item.ItemID,
-				Name:           item.Name,
-				Quantity:       item.Quantity,
-				Status:         item.ItemStatus.String(),
-				KitchenStation: item.KitchenStation,
-				KitchenNotes:   item.KitchenNotes,
-				Notes:          item.SpecialReq,
-				Options:        optionResponses,
-				CreatedAt:      item.CreatedAt,
-				ServedAt:       item.ServedAt,
+			responses = append(responses, &KitchenOrderResponse{
+				OrderID:      order.ID,
+				OrderNumber:  order.OrderNumber,
+				TableNumber:  tableNumber,
+				QueueNumber:  order.QueueNumber,
+				CustomerName: order.CustomerName,
+				OrderType:    order.OrderType.String(),
+				Round:        round.Number,
+				Items:        kitchenItems,
+				CreatedAt:    order.CreatedAt,
+				SubmittedAt:  round.SubmittedAt,
+			})
+		}
+	}
+
+	return responses
+}
+
+// toKitchenOrderItemResponse converts an order item with its options to a
+// kitchen response
+func (u *kitchenUsecase) toKitchenOrderItemResponse(ctx context.Context, item *entity.OrderItem) *KitchenOrderItemResponse {
+	// Get order item options
+	itemOptions, err := u.orderItemOptionRepo.GetByOrderItemID(ctx, item.ID)
+	if err != nil {
+		u.logger.Error("Error getting order item options", "error", err, "orderItemID", item.ID)
+		itemOptions = []*entity.OrderItemOption{}
+	}
+
+	// Convert options to responses
+	optionResponses := make([]*OrderItemOptionResponse, len(itemOptions))
+	for k, option := range itemOptions {
+		// Get menu option and value details
+		menuOption, _ := u.menuOptionRepo.GetByID(ctx, option.OptionID)
+		optionValue, _ := u.optionValueRepo.GetByID(ctx, option.ValueID)
+
+		optionResponses[k] = &OrderItemOptionResponse{
+			OrderItemID:     option.OrderItemID,
+			OptionID:        option.OptionID,
+			ValueID:         option.ValueID,
+			AdditionalPrice: option.AdditionalPrice.AmountBaht(),
+		}
+
+		if menuOption != nil {
+			optionResponses[k].Option = &MenuOptionResponse{
+				ID:         menuOption.ID,
+				Name:       menuOption.Name,
+				Type:       menuOption.Type.String(),
+				IsRequired: menuOption.IsRequired,
 			}
 		}
 
-		responses[i] = &KitchenOrderResponse{
-			OrderID:     order.ID,
-			OrderNumber: order.OrderNumber,
-			TableNumber: tableNumber,
-			OrderType:   "dine_in", // Default, could be extended
-			Items:       kitchenItems,
-			CreatedAt:   order.CreatedAt,
+		if optionValue != nil {
+			optionResponses[k].Value = &OptionValueResponse{
+				ID:              optionValue.ID,
+				OptionID:        optionValue.OptionID,
+				Name:            optionValue.Name,
+				IsDefault:       optionValue.IsDefault,
+				AdditionalPrice: optionValue.AdditionalPrice.AmountBaht(),
+				DisplayOrder:    optionValue.DisplayOrder,
+			}
 		}
 	}
 
-	return responses
+	response := &KitchenOrderItemResponse{
+		ID:             item.ID,
+		ItemID:         item.ItemID,
+		Name:           item.Name,
+		Quantity:       item.Quantity,
+		Status:         item.ItemStatus.String(),
+		Course:         item.Course,
+		Seat:           item.Seat,
+		Round:          item.Round,
+		KitchenStation: item.KitchenStation,
+		KitchenNotes:   item.KitchenNotes,
+		Notes:          item.SpecialReq,
+		Options:        optionResponses,
+		CreatedAt:      item.CreatedAt,
+		ServedAt:       item.ServedAt,
+	}
+	if item.IsVoided() {
+		response.CancelReason = item.AdjustmentReason
+	}
+	return response
 }
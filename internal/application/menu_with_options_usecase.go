@@ -8,6 +8,7 @@ import (
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
 	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
 type menuWithOptionsUsecase struct {
@@ -18,6 +19,7 @@ type menuWithOptionsUsecase struct {
 	menuItemOptionRepo repository.MenuItemOptionRepository
 	categoryRepo       repository.CategoryRepository
 	kitchenStationRepo repository.KitchenStationRepository
+	auditLogRepo       repository.AuditLogRepository
 }
 
 func NewMenuWithOptionsUsecase(repo repository.Repository) MenuWithOptionsUsecase {
@@ -29,6 +31,7 @@ func NewMenuWithOptionsUsecase(repo repository.Repository) MenuWithOptionsUsecas
 		menuItemOptionRepo: repo.MenuItemOptionRepository(),
 		categoryRepo:       repo.CategoryRepository(),
 		kitchenStationRepo: repo.KitchenStationRepository(),
+		auditLogRepo:       repo.AuditLogRepository(),
 	}
 }
 
@@ -45,6 +48,9 @@ func (u *menuWithOptionsUsecase) CreateMenuItemWithOptions(ctx context.Context,
 		menuItem.IsActive = req.IsActive
 		menuItem.IsRecommended = req.IsRecommended
 		menuItem.DisplayOrder = req.DisplayOrder
+		if err := menuItem.SetMarkdown(req.DiscountPercent, req.IsDiscounted); err != nil {
+			return nil, err
+		}
 
 		createdItem, err := u.menuItemRepo.Create(ctx, menuItem)
 		if err != nil {
@@ -80,6 +86,7 @@ func (u *menuWithOptionsUsecase) UpdateMenuItemWithOptions(ctx context.Context,
 		}
 
 		// 2. Update menu item
+		oldPrice := existingItem.Price.AmountBaht()
 		existingItem.CategoryID = req.CategoryID
 		existingItem.KitchenID = req.KitchenStationID
 		existingItem.Name = req.Name
@@ -88,11 +95,21 @@ func (u *menuWithOptionsUsecase) UpdateMenuItemWithOptions(ctx context.Context,
 		existingItem.IsActive = req.IsActive
 		existingItem.IsRecommended = req.IsRecommended
 		existingItem.DisplayOrder = req.DisplayOrder
+		if err := existingItem.SetMarkdown(req.DiscountPercent, req.IsDiscounted); err != nil {
+			return nil, err
+		}
 
 		_, err = u.menuItemRepo.Update(ctx, existingItem)
 		if err != nil {
 			return nil, fmt.Errorf("failed to update menu item: %w", err)
 		}
+		if oldPrice != req.Price {
+			before := map[string]float64{"price": oldPrice}
+			after := map[string]float64{"price": req.Price}
+			if err := recordAudit(ctx, u.auditLogRepo, vo.AuditActionMenuPriceChange, entity.AuditEntityMenuItem, itemID, before, after); err != nil {
+				return nil, err
+			}
+		}
 
 		// // 3. Update options - ลบเก่าแล้วเพิ่มใหม่
 		// err = u.menuItemOptionRepo.DeleteByItemID(ctx, itemID)
@@ -206,6 +223,8 @@ func (u *menuWithOptionsUsecase) GetMenuItemWithOptions(ctx context.Context, ite
 		Price:            menuItem.Price.AmountBaht(),
 		IsActive:         menuItem.IsActive,
 		IsRecommended:    menuItem.IsRecommended,
+		DiscountPercent:  menuItem.DiscountPercent,
+		IsDiscounted:     menuItem.IsDiscounted,
 		DisplayOrder:     menuItem.DisplayOrder,
 		Category:         category.Name,
 		KitchenStation:   kitchenStation.Name,
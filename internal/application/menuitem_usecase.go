@@ -9,6 +9,7 @@ import (
 	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
 // menuItemUsecase implements MenuItemUsecase interface
@@ -16,6 +17,8 @@ type menuItemUsecase struct {
 	menuItemRepo repository.MenuItemRepository
 	categoryRepo repository.CategoryRepository
 	kitchenRepo  repository.KitchenStationRepository
+	auditLogRepo repository.AuditLogRepository
+	tx           repository.TxManager
 	logger       infra.Logger
 	config       *config.Config
 }
@@ -25,6 +28,8 @@ func NewMenuItemUsecase(
 	menuItemRepo repository.MenuItemRepository,
 	categoryRepo repository.CategoryRepository,
 	kitchenRepo repository.KitchenStationRepository,
+	auditLogRepo repository.AuditLogRepository,
+	tx repository.TxManager,
 	logger infra.Logger,
 	config *config.Config,
 ) MenuItemUsecase {
@@ -32,6 +37,8 @@ func NewMenuItemUsecase(
 		menuItemRepo: menuItemRepo,
 		categoryRepo: categoryRepo,
 		kitchenRepo:  kitchenRepo,
+		auditLogRepo: auditLogRepo,
+		tx:           tx,
 		logger:       logger,
 		config:       config,
 	}
@@ -121,6 +128,8 @@ func (u *menuItemUsecase) UpdateMenuItem(ctx context.Context, id int, req *Updat
 	}
 
 	// Update menu item fields
+	before := u.toMenuItemResponse(currentMenuItem)
+	priceChanged := currentMenuItem.Price.AmountBaht() != req.Price
 	currentMenuItem.CategoryID = req.CategoryID
 	currentMenuItem.Name = req.Name
 	currentMenuItem.Description = req.Description
@@ -131,13 +140,32 @@ func (u *menuItemUsecase) UpdateMenuItem(ctx context.Context, id int, req *Updat
 		return nil, err
 	}
 
-	// Update menu item
-	updatedMenuItem, err := u.menuItemRepo.Update(ctx, currentMenuItem)
+	// Update menu item, auditing price changes in the same transaction
+	txCtx, err := u.tx.BeginTx(ctx)
 	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	updatedMenuItem, err := u.menuItemRepo.Update(txCtx, currentMenuItem)
+	if err != nil {
+		u.tx.RollbackTx(txCtx)
 		u.logger.Error("Error updating menu item", "error", err, "menuItemID", id)
 		return nil, fmt.Errorf("failed to update menu item: %w", err)
 	}
 
+	if priceChanged {
+		if err := recordAudit(txCtx, u.auditLogRepo, vo.AuditActionMenuPriceChange, entity.AuditEntityMenuItem, id, before, u.toMenuItemResponse(updatedMenuItem)); err != nil {
+			u.tx.RollbackTx(txCtx)
+			u.logger.Error("Error recording price change", "error", err, "menuItemID", id)
+			return nil, err
+		}
+	}
+
+	if err := u.tx.CommitTx(txCtx); err != nil {
+		u.logger.Error("Error committing transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	u.logger.Info("Menu item updated successfully", "menuItemID", id)
 
 	return u.toMenuItemResponse(updatedMenuItem), nil
@@ -271,6 +299,8 @@ func (u *menuItemUsecase) toMenuItemResponse(menuItem *entity.MenuItem) *MenuIte
 		Price:            menuItem.Price.AmountBaht(),
 		IsActive:         menuItem.IsActive,
 		IsRecommended:    menuItem.IsRecommended,
+		DiscountPercent:  menuItem.DiscountPercent,
+		IsDiscounted:     menuItem.IsDiscounted,
 		DisplayOrder:     menuItem.DisplayOrder,
 		// CreatedAt:   menuItem.CreatedAt,
 	}
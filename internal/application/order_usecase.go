@@ -22,10 +22,15 @@ type orderUsecase struct {
 	orderItemRepo          repository.OrderItemRepository
 	tableRepo              repository.TableRepository
 	menuItemRepo           repository.MenuItemRepository
+	paymentRepo            repository.PaymentRepository
+	statusHistoryRepo      repository.OrderStatusHistoryRepository
+	promotionRepo          repository.PromotionRepository
 	orderItemOptionUsecase OrderItemOptionUsecase
+	approvalUsecase        ApprovalUsecase
 	orderService           service.OrderService
 	qrCodeService          service.QRCodeService
 	printerService         infra.PrinterService
+	auditLogRepo           repository.AuditLogRepository
 	tx                     repository.TxManager
 	logger                 infra.Logger
 	config                 *config.Config
@@ -34,25 +39,35 @@ type orderUsecase struct {
 // NewOrderUsecase creates a new order usecase
 func NewOrderUsecase(
 	orderItemOptionUsecase OrderItemOptionUsecase,
+	approvalUsecase ApprovalUsecase,
 	orderRepo repository.OrderRepository,
 	orderItemRepo repository.OrderItemRepository,
 	tableRepo repository.TableRepository,
 	menuItemRepo repository.MenuItemRepository,
+	paymentRepo repository.PaymentRepository,
+	statusHistoryRepo repository.OrderStatusHistoryRepository,
+	promotionRepo repository.PromotionRepository,
 	orderService service.OrderService,
 	qrCodeService service.QRCodeService,
 	printerService infra.PrinterService,
+	auditLogRepo repository.AuditLogRepository,
 	tx repository.TxManager,
 	logger infra.Logger,
 	config *config.Config,
 ) OrderUsecase {
 	return &orderUsecase{
 		orderItemOptionUsecase: orderItemOptionUsecase,
+		approvalUsecase:        approvalUsecase,
 		orderRepo:              orderRepo,
 		orderItemRepo:          orderItemRepo,
 		tableRepo:              tableRepo,
 		menuItemRepo:           menuItemRepo,
+		paymentRepo:            paymentRepo,
+		statusHistoryRepo:      statusHistoryRepo,
+		promotionRepo:          promotionRepo,
 		orderService:           orderService,
 		printerService:         printerService,
+		auditLogRepo:           auditLogRepo,
 		tx:                     tx,
 		logger:                 logger,
 		config:                 config,
@@ -62,22 +77,49 @@ func NewOrderUsecase(
 
 // CreateOrder creates a new order
 func (u *orderUsecase) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*OrderResponse, string, error) {
-	u.logger.Info("Creating order", "tableID", req.TableID)
+	u.logger.Info("Creating order", "tableID", req.TableID, "orderType", req.OrderType)
 
-	// Validate order creation
-	if err := u.orderService.ValidateOrderCreation(ctx, req.TableID); err != nil {
-		u.logger.Error("Order validation failed", "error", err, "tableID", req.TableID)
+	orderType, err := vo.NewOrderType(req.OrderType)
+	if err != nil {
+		u.logger.Error("Invalid order type", "error", err, "orderType", req.OrderType)
 		return nil, "", err
 	}
 
+	// Validate order creation; only dine-in orders take a table
+	tableID, seating := 0, 0
+	if orderType.RequiresTable() {
+		tableID = req.TableID
+		if err := u.orderService.ValidateOrderCreation(ctx, tableID); err != nil {
+			u.logger.Error("Order validation failed", "error", err, "tableID", req.TableID)
+			return nil, "", err
+		}
+		table, err := u.tableRepo.GetByID(ctx, tableID)
+		if err != nil {
+			u.logger.Error("Error getting table", "error", err, "tableID", tableID)
+			return nil, "", fmt.Errorf("failed to get table: %w", err)
+		}
+		if table != nil {
+			seating = table.Seating
+		}
+	}
+
 	// Create order entity
-	order, err := entity.NewOrder(req.TableID)
+	order, err := entity.NewOrderOfType(orderType, tableID, entity.CustomerInfo{
+		Name:            strings.TrimSpace(req.CustomerName),
+		Phone:           strings.TrimSpace(req.CustomerPhone),
+		DeliveryAddress: strings.TrimSpace(req.DeliveryAddress),
+	})
 	if err != nil {
 		u.logger.Error("Error creating order entity", "error", err, "tableID", req.TableID)
 		return nil, "", err
 	}
+	if err := order.SetGuestCount(req.GuestCount, seating, req.OverrideSeating); err != nil {
+		u.logger.Error("Invalid guest count", "error", err, "guestCount", req.GuestCount, "seating", seating)
+		return nil, "", err
+	}
 	qrCode, raw := u.qrCodeService.GenerateQRCodeForOrder(ctx, order.ID)
 	order.QRCode = raw
+	order.CreatedBy = actorIDFromContext(ctx)
 	// qrCodeImageBytes, err := u.qrCodeService.GenerateQRCodeImage(ctx, qrCode)
 	// if err != nil {
 	// 	u.logger.Error("Error generating QR code image", "error", err, "qrCode", qrCode)
@@ -85,14 +127,35 @@ func (u *orderUsecase) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 	// }
 	// qrcodeImageBase64 := base64.StdEncoding.EncodeToString(qrCodeImageBytes)
 
-	// Save to database
-	createdOrder, err := u.orderRepo.Create(ctx, order)
+	// Save to database, opening the order's status history
+	var createdOrder *entity.Order
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		if orderType.RequiresTable() {
+			if err := u.claimTable(ctx, tableID); err != nil {
+				return err
+			}
+		}
+
+		// Orders without a table are called out by their queue number
+		if !orderType.RequiresTable() {
+			order.QueueNumber, err = u.orderRepo.NextQueueNumber(ctx, order.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to assign queue number: %w", err)
+			}
+		}
+
+		createdOrder, err = u.orderRepo.Create(ctx, order)
+		if err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+		return u.recordStatusChange(ctx, entity.NewOrderStatusChange(createdOrder.ID, "", createdOrder.OrderStatus, order.CreatedBy))
+	})
 	if err != nil {
 		u.logger.Error("Error creating order", "error", err, "tableID", req.TableID)
-		return nil, "", fmt.Errorf("failed to create order: %w", err)
+		return nil, "", err
 	}
 
-	u.logger.Info("Order created successfully", "orderID", createdOrder.ID, "tableID", createdOrder.TableID)
+	u.logger.Info("Order created successfully", "orderID", createdOrder.ID, "tableID", createdOrder.TableID, "orderType", createdOrder.OrderType)
 
 	return u.toOrderResponse(createdOrder), qrCode, nil
 }
@@ -128,7 +191,15 @@ func (u *orderUsecase) GetOrderWithItems(ctx context.Context, id int) (*OrderWit
 		return nil, errs.ErrOrderNotFound
 	}
 
-	return u.toOrderWithItemsResponse(order), nil
+	cart, err := u.orderItemRepo.ListCartByOrder(ctx, id)
+	if err != nil {
+		u.logger.Error("Error listing cart items", "error", err, "orderID", id)
+		return nil, fmt.Errorf("failed to list cart items: %w", err)
+	}
+
+	response := u.toOrderWithItemsResponse(order)
+	response.Cart = u.toOrderItemResponses(cart)
+	return response, nil
 }
 
 // UpdateOrder updates order information
@@ -144,6 +215,9 @@ func (u *orderUsecase) UpdateOrder(ctx context.Context, id int, req *UpdateOrder
 	if currentOrder == nil {
 		return nil, errs.ErrOrderNotFound
 	}
+	if err := currentOrder.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, id, err)
+	}
 
 	// Validate and update status
 	newStatus, err := vo.NewOrderStatus(req.Status)
@@ -152,22 +226,38 @@ func (u *orderUsecase) UpdateOrder(ctx context.Context, id int, req *UpdateOrder
 		return nil, err
 	}
 
-	// Update order status
-	if newStatus == vo.OrderStatusCompleted && !currentOrder.IsClosed() {
-		if err := u.orderService.ProcessOrderClosure(ctx, id); err != nil {
-			u.logger.Error("Order closure validation failed", "error", err, "orderID", id)
-			return nil, err
-		}
-		currentOrder.Close()
+	// Completing and reopening have their own checks
+	if newStatus == vo.OrderStatusCompleted {
+		return u.CloseOrder(ctx, id)
+	}
+	if currentOrder.IsClosed() {
+		return nil, errs.ErrOrderAlreadyClosedWithID(id)
 	}
 
-	currentOrder.OrderStatus = newStatus
+	// Update order status
+	change, err := currentOrder.TransitionTo(newStatus, actorIDFromContext(ctx))
+	if err != nil {
+		u.logger.Warn("Order status change not allowed", "error", err, "orderID", id, "status", req.Status)
+		return nil, err
+	}
 
 	// Update order
-	updatedOrder, err := u.orderRepo.Update(ctx, currentOrder)
+	var updatedOrder *entity.Order
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		updatedOrder, err = u.orderRepo.Update(ctx, currentOrder)
+		if err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		if currentOrder.OrderStatus == vo.OrderCancelled {
+			if err := u.discardCart(ctx, id); err != nil {
+				return err
+			}
+		}
+		return u.recordStatusChange(ctx, change)
+	})
 	if err != nil {
 		u.logger.Error("Error updating order", "error", err, "orderID", id)
-		return nil, fmt.Errorf("failed to update order: %w", err)
+		return nil, u.withCurrentState(ctx, id, err)
 	}
 
 	u.logger.Info("Order updated successfully", "orderID", id)
@@ -185,27 +275,73 @@ func (u *orderUsecase) CloseOrder(ctx context.Context, id int) (*OrderResponse,
 		u.logger.Error("Error getting current order", "error", err, "orderID", id)
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
+	if currentOrder == nil {
+		return nil, errs.ErrOrderNotFound
+	}
 
 	// Process order closure
-	if err := u.orderService.ProcessOrderClosure(ctx, id); err != nil && !errors.Is(err, errs.ErrEmptyOrder) {
+	err = u.orderService.ProcessOrderClosure(ctx, id)
+	emptyOrder := errors.Is(err, errs.ErrEmptyOrder)
+	if err != nil && !emptyOrder {
 		u.logger.Error("Order closure validation failed", "error", err, "orderID", id)
 		return nil, err
 	}
-	if err == errs.ErrEmptyOrder {
-		deleteErr := u.orderRepo.Delete(ctx, id)
-		if deleteErr != nil {
-			u.logger.Error("Error deleting empty order", "error", deleteErr, "orderID", id)
-			return nil, fmt.Errorf("failed to delete empty order: %w", deleteErr)
+
+	// Close order; one with nothing ordered is cancelled instead
+	before := u.toOrderResponse(currentOrder)
+	var change *entity.OrderStatusChange
+	if emptyOrder {
+		change, err = currentOrder.Cancel(actorIDFromContext(ctx))
+	} else {
+		currentOrder.Items, err = u.orderItemRepo.ListByOrder(ctx, id)
+		if err != nil {
+			u.logger.Error("Error getting order items", "error", err, "orderID", id)
+			return nil, fmt.Errorf("failed to get order items: %w", err)
 		}
+		change, err = currentOrder.Close(actorIDFromContext(ctx))
+	}
+	if err != nil {
+		u.logger.Warn("Order cannot be closed", "error", err, "orderID", id)
+		return nil, err
 	}
-	// Close order
-	currentOrder.Close()
 
 	// Update order
-	updatedOrder, err := u.orderRepo.Update(ctx, currentOrder)
+	var updatedOrder *entity.Order
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		// Price the final bill with limited promotions locked, so the last
+		// use of one goes to a single order; deposits taken while the order
+		// was open may now settle it in full
+		if !emptyOrder {
+			if err := u.lockLimitedPromotions(ctx); err != nil {
+				return err
+			}
+			total, err := u.orderService.CalculateOrderTotal(ctx, currentOrder)
+			if err != nil {
+				return fmt.Errorf("failed to calculate order total: %w", err)
+			}
+			if !currentOrder.PaidAmount.IsZero() {
+				currentOrder.RefreshPaymentStatus(total)
+			}
+		}
+
+		updatedOrder, err = u.orderRepo.Update(ctx, currentOrder)
+		if err != nil {
+			return fmt.Errorf("failed to close order: %w", err)
+		}
+		if err := u.recordStatusChange(ctx, change); err != nil {
+			return err
+		}
+		if err := u.discardCart(ctx, id); err != nil {
+			return err
+		}
+		if err := u.promotionRepo.ReplaceOrderPromotions(ctx, id, currentOrder.Promotions); err != nil {
+			return fmt.Errorf("failed to record order promotions: %w", err)
+		}
+		return recordAudit(ctx, u.auditLogRepo, vo.AuditActionOrderClose, entity.AuditEntityOrder, id, before, u.toOrderResponse(updatedOrder))
+	})
 	if err != nil {
 		u.logger.Error("Error closing order", "error", err, "orderID", id)
-		return nil, fmt.Errorf("failed to close order: %w", err)
+		return nil, err
 	}
 
 	u.logger.Info("Order closed successfully", "orderID", id)
@@ -213,6 +349,340 @@ func (u *orderUsecase) CloseOrder(ctx context.Context, id int) (*OrderResponse,
 	return u.toOrderResponse(updatedOrder), nil
 }
 
+// ReopenOrder puts a completed order back into service under a manager's approval
+func (u *orderUsecase) ReopenOrder(ctx context.Context, id int, req *ReopenOrderRequest) (*OrderResponse, error) {
+	u.logger.Info("Reopening order", "orderID", id)
+
+	currentOrder, err := u.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting current order", "error", err, "orderID", id)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if currentOrder == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if !currentOrder.IsClosed() {
+		return nil, errs.ErrOrderNotClosed
+	}
+
+	// The table may have been seated again since the order closed
+	if currentOrder.TableID > 0 {
+		openOrder, err := u.orderRepo.GetOpenOrderByTable(ctx, currentOrder.TableID)
+		if err != nil {
+			u.logger.Error("Error checking open order", "error", err, "tableID", currentOrder.TableID)
+			return nil, fmt.Errorf("failed to check open order: %w", err)
+		}
+		if openOrder != nil {
+			return nil, errs.ErrTableAlreadyHasOpenOrderWithContext(currentOrder.TableID, openOrder.ID)
+		}
+	}
+
+	before := u.toOrderResponse(currentOrder)
+	change, err := currentOrder.Reopen(actorIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedOrder *entity.Order
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		if currentOrder.TableID > 0 {
+			if err := u.claimTable(ctx, currentOrder.TableID); err != nil {
+				return err
+			}
+		}
+
+		approvalID, err := u.approvalUsecase.AuthorizeAction(ctx, req.Approval, entity.ApprovalTarget{
+			Action:  vo.ApprovalActionOrderReopen,
+			OrderID: id,
+		})
+		if err != nil {
+			return err
+		}
+
+		updatedOrder, err = u.orderRepo.Update(ctx, currentOrder)
+		if err != nil {
+			return fmt.Errorf("failed to reopen order: %w", err)
+		}
+		if err := u.recordStatusChange(ctx, change); err != nil {
+			return err
+		}
+		return recordApprovedAudit(ctx, u.auditLogRepo, approvalID, vo.AuditActionOrderReopen, entity.AuditEntityOrder, id, before, u.toOrderResponse(updatedOrder))
+	})
+	if err != nil {
+		u.logger.Error("Error reopening order", "error", err, "orderID", id)
+		return nil, err
+	}
+
+	u.logger.Info("Order reopened successfully", "orderID", id)
+
+	return u.toOrderResponse(updatedOrder), nil
+}
+
+// TransferOrder moves an order to another table. A table that already has an
+// open order takes the guests through MergeOrders instead.
+func (u *orderUsecase) TransferOrder(ctx context.Context, id int, req *TransferOrderRequest) (*OrderResponse, error) {
+	u.logger.Info("Transferring order", "orderID", id, "tableID", req.TableID)
+
+	currentOrder, err := u.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting current order", "error", err, "orderID", id)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if currentOrder == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if err := currentOrder.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, id, err)
+	}
+	if !currentOrder.InService() {
+		return nil, errs.ErrOrderNotInService
+	}
+	if currentOrder.TableID == req.TableID {
+		return nil, errs.ErrOrderAlreadyAtTable
+	}
+
+	table, err := u.tableRepo.GetByID(ctx, req.TableID)
+	if err != nil {
+		u.logger.Error("Error getting table", "error", err, "tableID", req.TableID)
+		return nil, fmt.Errorf("failed to get table: %w", err)
+	}
+	if table == nil {
+		return nil, errs.ErrTableNotFoundWithID(req.TableID)
+	}
+
+	openOrder, err := u.orderRepo.GetOpenOrderByTable(ctx, req.TableID)
+	if err != nil {
+		u.logger.Error("Error checking open order", "error", err, "tableID", req.TableID)
+		return nil, fmt.Errorf("failed to check open order: %w", err)
+	}
+	if openOrder != nil {
+		return nil, errs.ErrTableAlreadyHasOpenOrderWithContext(req.TableID, openOrder.ID)
+	}
+
+	before := u.toOrderResponse(currentOrder)
+	if err := currentOrder.TransferTo(req.TableID); err != nil {
+		u.logger.Warn("Order cannot be transferred", "error", err, "orderID", id, "orderType", currentOrder.OrderType)
+		return nil, err
+	}
+	if err := currentOrder.SetGuestCount(currentOrder.GuestCount, table.Seating, req.OverrideSeating); err != nil {
+		u.logger.Warn("Guest count not allowed", "error", err, "orderID", id, "guestCount", currentOrder.GuestCount, "seating", table.Seating)
+		return nil, err
+	}
+	currentOrder.UpdatedBy = actorIDFromContext(ctx)
+
+	var updatedOrder *entity.Order
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		if err := u.claimTable(ctx, req.TableID); err != nil {
+			return err
+		}
+
+		updatedOrder, err = u.orderRepo.Update(ctx, currentOrder)
+		if err != nil {
+			return fmt.Errorf("failed to transfer order: %w", err)
+		}
+		return recordAudit(ctx, u.auditLogRepo, vo.AuditActionOrderTransfer, entity.AuditEntityOrder, id, before, u.toOrderResponse(updatedOrder))
+	})
+	if err != nil {
+		u.logger.Error("Error transferring order", "error", err, "orderID", id)
+		return nil, u.withCurrentState(ctx, id, err)
+	}
+
+	u.logger.Info("Order transferred successfully", "orderID", id, "tableID", req.TableID)
+
+	return u.toOrderResponse(updatedOrder), nil
+}
+
+// UpdateGuestCount changes the number of guests an order is for, checked
+// against the seats of its table unless overridden
+func (u *orderUsecase) UpdateGuestCount(ctx context.Context, id int, req *UpdateGuestCountRequest) (*OrderResponse, error) {
+	u.logger.Info("Updating guest count", "orderID", id, "guestCount", req.GuestCount)
+
+	currentOrder, err := u.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting current order", "error", err, "orderID", id)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if currentOrder == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if err := currentOrder.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, id, err)
+	}
+	if !currentOrder.InService() {
+		return nil, errs.ErrOrderNotInService
+	}
+
+	seating := 0
+	if currentOrder.TableID > 0 {
+		table, err := u.tableRepo.GetByID(ctx, currentOrder.TableID)
+		if err != nil {
+			u.logger.Error("Error getting table", "error", err, "tableID", currentOrder.TableID)
+			return nil, fmt.Errorf("failed to get table: %w", err)
+		}
+		if table != nil {
+			seating = table.Seating
+		}
+	}
+
+	before := u.toOrderResponse(currentOrder)
+	if err := currentOrder.SetGuestCount(req.GuestCount, seating, req.OverrideSeating); err != nil {
+		u.logger.Warn("Guest count not allowed", "error", err, "orderID", id, "guestCount", req.GuestCount, "seating", seating)
+		return nil, err
+	}
+	currentOrder.UpdatedBy = actorIDFromContext(ctx)
+
+	var updatedOrder *entity.Order
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		updatedOrder, err = u.orderRepo.Update(ctx, currentOrder)
+		if err != nil {
+			return fmt.Errorf("failed to update guest count: %w", err)
+		}
+		return recordAudit(ctx, u.auditLogRepo, vo.AuditActionOrderGuests, entity.AuditEntityOrder, id, before, u.toOrderResponse(updatedOrder))
+	})
+	if err != nil {
+		u.logger.Error("Error updating guest count", "error", err, "orderID", id)
+		return nil, u.withCurrentState(ctx, id, err)
+	}
+
+	u.logger.Info("Guest count updated successfully", "orderID", id, "guestCount", req.GuestCount)
+
+	return u.toOrderResponse(updatedOrder), nil
+}
+
+// MergeOrders moves the items and payments of an order into the target order.
+// The target keeps its QR code; the merged order is cancelled, which
+// invalidates its own.
+func (u *orderUsecase) MergeOrders(ctx context.Context, id int, req *MergeOrderRequest) (*OrderWithItemsResponse, error) {
+	u.logger.Info("Merging orders", "orderID", id, "targetOrderID", req.TargetOrderID)
+
+	if id == req.TargetOrderID {
+		return nil, errs.ErrMergeSameOrder
+	}
+
+	source, err := u.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", id)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if source == nil {
+		return nil, errs.ErrOrderNotFoundWithID(id)
+	}
+	if err := source.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, id, err)
+	}
+
+	target, err := u.orderRepo.GetByID(ctx, req.TargetOrderID)
+	if err != nil {
+		u.logger.Error("Error getting target order", "error", err, "orderID", req.TargetOrderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if target == nil {
+		return nil, errs.ErrOrderNotFoundWithID(req.TargetOrderID)
+	}
+	if err := target.CheckVersion(req.TargetVersion); err != nil {
+		return nil, u.withCurrentState(ctx, target.ID, err)
+	}
+
+	if !source.InService() || !target.InService() {
+		return nil, errs.ErrOrderNotInService
+	}
+
+	// The guests of both orders sit at the target's table
+	seating := 0
+	if target.TableID > 0 {
+		table, err := u.tableRepo.GetByID(ctx, target.TableID)
+		if err != nil {
+			u.logger.Error("Error getting table", "error", err, "tableID", target.TableID)
+			return nil, fmt.Errorf("failed to get table: %w", err)
+		}
+		if table != nil {
+			seating = table.Seating
+		}
+	}
+
+	sourceBefore := u.toOrderResponse(source)
+	targetBefore := u.toOrderResponse(target)
+	actorID := actorIDFromContext(ctx)
+	guestCount := target.GuestCount + source.GuestCount
+	targetGuests := target.GuestCount
+	if err := target.SetGuestCount(guestCount, seating, req.OverrideSeating); err != nil {
+		u.logger.Warn("Guest count not allowed", "error", err, "orderID", target.ID, "guestCount", guestCount, "seating", seating)
+		return nil, err
+	}
+	// A version conflict is reported with the state of the order it hit
+	conflictOrderID := source.ID
+
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		// The merged order's rounds follow the target's, and its guests sit
+		// after the target's; what was left in its cart is not carried over
+		targetItems, err := u.orderItemRepo.ListByOrder(ctx, target.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+		roundOffset := target.RoundCount
+		seatOffset := max(targetGuests, entity.LastSeat(targetItems))
+		if err := u.discardCart(ctx, source.ID); err != nil {
+			return err
+		}
+		if err := u.orderItemRepo.MoveToOrder(ctx, source.ID, target.ID, roundOffset, seatOffset); err != nil {
+			return fmt.Errorf("failed to move order items: %w", err)
+		}
+		target.RoundCount += source.RoundCount
+		if err := u.paymentRepo.MoveToOrder(ctx, source.ID, target.ID); err != nil {
+			return fmt.Errorf("failed to move payments: %w", err)
+		}
+
+		change, err := source.MergeInto(target.ID, actorID)
+		if err != nil {
+			return err
+		}
+		mergedSource, err := u.orderRepo.Update(ctx, source)
+		if err != nil {
+			return fmt.Errorf("failed to update merged order: %w", err)
+		}
+		if err := u.recordStatusChange(ctx, change); err != nil {
+			return err
+		}
+
+		// Deposits taken on the merged order now count towards the target
+		payments, err := u.paymentRepo.ListByOrderID(ctx, target.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list order payments: %w", err)
+		}
+		target.Items = nil
+		total, err := u.orderService.CalculateOrderTotal(ctx, target)
+		if err != nil {
+			return fmt.Errorf("failed to calculate order total: %w", err)
+		}
+		target.SettlePayments(total, payments)
+		target.UpdatedBy = actorID
+		conflictOrderID = target.ID
+		mergedTarget, err := u.orderRepo.Update(ctx, target)
+		if err != nil {
+			return fmt.Errorf("failed to update target order: %w", err)
+		}
+
+		if err := recordAudit(ctx, u.auditLogRepo, vo.AuditActionOrderMerge, entity.AuditEntityOrder, source.ID, sourceBefore, u.toOrderResponse(mergedSource)); err != nil {
+			return err
+		}
+		return recordAudit(ctx, u.auditLogRepo, vo.AuditActionOrderMerge, entity.AuditEntityOrder, target.ID, targetBefore, u.toOrderResponse(mergedTarget))
+	})
+	if err != nil {
+		u.logger.Error("Error merging orders", "error", err, "orderID", id, "targetOrderID", req.TargetOrderID)
+		return nil, u.withCurrentState(ctx, conflictOrderID, err)
+	}
+
+	merged, err := u.orderRepo.GetByIDWithItems(ctx, target.ID)
+	if err != nil {
+		u.logger.Error("Error getting merged order", "error", err, "orderID", target.ID)
+		return nil, fmt.Errorf("failed to get merged order: %w", err)
+	}
+
+	u.logger.Info("Orders merged successfully", "orderID", id, "targetOrderID", target.ID)
+
+	return u.toOrderWithItemsResponse(merged), nil
+}
+
 // ListOrders retrieves all orders with pagination
 func (u *orderUsecase) ListOrders(ctx context.Context, limit, offset int) (*OrderListResponse, error) {
 	u.logger.Debug("Listing orders", "limit", limit, "offset", offset)
@@ -291,247 +761,1099 @@ func (u *orderUsecase) GetOrdersByStatus(ctx context.Context, status string, lim
 		return nil, err
 	}
 
-	orders, err := u.orderRepo.ListByStatus(ctx, status, limit, offset)
-	if err != nil {
-		u.logger.Error("Error getting orders by status", "error", err, "status", status)
-		return nil, fmt.Errorf("failed to get orders by status: %w", err)
+	orders, err := u.orderRepo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		u.logger.Error("Error getting orders by status", "error", err, "status", status)
+		return nil, fmt.Errorf("failed to get orders by status: %w", err)
+	}
+
+	return &OrderListResponse{
+		Orders: u.toOrderResponses(orders),
+		Total:  len(orders),
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// GetOrdersByType retrieves orders of one order type, newest first
+func (u *orderUsecase) GetOrdersByType(ctx context.Context, orderType string, limit, offset int) (*OrderListResponse, error) {
+	u.logger.Debug("Getting orders by type", "orderType", orderType, "limit", limit, "offset", offset)
+
+	t, err := vo.NewOrderType(orderType)
+	if err != nil {
+		u.logger.Error("Invalid order type", "error", err, "orderType", orderType)
+		return nil, err
+	}
+
+	orders, err := u.orderRepo.ListByOrderType(ctx, t.String(), limit, offset)
+	if err != nil {
+		u.logger.Error("Error getting orders by type", "error", err, "orderType", orderType)
+		return nil, fmt.Errorf("failed to get orders by type: %w", err)
+	}
+
+	return &OrderListResponse{
+		Orders: u.toOrderResponses(orders),
+		Total:  len(orders),
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// GetOrdersByDateRange retrieves orders within date range
+func (u *orderUsecase) GetOrdersByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) (*OrderListResponse, error) {
+	u.logger.Debug("Getting orders by date range", "startDate", startDate, "endDate", endDate, "limit", limit, "offset", offset)
+
+	// Validate date range
+	if startDate.After(endDate) {
+		u.logger.Error("Invalid date range", "startDate", startDate, "endDate", endDate)
+		return nil, errs.ErrInvalidDateRange
+	}
+
+	orders, err := u.orderRepo.ListByDateRange(ctx, startDate, endDate, limit, offset)
+	if err != nil {
+		u.logger.Error("Error getting orders by date range", "error", err, "startDate", startDate, "endDate", endDate)
+		return nil, fmt.Errorf("failed to get orders by date range: %w", err)
+	}
+
+	return &OrderListResponse{
+		Orders: u.toOrderResponses(orders),
+		Total:  len(orders),
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// AddOrderItem adds an item to an order
+func (u *orderUsecase) AddOrderItem(ctx context.Context, req *AddOrderItemRequest) (*OrderItemResponse, error) {
+	u.logger.Info("Adding order item", "orderID", req.OrderID, "itemID", req.ItemID, "quantity", req.Quantity)
+
+	// Validate order item
+	if err := u.orderService.ValidateOrderItem(ctx, req.OrderID, req.ItemID, req.Quantity); err != nil {
+		u.logger.Error("Order item validation failed", "error", err, "orderID", req.OrderID, "itemID", req.ItemID)
+		return nil, err
+	}
+
+	// Get menu item to get price
+	menuItem, err := u.menuItemRepo.GetByID(ctx, req.ItemID)
+	if err != nil {
+		u.logger.Error("Error getting menu item", "error", err, "itemID", req.ItemID)
+		return nil, fmt.Errorf("failed to get menu item: %w", err)
+	}
+	if menuItem == nil {
+		return nil, errs.ErrMenuItemNotFound
+	}
+
+	// Check if order item already exists
+	existingItem, err := u.orderItemRepo.GetByOrderAndItem(ctx, req.OrderID, req.ItemID)
+	if err != nil {
+		u.logger.Error("Error checking existing order item", "error", err, "orderID", req.OrderID, "itemID", req.ItemID)
+		return nil, fmt.Errorf("failed to check existing order item: %w", err)
+	}
+
+	if existingItem != nil {
+		// Update existing item quantity
+		if err := existingItem.UpdateQuantity(existingItem.Quantity + req.Quantity); err != nil {
+			u.logger.Error("Error updating order item quantity", "error", err, "orderItemID", existingItem.ID)
+			return nil, err
+		}
+		existingItem.UpdatedBy = actorIDFromContext(ctx)
+
+		updatedItem, err := u.orderItemRepo.Update(ctx, existingItem)
+		if err != nil {
+			u.logger.Error("Error updating order item", "error", err, "orderItemID", existingItem.ID)
+			return nil, fmt.Errorf("failed to update order item: %w", err)
+		}
+
+		return u.toOrderItemResponse(updatedItem), nil
+	}
+
+	// Create new order item
+	orderItem, err := entity.NewOrderItem(req.OrderID, req.ItemID, req.Quantity, menuItem.Price.AmountBaht(), menuItem.Name, menuItem.MarkdownPercent())
+	if err != nil {
+		u.logger.Error("Error creating order item entity", "error", err, "orderID", req.OrderID, "itemID", req.ItemID)
+		return nil, err
+	}
+	orderItem.CreatedBy = actorIDFromContext(ctx)
+
+	// Save to database
+	createdItem, err := u.orderItemRepo.Create(ctx, orderItem)
+	if err != nil {
+		u.logger.Error("Error creating order item", "error", err, "orderID", req.OrderID, "itemID", req.ItemID)
+		return nil, fmt.Errorf("failed to create order item: %w", err)
+	}
+
+	u.logger.Info("Order item added successfully", "orderItemID", createdItem.ID, "orderID", req.OrderID, "itemID", req.ItemID)
+
+	return u.toOrderItemResponse(createdItem), nil
+}
+
+// UpdateOrderItem changes the quantity of an item in an order's cart
+func (u *orderUsecase) UpdateOrderItem(ctx context.Context, id int, req *UpdateOrderItemRequest) (*OrderItemResponse, error) {
+	u.logger.Info("Updating order item", "orderItemID", id, "quantity", req.Quantity)
+
+	// Get current order item
+	currentItem, err := u.orderItemRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting current order item", "error", err, "orderItemID", id)
+		return nil, fmt.Errorf("failed to get order item: %w", err)
+	}
+	if currentItem == nil {
+		return nil, errs.ErrOrderItemNotFound
+	}
+	if err := currentItem.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, currentItem.OrderID, err)
+	}
+
+	// Check if order is still open
+	order, err := u.orderRepo.GetByID(ctx, currentItem.OrderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", currentItem.OrderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if order.IsClosed() {
+		return nil, errs.ErrCannotModifyClosedOrder
+	}
+
+	// Submitting a round locks its items. Taking food off one is a void,
+	// which records its reason, reaches the kitchen and shows in the reports;
+	// more of it is a new item in the next round.
+	if !currentItem.InCart() {
+		if req.Quantity < currentItem.Quantity {
+			return nil, errs.ErrReductionNeedsVoid
+		}
+		return nil, errs.ErrItemAlreadySubmitted
+	}
+
+	// Update quantity
+	if err := currentItem.UpdateQuantity(req.Quantity); err != nil {
+		u.logger.Error("Error updating order item quantity", "error", err, "orderItemID", id, "quantity", req.Quantity)
+		return nil, err
+	}
+	currentItem.UpdatedBy = actorIDFromContext(ctx)
+
+	// Update order item
+	updatedItem, err := u.orderItemRepo.Update(ctx, currentItem)
+	if err != nil {
+		u.logger.Error("Error updating order item", "error", err, "orderItemID", id)
+		return nil, u.withCurrentState(ctx, currentItem.OrderID, err)
+	}
+
+	u.logger.Info("Order item updated successfully", "orderItemID", id)
+
+	return u.toOrderItemResponse(updatedItem), nil
+}
+
+// RemoveOrderItem removes an order item. Items still in the cart are deleted;
+// submitted items are voided with a reason code, which keeps their record but
+// takes them off the bill. Voiding an item the kitchen already started needs a
+// manager's approval.
+func (u *orderUsecase) RemoveOrderItem(ctx context.Context, id int, req *VoidOrderItemRequest) error {
+	u.logger.Info("Removing order item", "orderItemID", id, "reasonCode", req.ReasonCode)
+
+	// Get current order item
+	currentItem, err := u.orderItemRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting current order item", "error", err, "orderItemID", id)
+		return fmt.Errorf("failed to get order item: %w", err)
+	}
+	if currentItem == nil {
+		return errs.ErrOrderItemNotFound
+	}
+	if err := currentItem.CheckVersion(req.Version); err != nil {
+		return u.withCurrentState(ctx, currentItem.OrderID, err)
+	}
+
+	// Check if order is still open
+	order, err := u.orderRepo.GetByID(ctx, currentItem.OrderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", currentItem.OrderID)
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return errs.ErrOrderNotFound
+	}
+	if order.IsClosed() {
+		return errs.ErrCannotModifyClosedOrder
+	}
+
+	// Cart items never reached the kitchen or the bill
+	if currentItem.InCart() {
+		err = u.doInTransaction(ctx, func(ctx context.Context) error {
+			return u.processDeleteOrderItem(ctx, currentItem.OrderID, id, req.Version)
+		})
+		if err != nil {
+			u.logger.Error("Error deleting order item", "error", err, "orderItemID", id)
+			return u.withCurrentState(ctx, currentItem.OrderID, err)
+		}
+		u.logger.Info("Order item removed successfully", "orderItemID", id)
+		return nil
+	}
+
+	if !hasReasonCode(u.config.Adjustment.VoidReasons, req.ReasonCode) {
+		return errs.ErrUnknownReasonCode
+	}
+
+	before := u.toOrderItemResponse(currentItem)
+	started := currentItem.HasStarted()
+	inKitchen := currentItem.IsInKitchen()
+	amount := u.orderService.CalculateItemAmount(ctx, currentItem)
+	if err := currentItem.Void(req.ReasonCode, amount, actorIDFromContext(ctx)); err != nil {
+		return err
+	}
+
+	var voidedItem *entity.OrderItem
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		approvalID := 0
+		if started {
+			approvalID, err = u.approvalUsecase.AuthorizeAction(ctx, withReasonCode(req.Approval, req.ReasonCode), entity.ApprovalTarget{
+				Action:      vo.ApprovalActionItemVoid,
+				OrderID:     currentItem.OrderID,
+				OrderItemID: &id,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		voidedItem, err = u.orderItemRepo.Update(ctx, currentItem)
+		if err != nil {
+			return fmt.Errorf("failed to update order item: %w", err)
+		}
+		after := u.toOrderItemResponse(voidedItem)
+		if started {
+			return recordApprovedAudit(ctx, u.auditLogRepo, approvalID, vo.AuditActionOrderItemVoid, entity.AuditEntityOrderItem, id, before, after)
+		}
+		return recordAudit(ctx, u.auditLogRepo, vo.AuditActionOrderItemVoid, entity.AuditEntityOrderItem, id, before, after)
+	})
+	if err != nil {
+		u.logger.Error("Error voiding order item", "error", err, "orderItemID", id)
+		return u.withCurrentState(ctx, currentItem.OrderID, err)
+	}
+
+	// Tell the kitchen to stop on an item it has a ticket for
+	if inKitchen {
+		u.printKitchenTickets(ctx, order, []*entity.OrderItem{voidedItem})
+	}
+
+	u.logger.Info("Order item voided successfully", "orderItemID", id, "reasonCode", req.ReasonCode)
+	return nil
+}
+
+// CompOrderItem keeps a submitted item on the bill at zero price, under a
+// manager's approval
+func (u *orderUsecase) CompOrderItem(ctx context.Context, id int, req *CompOrderItemRequest) (*OrderItemResponse, error) {
+	u.logger.Info("Comping order item", "orderItemID", id, "reasonCode", req.ReasonCode)
+
+	if !hasReasonCode(u.config.Adjustment.CompReasons, req.ReasonCode) {
+		return nil, errs.ErrUnknownReasonCode
+	}
+
+	currentItem, err := u.orderItemRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting current order item", "error", err, "orderItemID", id)
+		return nil, fmt.Errorf("failed to get order item: %w", err)
+	}
+	if currentItem == nil {
+		return nil, errs.ErrOrderItemNotFound
+	}
+	if err := currentItem.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, currentItem.OrderID, err)
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, currentItem.OrderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", currentItem.OrderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if order.IsClosed() {
+		return nil, errs.ErrCannotModifyClosedOrder
+	}
+
+	before := u.toOrderItemResponse(currentItem)
+	amount := u.orderService.CalculateItemAmount(ctx, currentItem)
+	if err := currentItem.Comp(req.ReasonCode, amount, actorIDFromContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	var updatedItem *entity.OrderItem
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		approvalID, err := u.approvalUsecase.AuthorizeAction(ctx, withReasonCode(req.Approval, req.ReasonCode), entity.ApprovalTarget{
+			Action:      vo.ApprovalActionItemComp,
+			OrderID:     currentItem.OrderID,
+			OrderItemID: &id,
+		})
+		if err != nil {
+			return err
+		}
+
+		updatedItem, err = u.orderItemRepo.Update(ctx, currentItem)
+		if err != nil {
+			return fmt.Errorf("failed to update order item: %w", err)
+		}
+		return recordApprovedAudit(ctx, u.auditLogRepo, approvalID, vo.AuditActionOrderItemComp, entity.AuditEntityOrderItem, id, before, u.toOrderItemResponse(updatedItem))
+	})
+	if err != nil {
+		u.logger.Error("Error comping order item", "error", err, "orderItemID", id)
+		return nil, u.withCurrentState(ctx, currentItem.OrderID, err)
+	}
+
+	u.logger.Info("Order item comped successfully", "orderItemID", id, "reasonCode", req.ReasonCode)
+	return u.toOrderItemResponse(updatedItem), nil
+}
+
+// ListAdjustmentReasons lists the reason codes items may be voided and comped with
+func (u *orderUsecase) ListAdjustmentReasons(ctx context.Context) *AdjustmentReasonsResponse {
+	return &AdjustmentReasonsResponse{
+		Void: append([]string{}, u.config.Adjustment.VoidReasons...),
+		Comp: append([]string{}, u.config.Adjustment.CompReasons...),
+	}
+}
+
+// hasReasonCode checks if the reason code is one of those configured
+func hasReasonCode(codes []string, code string) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// withReasonCode fills in the reason code of an approval given on the spot
+// from the void or comp it approves
+func withReasonCode(approval *ApprovalInput, reasonCode string) *ApprovalInput {
+	if approval == nil || approval.ReasonCode != "" {
+		return approval
+	}
+	filled := *approval
+	filled.ReasonCode = reasonCode
+	return &filled
+}
+
+// ApplyItemDiscount sets a manual discount on an order item under a manager's approval
+func (u *orderUsecase) ApplyItemDiscount(ctx context.Context, id int, req *ApplyItemDiscountRequest) (*OrderItemResponse, error) {
+	u.logger.Info("Applying item discount", "orderItemID", id, "discount", req.Discount)
+
+	currentItem, err := u.orderItemRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting current order item", "error", err, "orderItemID", id)
+		return nil, fmt.Errorf("failed to get order item: %w", err)
+	}
+	if currentItem == nil {
+		return nil, errs.ErrOrderItemNotFound
+	}
+	if err := currentItem.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, currentItem.OrderID, err)
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, currentItem.OrderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", currentItem.OrderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if order.IsClosed() {
+		return nil, errs.ErrCannotModifyClosedOrder
+	}
+
+	before := u.toOrderItemResponse(currentItem)
+	if err := currentItem.ApplyDiscount(req.Discount); err != nil {
+		return nil, err
+	}
+	currentItem.UpdatedBy = actorIDFromContext(ctx)
+
+	var updatedItem *entity.OrderItem
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		approvalID, err := u.approvalUsecase.AuthorizeAction(ctx, req.Approval, entity.ApprovalTarget{
+			Action:      vo.ApprovalActionDiscount,
+			OrderID:     currentItem.OrderID,
+			OrderItemID: &id,
+		})
+		if err != nil {
+			return err
+		}
+
+		updatedItem, err = u.orderItemRepo.Update(ctx, currentItem)
+		if err != nil {
+			return fmt.Errorf("failed to update order item: %w", err)
+		}
+		return recordApprovedAudit(ctx, u.auditLogRepo, approvalID, vo.AuditActionOrderItemDiscount, entity.AuditEntityOrderItem, id, before, u.toOrderItemResponse(updatedItem))
+	})
+	if err != nil {
+		u.logger.Error("Error applying item discount", "error", err, "orderItemID", id)
+		return nil, u.withCurrentState(ctx, currentItem.OrderID, err)
+	}
+
+	u.logger.Info("Item discount applied successfully", "orderItemID", id)
+
+	return u.toOrderItemResponse(updatedItem), nil
+}
+
+// ListOrderItems retrieves all items for an order
+func (u *orderUsecase) ListOrderItems(ctx context.Context, orderID int) ([]*OrderItemResponse, error) {
+	u.logger.Debug("Listing order items", "orderID", orderID)
+
+	// Check if order exists
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+
+	items, err := u.orderItemRepo.ListByOrder(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error listing order items", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to list order items: %w", err)
+	}
+
+	return u.toOrderItemResponses(items), nil
+}
+
+// SubmitRound sends the items in an order's cart to the kitchen as its next
+// round and moves the order to ordered. Items to be held for their course wait
+// to be fired; the rest are printed for their stations.
+func (u *orderUsecase) SubmitRound(ctx context.Context, orderID int, req *SubmitRoundRequest) (*OrderWithItemsResponse, error) {
+	u.logger.Info("Submitting round", "orderID", orderID)
+
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if order.IsClosed() {
+		return nil, errs.ErrCannotModifyClosedOrder
+	}
+	if err := order.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, orderID, err)
+	}
+
+	cart, err := u.orderItemRepo.ListCartByOrder(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error listing cart items", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to list cart items: %w", err)
+	}
+	if len(cart) == 0 {
+		return nil, errs.ErrEmptyCart
+	}
+
+	by := actorIDFromContext(ctx)
+	round, change, err := order.StartRound(by)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var fired []*entity.OrderItem
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		for _, item := range cart {
+			if err := item.Submit(round, now); err != nil {
+				return err
+			}
+			item.UpdatedBy = by
+			updatedItem, err := u.orderItemRepo.Update(ctx, item)
+			if err != nil {
+				return fmt.Errorf("failed to update order item: %w", err)
+			}
+			if !updatedItem.IsHeld() {
+				fired = append(fired, updatedItem)
+			}
+		}
+		// The order version guards the round number against a second submit
+		if _, err := u.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		if change != nil {
+			return u.recordStatusChange(ctx, change)
+		}
+		return nil
+	})
+	if err != nil {
+		u.logger.Error("Error submitting round", "error", err, "orderID", orderID)
+		return nil, u.withCurrentState(ctx, orderID, err)
+	}
+
+	u.printKitchenTickets(ctx, order, fired)
+
+	u.logger.Info("Round submitted successfully", "orderID", orderID, "round", round, "items", len(cart))
+	return u.GetOrderWithItems(ctx, orderID)
+}
+
+// RepeatOrderItems copies a round, or chosen items, of this or an earlier
+// order into the order's cart with their options, priced as the menu is today.
+// Items that can no longer be ordered are skipped and reported.
+func (u *orderUsecase) RepeatOrderItems(ctx context.Context, orderID int, req *RepeatOrderItemsRequest) (*RepeatOrderItemsResponse, error) {
+	u.logger.Info("Repeating order items", "orderID", orderID, "sourceOrderID", req.SourceOrderID, "round", req.Round)
+
+	if (req.Round == nil) == (len(req.OrderItemIDs) == 0) {
+		return nil, errs.ErrInvalidRepeatItems
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if order.IsClosed() {
+		return nil, errs.ErrCannotModifyClosedOrder
+	}
+	if err := order.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, orderID, err)
+	}
+
+	sourceOrderID := req.SourceOrderID
+	if sourceOrderID == 0 {
+		sourceOrderID = orderID
+	}
+	sources, err := u.repeatSourceItems(ctx, sourceOrderID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &RepeatOrderItemsResponse{
+		Items:   []*OrderItemResponse{},
+		Skipped: []*SkippedItemResponse{},
+	}
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		for _, source := range sources {
+			item, reason, err := u.repeatOrderItem(ctx, orderID, source)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				resp.Skipped = append(resp.Skipped, &SkippedItemResponse{
+					OrderItemID: source.ID,
+					MenuItemID:  source.ItemID,
+					Name:        source.Name,
+					Reason:      reason,
+				})
+				continue
+			}
+			resp.Items = append(resp.Items, u.toOrderItemResponse(item))
+		}
+		if len(resp.Items) == 0 {
+			return nil
+		}
+		// Bump the order version so other devices see the cart changed
+		if _, err := u.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		u.logger.Error("Error repeating order items", "error", err, "orderID", orderID)
+		return nil, u.withCurrentState(ctx, orderID, err)
+	}
+
+	u.logger.Info("Order items repeated successfully", "orderID", orderID, "added", len(resp.Items), "skipped", len(resp.Skipped))
+	return resp, nil
+}
+
+// repeatSourceItems picks the submitted items of the source order to repeat:
+// those of a round, leaving out cancelled ones, or those asked for by ID
+func (u *orderUsecase) repeatSourceItems(ctx context.Context, sourceOrderID int, req *RepeatOrderItemsRequest) ([]*entity.OrderItem, error) {
+	source, err := u.orderRepo.GetByID(ctx, sourceOrderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", sourceOrderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if source == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+
+	items, err := u.orderItemRepo.ListByOrder(ctx, sourceOrderID)
+	if err != nil {
+		u.logger.Error("Error listing order items", "error", err, "orderID", sourceOrderID)
+		return nil, fmt.Errorf("failed to list order items: %w", err)
+	}
+
+	if req.Round != nil {
+		var selected []*entity.OrderItem
+		for _, item := range items {
+			if item.Round == *req.Round && item.ItemStatus != vo.ItemStatusCancelled {
+				selected = append(selected, item)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, errs.NewNotFoundError("round", *req.Round)
+		}
+		return selected, nil
+	}
+
+	byID := make(map[int]*entity.OrderItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	selected := make([]*entity.OrderItem, 0, len(req.OrderItemIDs))
+	for _, id := range req.OrderItemIDs {
+		item, ok := byID[id]
+		if !ok {
+			return nil, errs.ErrOrderItemNotFound.WithField("order_item_id", id)
+		}
+		selected = append(selected, item)
+	}
+	return selected, nil
+}
+
+// repeatOrderItem adds a copy of an item and its options to the order's
+// cart. When the item can no longer be ordered, nothing is added and the
+// reason is returned instead.
+func (u *orderUsecase) repeatOrderItem(ctx context.Context, orderID int, source *entity.OrderItem) (*entity.OrderItem, string, error) {
+	if err := u.orderService.ValidateOrderItem(ctx, orderID, source.ItemID, source.Quantity); err != nil {
+		var domainErr errs.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, domainErr.Message(), nil
+		}
+		return nil, "", err
+	}
+
+	options, err := u.orderItemOptionUsecase.GetOrderItemOptions(ctx, source.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	item := &ManageOrderItemItemRequest{
+		MenuItemID: source.ItemID,
+		Quantity:   source.Quantity,
+		Course:     source.Course,
+		Options:    make([]*OrderItemOptionManageRequest, 0, len(options)),
+	}
+	// Seats only mean something within the order they were taken for
+	if source.OrderID == orderID {
+		item.Seat = source.Seat
+	}
+	for _, option := range options {
+		// Options removed from the menu since cannot be priced again
+		if option.Option == nil || option.Value == nil {
+			return nil, errs.ErrOptionUnavailable.Message(), nil
+		}
+		item.Options = append(item.Options, &OrderItemOptionManageRequest{
+			OptionID:    option.OptionID,
+			OptionValID: option.ValueID,
+			Action:      "add",
+		})
+	}
+
+	orderItem, err := u.processAddOrderItem(ctx, orderID, item)
+	if err != nil {
+		return nil, "", err
+	}
+	return orderItem, "", nil
+}
+
+// FireCourse sends the held items of a course, or of every course when none
+// is given, to the kitchen and prints a ticket for each station
+func (u *orderUsecase) FireCourse(ctx context.Context, orderID int, req *FireCourseRequest) ([]*OrderItemResponse, error) {
+	u.logger.Info("Firing course", "orderID", orderID, "course", req.Course)
+
+	order, items, err := u.getOpenOrderItems(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fired []*entity.OrderItem
+	for _, item := range items {
+		if inCourse(item, req.Course) && item.Fire() {
+			fired = append(fired, item)
+		}
+	}
+
+	fired, err = u.saveCourseItems(ctx, order, fired)
+	if err != nil {
+		u.logger.Error("Error firing course", "error", err, "orderID", orderID)
+		return nil, u.withCurrentState(ctx, orderID, err)
+	}
+
+	u.printKitchenTickets(ctx, order, fired)
+
+	u.logger.Info("Course fired successfully", "orderID", orderID, "course", req.Course, "firedItems", len(fired))
+	return u.toOrderItemResponses(fired), nil
+}
+
+// HoldCourse keeps the pending items of a course, or of every course when
+// none is given, away from the kitchen until they are fired
+func (u *orderUsecase) HoldCourse(ctx context.Context, orderID int, req *HoldCourseRequest) ([]*OrderItemResponse, error) {
+	u.logger.Info("Holding course", "orderID", orderID, "course", req.Course)
+
+	order, items, err := u.getOpenOrderItems(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var held []*entity.OrderItem
+	for _, item := range items {
+		// Items the kitchen already has are left alone
+		if !inCourse(item, req.Course) || item.ItemStatus != vo.ItemStatusPending {
+			continue
+		}
+		if err := item.Hold(); err != nil {
+			return nil, err
+		}
+		held = append(held, item)
+	}
+
+	held, err = u.saveCourseItems(ctx, order, held)
+	if err != nil {
+		u.logger.Error("Error holding course", "error", err, "orderID", orderID)
+		return nil, u.withCurrentState(ctx, orderID, err)
+	}
+
+	u.logger.Info("Course held successfully", "orderID", orderID, "course", req.Course, "heldItems", len(held))
+	return u.toOrderItemResponses(held), nil
+}
+
+// getOpenOrderItems gets an order that may still change, with its items
+func (u *orderUsecase) getOpenOrderItems(ctx context.Context, orderID int) (*entity.Order, []*entity.OrderItem, error) {
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
+		return nil, nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, nil, errs.ErrOrderNotFound
+	}
+	if order.IsClosed() {
+		return nil, nil, errs.ErrCannotModifyClosedOrder
+	}
+
+	items, err := u.orderItemRepo.ListByOrder(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error listing order items", "error", err, "orderID", orderID)
+		return nil, nil, fmt.Errorf("failed to list order items: %w", err)
+	}
+	return order, items, nil
+}
+
+// saveCourseItems stores items whose course was fired or held, and bumps the
+// order version so other devices see the change
+func (u *orderUsecase) saveCourseItems(ctx context.Context, order *entity.Order, items []*entity.OrderItem) ([]*entity.OrderItem, error) {
+	saved := make([]*entity.OrderItem, 0, len(items))
+	if len(items) == 0 {
+		return saved, nil
+	}
+
+	err := u.doInTransaction(ctx, func(ctx context.Context) error {
+		for _, item := range items {
+			item.UpdatedBy = actorIDFromContext(ctx)
+			updatedItem, err := u.orderItemRepo.Update(ctx, item)
+			if err != nil {
+				return fmt.Errorf("failed to update order item: %w", err)
+			}
+			saved = append(saved, updatedItem)
+		}
+		if _, err := u.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// printKitchenTickets prints one ticket per kitchen station for the items
+// just sent to the kitchen. The items are already fired, so a failed print
+// is only logged.
+func (u *orderUsecase) printKitchenTickets(ctx context.Context, order *entity.Order, items []*entity.OrderItem) {
+	var stations []string
+	byStation := make(map[string][]*entity.OrderItem)
+	for _, item := range items {
+		station := item.KitchenStation
+		if station == "" {
+			menuItem, err := u.menuItemRepo.GetByID(ctx, item.ItemID)
+			if err == nil && menuItem != nil && menuItem.KitchenStation != nil {
+				station = menuItem.KitchenStation.Name
+			}
+		}
+		if _, ok := byStation[station]; !ok {
+			stations = append(stations, station)
+		}
+		byStation[station] = append(byStation[station], item)
+	}
+
+	for _, station := range stations {
+		ticketPDF, err := u.orderService.KitchenTicketPdf(ctx, order, station, byStation[station])
+		if err != nil {
+			u.logger.Warn("Error generating kitchen ticket", "error", err, "orderID", order.ID, "station", station)
+			continue
+		}
+		if err := u.printerService.Print(ctx, ticketPDF, "PDF"); err != nil {
+			u.logger.Warn("Error printing kitchen ticket", "error", err, "orderID", order.ID, "station", station)
+		}
 	}
+}
 
-	return &OrderListResponse{
-		Orders: u.toOrderResponses(orders),
-		Total:  len(orders),
-		Limit:  limit,
-		Offset: offset,
-	}, nil
+// inCourse checks if an item is in the given course; a nil course takes
+// every item
+func inCourse(item *entity.OrderItem, course *int) bool {
+	return course == nil || item.Course == *course
 }
 
-// GetOrdersByDateRange retrieves orders within date range
-func (u *orderUsecase) GetOrdersByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) (*OrderListResponse, error) {
-	u.logger.Debug("Getting orders by date range", "startDate", startDate, "endDate", endDate, "limit", limit, "offset", offset)
+// CalculateOrderTotal calculates the total amount for an order
+func (u *orderUsecase) CalculateOrderTotal(ctx context.Context, orderID int) (*OrderTotalResponse, error) {
+	u.logger.Debug("Calculating order total", "orderID", orderID)
 
-	// Validate date range
-	if startDate.After(endDate) {
-		u.logger.Error("Invalid date range", "startDate", startDate, "endDate", endDate)
-		return nil, errs.ErrInvalidDateRange
+	// Get order
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
 	}
 
-	orders, err := u.orderRepo.ListByDateRange(ctx, startDate, endDate, limit, offset)
+	// Price the bill
+	breakdown, err := u.orderService.PriceOrder(ctx, order)
 	if err != nil {
-		u.logger.Error("Error getting orders by date range", "error", err, "startDate", startDate, "endDate", endDate)
-		return nil, fmt.Errorf("failed to get orders by date range: %w", err)
+		u.logger.Error("Error pricing order", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to price order: %w", err)
 	}
 
-	return &OrderListResponse{
-		Orders: u.toOrderResponses(orders),
-		Total:  len(orders),
-		Limit:  limit,
-		Offset: offset,
+	itemDiscount, _ := vo.NewMoneyFromSatang(0)
+	for _, item := range order.Items {
+		itemDiscount = itemDiscount.Add(item.DiscountAmount())
+	}
+
+	return &OrderTotalResponse{
+		OrderID:       orderID,
+		Items:         u.toOrderItemResponses(order.Items),
+		ItemDiscount:  itemDiscount.AmountBaht(),
+		Subtotal:      breakdown.Subtotal.AmountBaht(),
+		Discount:      breakdown.Discount.AmountBaht(),
+		ServiceCharge: breakdown.ServiceCharge.AmountBaht(),
+		Tax:           breakdown.TaxAmount.AmountBaht(),
+		VATInclusive:  breakdown.VATInclusive,
+		Total:         breakdown.Total.AmountBaht(),
+		ItemCount:     order.GetItemCount(),
+		CouponCode:    order.CouponCode,
+		Promotions:    u.toAppliedPromotionResponses(breakdown.Promotions),
 	}, nil
 }
 
-// AddOrderItem adds an item to an order
-func (u *orderUsecase) AddOrderItem(ctx context.Context, req *AddOrderItemRequest) (*OrderItemResponse, error) {
-	u.logger.Info("Adding order item", "orderID", req.OrderID, "itemID", req.ItemID, "quantity", req.Quantity)
+// ApplyCoupon gives an open order a promo code and reprices its bill
+func (u *orderUsecase) ApplyCoupon(ctx context.Context, orderID int, req *ApplyCouponRequest) (*OrderTotalResponse, error) {
+	code := entity.NormalizePromoCode(req.Code)
+	u.logger.Info("Applying coupon", "orderID", orderID, "code", code)
 
-	// Validate order item
-	if err := u.orderService.ValidateOrderItem(ctx, req.OrderID, req.ItemID, req.Quantity); err != nil {
-		u.logger.Error("Order item validation failed", "error", err, "orderID", req.OrderID, "itemID", req.ItemID)
-		return nil, err
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
 	}
 
-	// Get menu item to get price
-	menuItem, err := u.menuItemRepo.GetByID(ctx, req.ItemID)
+	promotion, err := u.promotionRepo.GetByCode(ctx, code)
 	if err != nil {
-		u.logger.Error("Error getting menu item", "error", err, "itemID", req.ItemID)
-		return nil, fmt.Errorf("failed to get menu item: %w", err)
+		u.logger.Error("Error getting promotion", "error", err, "code", code)
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
 	}
-	if menuItem == nil {
-		return nil, errs.ErrMenuItemNotFound
+	if promotion == nil || !promotion.IsActive {
+		return nil, errs.ErrInvalidPromoCodeWithValue(code)
+	}
+	if !promotion.IsRunning(order.CreatedAt) {
+		return nil, errs.ErrPromoCodeExpired
 	}
 
-	// Check if order item already exists
-	existingItem, err := u.orderItemRepo.GetByOrderAndItem(ctx, req.OrderID, req.ItemID)
+	before := u.toOrderResponse(order)
+	if err := order.ApplyCoupon(code, actorIDFromContext(ctx)); err != nil {
+		return nil, err
+	}
+	breakdown, err := u.orderService.PriceOrder(ctx, order)
 	if err != nil {
-		u.logger.Error("Error checking existing order item", "error", err, "orderID", req.OrderID, "itemID", req.ItemID)
-		return nil, fmt.Errorf("failed to check existing order item: %w", err)
+		u.logger.Error("Error pricing order", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to price order: %w", err)
+	}
+	if !hasPromotion(breakdown.Promotions, promotion.ID) {
+		u.logger.Warn("Coupon does not apply to order", "orderID", orderID, "code", code)
+		return nil, errs.ErrDiscountNotApplicable
 	}
 
-	if existingItem != nil {
-		// Update existing item quantity
-		if err := existingItem.UpdateQuantity(existingItem.Quantity + req.Quantity); err != nil {
-			u.logger.Error("Error updating order item quantity", "error", err, "orderItemID", existingItem.ID)
-			return nil, err
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		// Orders holding the code count as using it, so the check and the
+		// save happen under the promotion's lock. Usage on this order does
+		// not count towards its own limit.
+		if err := u.promotionRepo.LockUsage(ctx, promotion.ID); err != nil {
+			return fmt.Errorf("failed to lock promotion: %w", err)
 		}
-
-		updatedItem, err := u.orderItemRepo.Update(ctx, existingItem)
+		promotion.UsageCount, err = u.promotionRepo.CountUsage(ctx, promotion.ID, orderID)
 		if err != nil {
-			u.logger.Error("Error updating order item", "error", err, "orderItemID", existingItem.ID)
-			return nil, fmt.Errorf("failed to update order item: %w", err)
+			return fmt.Errorf("failed to count promotion usage: %w", err)
 		}
-
-		return u.toOrderItemResponse(updatedItem), nil
-	}
-
-	// Create new order item
-	orderItem, err := entity.NewOrderItem(req.OrderID, req.ItemID, req.Quantity, menuItem.Price.AmountBaht(), menuItem.Name)
+		if promotion.IsUsedUp() {
+			return errs.ErrPromoCodeUsedUp
+		}
+		return u.saveCoupon(ctx, order, before)
+	})
 	if err != nil {
-		u.logger.Error("Error creating order item entity", "error", err, "orderID", req.OrderID, "itemID", req.ItemID)
+		u.logger.Error("Error applying coupon", "error", err, "orderID", orderID)
 		return nil, err
 	}
 
-	// Save to database
-	createdItem, err := u.orderItemRepo.Create(ctx, orderItem)
-	if err != nil {
-		u.logger.Error("Error creating order item", "error", err, "orderID", req.OrderID, "itemID", req.ItemID)
-		return nil, fmt.Errorf("failed to create order item: %w", err)
-	}
-
-	u.logger.Info("Order item added successfully", "orderItemID", createdItem.ID, "orderID", req.OrderID, "itemID", req.ItemID)
+	u.logger.Info("Coupon applied successfully", "orderID", orderID, "code", code)
 
-	return u.toOrderItemResponse(createdItem), nil
+	return u.CalculateOrderTotal(ctx, orderID)
 }
 
-// UpdateOrderItem updates an order item
-func (u *orderUsecase) UpdateOrderItem(ctx context.Context, id int, req *UpdateOrderItemRequest) (*OrderItemResponse, error) {
-	u.logger.Info("Updating order item", "orderItemID", id, "quantity", req.Quantity)
-
-	// Get current order item
-	currentItem, err := u.orderItemRepo.GetByID(ctx, id)
-	if err != nil {
-		u.logger.Error("Error getting current order item", "error", err, "orderItemID", id)
-		return nil, fmt.Errorf("failed to get order item: %w", err)
-	}
-	if currentItem == nil {
-		return nil, errs.ErrOrderItemNotFound
-	}
+// RemoveCoupon takes the promo code off an open order
+func (u *orderUsecase) RemoveCoupon(ctx context.Context, orderID int) (*OrderTotalResponse, error) {
+	u.logger.Info("Removing coupon", "orderID", orderID)
 
-	// Check if order is still open
-	order, err := u.orderRepo.GetByID(ctx, currentItem.OrderID)
+	order, err := u.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
-		u.logger.Error("Error getting order", "error", err, "orderID", currentItem.OrderID)
+		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
 	if order == nil {
 		return nil, errs.ErrOrderNotFound
 	}
-	if order.IsClosed() {
-		return nil, errs.ErrCannotModifyClosedOrder
-	}
 
-	// Update quantity
-	if err := currentItem.UpdateQuantity(req.Quantity); err != nil {
-		u.logger.Error("Error updating order item quantity", "error", err, "orderItemID", id, "quantity", req.Quantity)
+	before := u.toOrderResponse(order)
+	if err := order.ApplyCoupon("", actorIDFromContext(ctx)); err != nil {
 		return nil, err
 	}
+	if _, err := u.orderService.PriceOrder(ctx, order); err != nil {
+		u.logger.Error("Error pricing order", "error", err, "orderID", orderID)
+		return nil, fmt.Errorf("failed to price order: %w", err)
+	}
 
-	// Update order item
-	updatedItem, err := u.orderItemRepo.Update(ctx, currentItem)
+	err = u.doInTransaction(ctx, func(ctx context.Context) error {
+		return u.saveCoupon(ctx, order, before)
+	})
 	if err != nil {
-		u.logger.Error("Error updating order item", "error", err, "orderItemID", id)
-		return nil, fmt.Errorf("failed to update order item: %w", err)
+		u.logger.Error("Error removing coupon", "error", err, "orderID", orderID)
+		return nil, err
 	}
 
-	u.logger.Info("Order item updated successfully", "orderItemID", id)
+	u.logger.Info("Coupon removed successfully", "orderID", orderID)
 
-	return u.toOrderItemResponse(updatedItem), nil
+	return u.CalculateOrderTotal(ctx, orderID)
 }
 
-// RemoveOrderItem removes an order item
-func (u *orderUsecase) RemoveOrderItem(ctx context.Context, id int) error {
-	u.logger.Info("Removing order item", "orderItemID", id)
-
-	// Get current order item
-	currentItem, err := u.orderItemRepo.GetByID(ctx, id)
+// saveCoupon stores an order's coupon change with its repriced bill; it runs
+// in the caller's transaction
+func (u *orderUsecase) saveCoupon(ctx context.Context, order *entity.Order, before *OrderResponse) error {
+	updatedOrder, err := u.orderRepo.Update(ctx, order)
 	if err != nil {
-		u.logger.Error("Error getting current order item", "error", err, "orderItemID", id)
-		return fmt.Errorf("failed to get order item: %w", err)
+		return fmt.Errorf("failed to update order: %w", err)
 	}
-	if currentItem == nil {
-		return errs.ErrOrderItemNotFound
+	return recordAudit(ctx, u.auditLogRepo, vo.AuditActionOrderCoupon, entity.AuditEntityOrder, order.ID, before, u.toOrderResponse(updatedOrder))
+}
+
+// hasPromotion checks if a promotion is among the applied ones
+func hasPromotion(applied []entity.AppliedPromotion, promotionID int) bool {
+	for _, promotion := range applied {
+		if promotion.PromotionID == promotionID {
+			return true
+		}
 	}
+	return false
+}
 
-	// Check if order is still open
-	order, err := u.orderRepo.GetByID(ctx, currentItem.OrderID)
+// lockLimitedPromotions locks the active promotions with a usage limit until
+// the transaction ends, so their usage is counted and taken by one order at
+// a time. Promotions are listed in the same order every time, so orders
+// closing together wait for each other rather than deadlock.
+func (u *orderUsecase) lockLimitedPromotions(ctx context.Context) error {
+	promotions, err := u.promotionRepo.List(ctx, true, 0, 0)
 	if err != nil {
-		u.logger.Error("Error getting order", "error", err, "orderID", currentItem.OrderID)
-		return fmt.Errorf("failed to get order: %w", err)
-	}
-	if order == nil {
-		return errs.ErrOrderNotFound
+		return fmt.Errorf("failed to list promotions: %w", err)
 	}
-	if order.IsClosed() {
-		return errs.ErrCannotModifyClosedOrder
+	for _, promotion := range promotions {
+		if promotion.UsageLimit == 0 {
+			continue
+		}
+		if err := u.promotionRepo.LockUsage(ctx, promotion.ID); err != nil {
+			return fmt.Errorf("failed to lock promotion: %w", err)
+		}
 	}
+	return nil
+}
 
-	// Delete order item
-	if err := u.orderItemRepo.Delete(ctx, id); err != nil {
-		u.logger.Error("Error deleting order item", "error", err, "orderItemID", id)
-		return fmt.Errorf("failed to delete order item: %w", err)
+// discardCart deletes the items left in the cart of an order that is being
+// closed or cancelled; they were never sent to the kitchen or billed
+func (u *orderUsecase) discardCart(ctx context.Context, orderID int) error {
+	cart, err := u.orderItemRepo.ListCartByOrder(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to list cart items: %w", err)
+	}
+	for _, item := range cart {
+		if err := u.processDeleteOrderItem(ctx, orderID, item.ID, nil); err != nil {
+			return fmt.Errorf("failed to discard cart item: %w", err)
+		}
 	}
-
-	u.logger.Info("Order item removed successfully", "orderItemID", id)
 	return nil
 }
 
-// ListOrderItems retrieves all items for an order
-func (u *orderUsecase) ListOrderItems(ctx context.Context, orderID int) ([]*OrderItemResponse, error) {
-	u.logger.Debug("Listing order items", "orderID", orderID)
-
-	// Check if order exists
-	order, err := u.orderRepo.GetByID(ctx, orderID)
+// claimTable locks the table until the transaction ends and checks nobody is
+// being served at it, so two requests cannot seat orders at the same table
+func (u *orderUsecase) claimTable(ctx context.Context, tableID int) error {
+	table, err := u.tableRepo.GetByIDForUpdate(ctx, tableID)
 	if err != nil {
-		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
+		return fmt.Errorf("failed to lock table: %w", err)
 	}
-	if order == nil {
-		return nil, errs.ErrOrderNotFound
+	if table == nil {
+		return errs.ErrTableNotFoundWithID(tableID)
 	}
 
-	items, err := u.orderItemRepo.ListByOrder(ctx, orderID)
+	openOrder, err := u.orderRepo.GetOpenOrderByTable(ctx, tableID)
 	if err != nil {
-		u.logger.Error("Error listing order items", "error", err, "orderID", orderID)
-		return nil, fmt.Errorf("failed to list order items: %w", err)
+		return fmt.Errorf("failed to check open order: %w", err)
+	}
+	if openOrder != nil {
+		return errs.ErrTableAlreadyHasOpenOrderWithContext(tableID, openOrder.ID)
 	}
+	return nil
+}
 
-	return u.toOrderItemResponses(items), nil
+// recordStatusChange adds a status change to the order's history
+func (u *orderUsecase) recordStatusChange(ctx context.Context, change *entity.OrderStatusChange) error {
+	if _, err := u.statusHistoryRepo.Create(ctx, change); err != nil {
+		return fmt.Errorf("failed to record order status change: %w", err)
+	}
+	return nil
 }
 
-// CalculateOrderTotal calculates the total amount for an order
-func (u *orderUsecase) CalculateOrderTotal(ctx context.Context, orderID int) (*OrderTotalResponse, error) {
-	u.logger.Debug("Calculating order total", "orderID", orderID)
+// doInTransaction runs fn in a transaction, rolling back when it fails
+func (u *orderUsecase) doInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return runInTransaction(ctx, u.tx, fn)
+}
 
-	// Get order
-	order, err := u.orderRepo.GetByID(ctx, orderID)
-	if err != nil {
-		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-	if order == nil {
-		return nil, errs.ErrOrderNotFound
+// withCurrentState adds the order as it is now to a version conflict, so the
+// client can merge its changes; other errors are returned as they are
+func (u *orderUsecase) withCurrentState(ctx context.Context, orderID int, err error) error {
+	itemConflict := errors.Is(err, errs.ErrOrderItemVersionConflict)
+	if !itemConflict && !errors.Is(err, errs.ErrOrderVersionConflict) {
+		return err
 	}
 
-	// Get order items
-	items, err := u.orderItemRepo.ListByOrder(ctx, orderID)
-	if err != nil {
-		u.logger.Error("Error getting order items", "error", err, "orderID", orderID)
-		return nil, fmt.Errorf("failed to get order items: %w", err)
+	current, getErr := u.orderRepo.GetByIDWithItems(ctx, orderID)
+	if getErr != nil || current == nil {
+		u.logger.Error("Error getting order after version conflict", "error", getErr, "orderID", orderID)
+		return err
 	}
-
-	// Calculate total
-	total := 0.0
-	itemCount := 0
-	for _, item := range items {
-		subtotal := item.CalculateSubtotal()
-		total += subtotal.AmountBaht()
-		itemCount += item.Quantity
+	if itemConflict {
+		return errs.ErrOrderItemVersionConflictWithCurrent(current.Version, u.toOrderWithItemsResponse(current))
 	}
-
-	return &OrderTotalResponse{
-		OrderID:   orderID,
-		Items:     u.toOrderItemResponses(items),
-		Total:     total,
-		ItemCount: itemCount,
-	}, nil
+	return errs.ErrOrderVersionConflictWithCurrent(current.Version, u.toOrderWithItemsResponse(current))
 }
 
 // Helper methods for conversion
@@ -539,11 +1861,21 @@ func (u *orderUsecase) CalculateOrderTotal(ctx context.Context, orderID int) (*O
 // toOrderResponse converts entity to response
 func (u *orderUsecase) toOrderResponse(order *entity.Order) *OrderResponse {
 	response := &OrderResponse{
-		ID:        order.ID,
-		TableID:   order.TableID,
-		Status:    order.OrderStatus.String(),
-		QRcode:    order.QRCode,
-		CreatedAt: order.CreatedAt,
+		ID:              order.ID,
+		TableID:         order.TableID,
+		OrderType:       order.OrderType.String(),
+		CustomerName:    order.CustomerName,
+		CustomerPhone:   order.CustomerPhone,
+		DeliveryAddress: order.DeliveryAddress,
+		QueueNumber:     order.QueueNumber,
+		GuestCount:      order.GuestCount,
+		Status:          order.OrderStatus.String(),
+		QRcode:          order.QRCode,
+		CreatedAt:       order.CreatedAt,
+		CreatedBy:       order.CreatedBy,
+		ClosedBy:        order.ClosedBy,
+		MergedIntoID:    order.MergedIntoID,
+		Version:         order.Version,
 	}
 
 	if order.ClosedAt != nil {
@@ -556,12 +1888,18 @@ func (u *orderUsecase) toOrderResponse(order *entity.Order) *OrderResponse {
 // toOrderWithItemsResponse converts entity to response with items
 func (u *orderUsecase) toOrderWithItemsResponse(order *entity.Order) *OrderWithItemsResponse {
 	response := &OrderWithItemsResponse{
-		ID:        order.ID,
-		TableID:   order.TableID,
-		Status:    order.OrderStatus.String(),
-		Items:     u.toOrderItemResponses(order.Items),
-		Total:     order.CalculateTotal().AmountBaht(),
-		CreatedAt: order.CreatedAt,
+		ID:           order.ID,
+		TableID:      order.TableID,
+		OrderType:    order.OrderType.String(),
+		CustomerName: order.CustomerName,
+		QueueNumber:  order.QueueNumber,
+		GuestCount:   order.GuestCount,
+		Status:       order.OrderStatus.String(),
+		Items:        u.toOrderItemResponses(order.Items),
+		Rounds:       u.toOrderRoundResponses(entity.GroupRounds(order.Items)),
+		Total:        order.CalculateTotal().AmountBaht(),
+		CreatedAt:    order.CreatedAt,
+		Version:      order.Version,
 	}
 
 	if order.ClosedAt != nil {
@@ -590,16 +1928,45 @@ func (u *orderUsecase) toOrderWithItemsResponses(orders []*entity.Order) []*Orde
 // toOrderItemResponse converts entity to response
 func (u *orderUsecase) toOrderItemResponse(item *entity.OrderItem) *OrderItemResponse {
 	return &OrderItemResponse{
-		ID:             item.ID,
-		OrderID:        item.OrderID,
-		ItemID:         item.ItemID,
-		Quantity:       item.Quantity,
-		UnitPrice:      item.UnitPrice.AmountBaht(),
-		Subtotal:       item.CalculateSubtotal().AmountBaht(),
-		CreatedAt:      item.CreatedAt,
-		Name:           item.Name,
-		KitchenStation: item.KitchenStation,
+		ID:               item.ID,
+		OrderID:          item.OrderID,
+		ItemID:           item.ItemID,
+		Quantity:         item.Quantity,
+		UnitPrice:        item.UnitPrice.AmountBaht(),
+		DiscountPercent:  item.DiscountPercent,
+		Discount:         item.DiscountAmount().AmountBaht(),
+		Subtotal:         item.CalculateSubtotal().AmountBaht(),
+		CreatedAt:        item.CreatedAt,
+		Name:             item.Name,
+		Status:           item.ItemStatus.String(),
+		Course:           item.Course,
+		Seat:             item.Seat,
+		Round:            item.Round,
+		HoldOnSubmit:     item.HoldOnSubmit,
+		KitchenStation:   item.KitchenStation,
+		Adjustment:       item.Adjustment.String(),
+		AdjustmentReason: item.AdjustmentReason,
+		AdjustedAmount:   item.AdjustedAmount.AmountBaht(),
+		Version:          item.Version,
+	}
+}
+
+// toOrderRoundResponses converts rounds to responses
+func (u *orderUsecase) toOrderRoundResponses(rounds []*entity.OrderRound) []*OrderRoundResponse {
+	responses := make([]*OrderRoundResponse, len(rounds))
+	for i, round := range rounds {
+		itemIDs := make([]int, len(round.Items))
+		for j, item := range round.Items {
+			itemIDs[j] = item.ID
+		}
+		responses[i] = &OrderRoundResponse{
+			Number:      round.Number,
+			Status:      round.Status().String(),
+			SubmittedAt: round.SubmittedAt,
+			ItemIDs:     itemIDs,
+		}
 	}
+	return responses
 }
 
 // toOrderItemResponses converts slice of entities to responses
@@ -870,7 +2237,7 @@ func (u *orderUsecase) PrintOrderQRCode(ctx context.Context, orderID int) error
 // }
 
 // Helper function สำหรับลบ order item
-func (u *orderUsecase) processDeleteOrderItem(ctx context.Context, orderItemID int) error {
+func (u *orderUsecase) processDeleteOrderItem(ctx context.Context, orderID int, orderItemID int, version *int) error {
 	// ตรวจสอบว่า order item มีอยู่จริง
 	orderItem, err := u.orderItemRepo.GetByID(ctx, orderItemID)
 	if err != nil {
@@ -880,6 +2247,18 @@ func (u *orderUsecase) processDeleteOrderItem(ctx context.Context, orderItemID i
 		return errs.ErrOrderItemNotFound
 	}
 
+	// ตรวจสอบว่า order item นี้เป็นของ order ที่ถูกต้อง
+	if orderItem.OrderID != orderID {
+		return errs.ErrOrderAccessDenied
+	}
+	if err := orderItem.CheckVersion(version); err != nil {
+		return err
+	}
+	// Submitted items are changed by staff, with approval once started
+	if !orderItem.InCart() {
+		return errs.ErrItemAlreadySubmitted
+	}
+
 	// ลบ order item options ก่อน (ถ้ามี)
 	err = u.orderItemOptionUsecase.RemoveAllOptionsFromOrderItem(ctx, orderItemID)
 	if err != nil {
@@ -888,7 +2267,10 @@ func (u *orderUsecase) processDeleteOrderItem(ctx context.Context, orderItemID i
 	}
 
 	// ลบ order item
-	return u.orderItemRepo.Delete(ctx, orderItemID)
+	if err := u.orderItemRepo.Delete(ctx, orderItemID); err != nil {
+		return err
+	}
+	return recordAudit(ctx, u.auditLogRepo, vo.AuditActionOrderItemDelete, entity.AuditEntityOrderItem, orderItemID, u.toOrderItemResponse(orderItem), nil)
 }
 
 // อัปเดตใน internal/application/order_usecase.go
@@ -906,7 +2288,12 @@ func (u *orderUsecase) processUpdateOrderItem(ctx context.Context, orderID int,
 
 	// ตรวจสอบว่า order item นี้เป็นของ order ที่ถูกต้อง
 	if currentItem.OrderID != orderID {
-		return nil, fmt.Errorf("order item %d does not belong to order %d", item.OrderItemID, orderID)
+		return nil, errs.ErrOrderAccessDenied
+	}
+
+	// Voiding food the kitchen already started needs a manager's approval
+	if currentItem.HasStarted() && (item.Quantity < currentItem.Quantity || currentItem.ItemID != item.MenuItemID) {
+		return nil, errs.ErrApprovalRequired
 	}
 
 	// หาก menu item เปลี่ยน ต้อง validate menu item ใหม่
@@ -937,6 +2324,7 @@ func (u *orderUsecase) processUpdateOrderItem(ctx context.Context, orderID int,
 	if err := currentItem.UpdateQuantity(item.Quantity); err != nil {
 		return nil, fmt.Errorf("failed to update quantity: %w", err)
 	}
+	currentItem.UpdatedBy = actorIDFromContext(ctx)
 
 	// บันทึกการเปลี่ยนแปลงของ order item
 	updatedItem, err := u.orderItemRepo.Update(ctx, currentItem)
@@ -1063,6 +2451,9 @@ func (u *orderUsecase) ManageOrderItemList(ctx context.Context, req *ManageOrder
 	if order.IsClosed() {
 		return nil, errs.ErrCannotModifyClosedOrder
 	}
+	if err := order.CheckVersion(req.Version); err != nil {
+		return nil, u.withCurrentState(ctx, req.OrderID, err)
+	}
 
 	// เริ่ม transaction
 	txCtx, err := u.tx.BeginTx(ctx)
@@ -1105,7 +2496,7 @@ func (u *orderUsecase) ManageOrderItemList(ctx context.Context, req *ManageOrder
 			if err != nil {
 				u.logger.Error("Error adding order item", "error", err, "index", i)
 				u.tx.RollbackTx(txCtx)
-				return nil, fmt.Errorf("failed to add order item %d: %w", i, err)
+				return nil, u.withCurrentState(ctx, req.OrderID, fmt.Errorf("failed to add order item %d: %w", i, err))
 			}
 			responses = append(responses, u.toOrderItemResponse(orderItem))
 
@@ -1118,7 +2509,7 @@ func (u *orderUsecase) ManageOrderItemList(ctx context.Context, req *ManageOrder
 			if err != nil {
 				u.logger.Error("Error updating order item", "error", err, "index", i)
 				u.tx.RollbackTx(txCtx)
-				return nil, fmt.Errorf("failed to update order item %d: %w", i, err)
+				return nil, u.withCurrentState(ctx, req.OrderID, fmt.Errorf("failed to update order item %d: %w", i, err))
 			}
 			responses = append(responses, u.toOrderItemResponse(orderItem))
 
@@ -1127,11 +2518,11 @@ func (u *orderUsecase) ManageOrderItemList(ctx context.Context, req *ManageOrder
 				u.tx.RollbackTx(txCtx)
 				return nil, fmt.Errorf("order_item_id is required for delete action at index %d", i)
 			}
-			err := u.processDeleteOrderItem(txCtx, *item.OrderItemID)
+			err := u.processDeleteOrderItem(txCtx, req.OrderID, *item.OrderItemID, item.Version)
 			if err != nil {
 				u.logger.Error("Error deleting order item", "error", err, "index", i)
 				u.tx.RollbackTx(txCtx)
-				return nil, fmt.Errorf("failed to delete order item %d: %w", i, err)
+				return nil, u.withCurrentState(ctx, req.OrderID, fmt.Errorf("failed to delete order item %d: %w", i, err))
 			}
 			// ไม่เพิ่มใน response เพราะถูกลบแล้ว
 
@@ -1141,6 +2532,13 @@ func (u *orderUsecase) ManageOrderItemList(ctx context.Context, req *ManageOrder
 		}
 	}
 
+	// Bump the order version so other devices see the items changed
+	if _, err := u.orderRepo.Update(txCtx, order); err != nil {
+		u.logger.Error("Error updating order version", "error", err, "orderID", req.OrderID)
+		u.tx.RollbackTx(txCtx)
+		return nil, u.withCurrentState(ctx, req.OrderID, fmt.Errorf("failed to update order: %w", err))
+	}
+
 	// Commit transaction
 	if err := u.tx.CommitTx(txCtx); err != nil {
 		u.logger.Error("Error committing transaction", "error", err)
@@ -1154,6 +2552,22 @@ func (u *orderUsecase) ManageOrderItemList(ctx context.Context, req *ManageOrder
 // Helper functions
 
 func (u *orderUsecase) processAddOrderItem(ctx context.Context, orderID int, item *ManageOrderItemItemRequest) (*entity.OrderItem, error) {
+	if item.Course < 0 {
+		return nil, errs.ErrInvalidCourse
+	}
+	if item.Seat != 0 {
+		order, err := u.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order: %w", err)
+		}
+		if order == nil {
+			return nil, errs.ErrOrderNotFound
+		}
+		if err := order.CheckSeat(item.Seat); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate order item
 	if err := u.orderService.ValidateOrderItem(ctx, orderID, item.MenuItemID, item.Quantity); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -1189,10 +2603,22 @@ func (u *orderUsecase) processAddOrderItem(ctx context.Context, orderID int, ite
 	// }
 
 	// สร้าง order item ใหม่
-	newOrderItem, err := entity.NewOrderItem(orderID, item.MenuItemID, item.Quantity, menuItem.Price.AmountBaht(), menuItem.Name)
+	newOrderItem, err := entity.NewOrderItem(orderID, item.MenuItemID, item.Quantity, menuItem.Price.AmountBaht(), menuItem.Name, menuItem.MarkdownPercent())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order item entity: %w", err)
 	}
+	newOrderItem.CreatedBy = actorIDFromContext(ctx)
+	newOrderItem.ItemStatus = vo.ItemStatusDraft
+	newOrderItem.Course = item.Course
+	newOrderItem.Seat = item.Seat
+	if menuItem.KitchenStation != nil {
+		newOrderItem.KitchenStation = menuItem.KitchenStation.Name
+	}
+	if item.Hold {
+		if err := newOrderItem.Hold(); err != nil {
+			return nil, err
+		}
+	}
 
 	orderItem, err = u.orderItemRepo.Create(ctx, newOrderItem)
 	if err != nil {
@@ -1222,7 +2648,14 @@ func (u *orderUsecase) processUpdateOrderItem2(ctx context.Context, orderID int,
 
 	// ตรวจสอบว่า order item นี้เป็นของ order ที่ถูกต้อง
 	if currentItem.OrderID != orderID {
-		return nil, fmt.Errorf("order item %d does not belong to order %d", orderItemID, orderID)
+		return nil, errs.ErrOrderAccessDenied
+	}
+	if err := currentItem.CheckVersion(item.Version); err != nil {
+		return nil, err
+	}
+	// Submitted items are changed by staff, with approval once started
+	if !currentItem.InCart() {
+		return nil, errs.ErrItemAlreadySubmitted
 	}
 
 	// หาก menu item เปลี่ยน ต้อง validate menu item ใหม่
@@ -1253,6 +2686,7 @@ func (u *orderUsecase) processUpdateOrderItem2(ctx context.Context, orderID int,
 	if err := currentItem.UpdateQuantity(item.Quantity); err != nil {
 		return nil, fmt.Errorf("failed to update quantity: %w", err)
 	}
+	currentItem.UpdatedBy = actorIDFromContext(ctx)
 
 	// บันทึกการเปลี่ยนแปลงของ order item
 	updatedItem, err := u.orderItemRepo.Update(ctx, currentItem)
@@ -1335,6 +2769,7 @@ func (u *orderUsecase) AddOrderItemList(ctx context.Context, req *AddOrderItemLi
 	// แปลง request เก่าเป็นแบบใหม่
 	newReq := &ManageOrderItemListRequest{
 		OrderID: req.OrderID,
+		Version: req.Version,
 		Items:   make([]*ManageOrderItemItemRequest, len(req.Items)),
 	}
 
@@ -1353,6 +2788,9 @@ func (u *orderUsecase) AddOrderItemList(ctx context.Context, req *AddOrderItemLi
 			Quantity:   item.Quantity,
 			Options:    options,
 			Action:     "add",
+			Course:     item.Course,
+			Seat:       item.Seat,
+			Hold:       item.Hold,
 		}
 	}
 
@@ -1364,6 +2802,7 @@ func (u *orderUsecase) UpdateOrderItemList(ctx context.Context, req *UpdateOrder
 	// แปลง request เก่าเป็นแบบใหม่
 	newReq := &ManageOrderItemListRequest{
 		OrderID: req.OrderID,
+		Version: req.Version,
 		Items:   make([]*ManageOrderItemItemRequest, len(req.Items)),
 	}
 
@@ -1383,6 +2822,7 @@ func (u *orderUsecase) UpdateOrderItemList(ctx context.Context, req *UpdateOrder
 			Quantity:    item.Quantity,
 			Options:     options,
 			Action:      item.Action,
+			Version:     item.Version,
 		}
 	}
 
@@ -1452,6 +2892,8 @@ func (u *orderUsecase) SearchOrders(ctx context.Context, req *OrderSearchRequest
 		orders, err = u.orderRepo.ListByTable(ctx, *req.TableID, req.Limit, req.Offset)
 	} else if req.Status != "" {
 		orders, err = u.orderRepo.ListByStatus(ctx, req.Status, req.Limit, req.Offset)
+	} else if req.OrderType != "" {
+		orders, err = u.orderRepo.ListByOrderType(ctx, req.OrderType, req.Limit, req.Offset)
 	} else if req.StartDate != nil && req.EndDate != nil {
 		orders, err = u.orderRepo.ListByDateRange(ctx, *req.StartDate, *req.EndDate, req.Limit, req.Offset)
 	} else {
@@ -1509,7 +2951,40 @@ func (u *orderUsecase) GetOrderDetailWithOptions(ctx context.Context, id int) (*
 		// }
 	}
 
-	return u.toOrderDetailResponse(order, table, payment), nil
+	// Price the bill as it is when taking payments
+	total, err := u.orderService.CalculateOrderTotal(ctx, order)
+	if err != nil {
+		u.logger.Error("Error calculating order total", "error", err, "orderID", id)
+		return nil, fmt.Errorf("failed to calculate order total: %w", err)
+	}
+
+	cart, err := u.orderItemRepo.ListCartByOrder(ctx, id)
+	if err != nil {
+		u.logger.Error("Error listing cart items", "error", err, "orderID", id)
+		return nil, fmt.Errorf("failed to list cart items: %w", err)
+	}
+
+	response := u.toOrderDetailResponse(order, table, payment)
+	response.Cart = u.toOrderItemDetailResponses(cart)
+	response.PaidAmount = order.PaidAmount.AmountBaht()
+	response.RemainingAmount = order.OutstandingBalance(total).AmountBaht()
+
+	history, err := u.statusHistoryRepo.ListByOrder(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting order status history", "error", err, "orderID", id)
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
+	}
+	response.StatusHistory = make([]*OrderStatusChangeResponse, len(history))
+	for i, change := range history {
+		response.StatusHistory[i] = &OrderStatusChangeResponse{
+			FromStatus: change.FromStatus.String(),
+			ToStatus:   change.ToStatus.String(),
+			ChangedBy:  change.ChangedBy,
+			ChangedAt:  change.ChangedAt,
+		}
+	}
+
+	return response, nil
 }
 
 // GetOrdersByStatusWithDetails gets orders by status with full details
@@ -1561,7 +3036,9 @@ func (u *orderUsecase) filterOrdersBySearch(orders []*entity.Order, search strin
 		// Search by order ID or order number
 		if strings.Contains(strings.ToLower(fmt.Sprintf("%d", order.ID)), searchLower) ||
 			strings.Contains(strings.ToLower(fmt.Sprintf("%d", order.OrderNumber)), searchLower) ||
-			strings.Contains(strings.ToLower(order.Notes), searchLower) {
+			strings.Contains(strings.ToLower(order.Notes), searchLower) ||
+			strings.Contains(strings.ToLower(order.CustomerName), searchLower) ||
+			strings.Contains(order.CustomerPhone, searchLower) {
 			filtered = append(filtered, order)
 			continue
 		}
@@ -1588,25 +3065,55 @@ func (u *orderUsecase) GetOrderIDFromQRCode(ctx context.Context, qrCode string)
 	return orderID, nil
 }
 
+// AuthenticateOrderToken resolves the open order a customer QR code grants
+// access to. Tokens of closed or merged orders are no longer accepted.
+func (u *orderUsecase) AuthenticateOrderToken(ctx context.Context, qrCode string) (int, error) {
+	if qrCode == "" {
+		return 0, errs.ErrInvalidOrderToken
+	}
+
+	order, err := u.orderRepo.GetOrderByQRCode(ctx, qrCode)
+	if err != nil {
+		u.logger.Error("Error getting order by QR code", "error", err)
+		return 0, fmt.Errorf("failed to get order by QR code: %w", err)
+	}
+	if order == nil || !order.InService() {
+		return 0, errs.ErrInvalidOrderToken
+	}
+
+	return order.ID, nil
+}
+
 // toOrderDetailResponse converts entity to detailed response
 func (u *orderUsecase) toOrderDetailResponse(order *entity.Order, table *entity.Table, payment *entity.Payment) *OrderDetailResponse {
 	response := &OrderDetailResponse{
 		ID:                  order.ID,
 		OrderNumber:         order.OrderNumber,
 		TableID:             order.TableID,
+		OrderType:           order.OrderType.String(),
+		CustomerName:        order.CustomerName,
+		CustomerPhone:       order.CustomerPhone,
+		DeliveryAddress:     order.DeliveryAddress,
+		QueueNumber:         order.QueueNumber,
+		GuestCount:          order.GuestCount,
 		Status:              order.OrderStatus.String(),
 		Notes:               order.Notes,
 		QRcode:              order.QRCode,
 		SpecialInstructions: order.SpecialInstructions,
 		Items:               u.toOrderItemDetailResponses(order.Items),
+		Rounds:              u.toOrderRoundResponses(entity.GroupRounds(order.Items)),
 		ItemCount:           order.GetItemCount(),
-		Subtotal:            order.CalculateTotal().AmountBaht(),
-		Discount:            order.CalculateDiscount().AmountBaht(),
-		Tax:                 order.CalculateTax().AmountBaht(),
-		Total:               order.CalculateTotal().AmountBaht(), // Adjust based on your business logic
+		Subtotal:            order.Subtotal.AmountBaht(),
+		Discount:            order.Discount.AmountBaht(),
+		Tax:                 order.TaxAmount.AmountBaht(),
+		ServiceCharge:       order.ServiceCharge.AmountBaht(),
+		CouponCode:          order.CouponCode,
+		Promotions:          u.toAppliedPromotionResponses(order.Promotions),
+		Total:               order.Total.AmountBaht(),
 		CreatedAt:           order.CreatedAt,
 		UpdatedAt:           order.UpdatedAt,
 		ClosedAt:            order.ClosedAt,
+		Version:             order.Version,
 	}
 
 	if order.PaymentStatus != "" {
@@ -1635,23 +3142,47 @@ func (u *orderUsecase) toOrderDetailResponse(order *entity.Order, table *entity.
 	return response
 }
 
+// toAppliedPromotionResponses converts promotion lines to responses
+func (u *orderUsecase) toAppliedPromotionResponses(promotions []entity.AppliedPromotion) []*AppliedPromotionResponse {
+	responses := make([]*AppliedPromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		responses[i] = &AppliedPromotionResponse{
+			PromotionID: promotion.PromotionID,
+			Name:        promotion.Name,
+			Code:        promotion.Code,
+			Amount:      promotion.Amount.AmountBaht(),
+		}
+	}
+	return responses
+}
+
 // toOrderItemDetailResponses converts order items to detailed responses with options
 func (u *orderUsecase) toOrderItemDetailResponses(items []*entity.OrderItem) []*OrderItemDetailResponse {
 	responses := make([]*OrderItemDetailResponse, len(items))
 
 	for i, item := range items {
 		response := &OrderItemDetailResponse{
-			ID:             item.ID,
-			OrderID:        item.OrderID,
-			ItemID:         item.ItemID,
-			Name:           item.Name,
-			Quantity:       item.Quantity,
-			UnitPrice:      item.UnitPrice.AmountBaht(),
-			Subtotal:       item.CalculateSubtotal().AmountBaht(),
-			KitchenStation: item.KitchenStation,
-			KitchenNotes:   item.KitchenNotes,
-			CreatedAt:      item.CreatedAt,
-			UpdatedAt:      item.UpdatedAt,
+			ID:               item.ID,
+			OrderID:          item.OrderID,
+			ItemID:           item.ItemID,
+			Name:             item.Name,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice.AmountBaht(),
+			DiscountPercent:  item.DiscountPercent,
+			Discount:         item.DiscountAmount().AmountBaht(),
+			Subtotal:         item.CalculateSubtotal().AmountBaht(),
+			Course:           item.Course,
+			Seat:             item.Seat,
+			Round:            item.Round,
+			HoldOnSubmit:     item.HoldOnSubmit,
+			KitchenStation:   item.KitchenStation,
+			KitchenNotes:     item.KitchenNotes,
+			Adjustment:       item.Adjustment.String(),
+			AdjustmentReason: item.AdjustmentReason,
+			AdjustedAmount:   item.AdjustedAmount.AmountBaht(),
+			CreatedAt:        item.CreatedAt,
+			UpdatedAt:        item.UpdatedAt,
+			Version:          item.Version,
 		}
 
 		if item.ItemStatus != "" {
@@ -16,27 +16,42 @@ import (
 
 // paymentUsecase implements PaymentUsecase interface
 type paymentUsecase struct {
-	paymentRepo  repository.PaymentRepository
-	orderRepo    repository.OrderRepository
-	orderService service.OrderService
-	logger       infra.Logger
-	config       *config.Config
+	paymentRepo     repository.PaymentRepository
+	orderRepo       repository.OrderRepository
+	shiftRepo       repository.ShiftRepository
+	orderService    service.OrderService
+	printerService  infra.PrinterService
+	approvalUsecase ApprovalUsecase
+	auditLogRepo    repository.AuditLogRepository
+	tx              repository.TxManager
+	logger          infra.Logger
+	config          *config.Config
 }
 
 // NewPaymentUsecase creates a new payment usecase
 func NewPaymentUsecase(
 	paymentRepo repository.PaymentRepository,
 	orderRepo repository.OrderRepository,
+	shiftRepo repository.ShiftRepository,
 	orderService service.OrderService,
+	printerService infra.PrinterService,
+	approvalUsecase ApprovalUsecase,
+	auditLogRepo repository.AuditLogRepository,
+	tx repository.TxManager,
 	logger infra.Logger,
 	config *config.Config,
 ) PaymentUsecase {
 	return &paymentUsecase{
-		paymentRepo:  paymentRepo,
-		orderRepo:    orderRepo,
-		orderService: orderService,
-		logger:       logger,
-		config:       config,
+		paymentRepo:     paymentRepo,
+		orderRepo:       orderRepo,
+		shiftRepo:       shiftRepo,
+		orderService:    orderService,
+		printerService:  printerService,
+		approvalUsecase: approvalUsecase,
+		auditLogRepo:    auditLogRepo,
+		tx:              tx,
+		logger:          logger,
+		config:          config,
 	}
 }
 
@@ -44,98 +59,249 @@ func NewPaymentUsecase(
 func (u *paymentUsecase) ProcessPayment(ctx context.Context, req *ProcessPaymentRequest) (*PaymentResponse, error) {
 	u.logger.Info("Processing payment", "orderID", req.OrderID, "amount", req.Amount, "method", req.Method)
 
-	// Check if order exists
-	order, err := u.orderRepo.GetByID(ctx, req.OrderID)
+	// Create payment entity
+	payment, err := entity.NewPayment(req.OrderID, req.Amount, req.Method)
 	if err != nil {
-		u.logger.Error("Error getting order", "error", err, "orderID", req.OrderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
+		u.logger.Error("Error creating payment entity", "error", err, "orderID", req.OrderID)
+		return nil, err
 	}
-	if order == nil {
-		u.logger.Warn("Order not found", "orderID", req.OrderID)
-		return nil, errs.ErrOrderNotFound
+	payment.ProcessedBy = actorIDFromContext(ctx)
+
+	// Tie the payment to the cashier's open shift for cash accountability
+	if payment.ProcessedBy != nil {
+		shift, err := u.shiftRepo.GetOpenByUser(ctx, *payment.ProcessedBy)
+		if err != nil {
+			u.logger.Error("Error getting open shift", "error", err, "userID", *payment.ProcessedBy)
+			return nil, fmt.Errorf("failed to get open shift: %w", err)
+		}
+		if shift == nil {
+			u.logger.Warn("Payment attempted without an open shift", "userID", *payment.ProcessedBy, "orderID", req.OrderID)
+			return nil, errs.ErrNoOpenShift
+		}
+		payment.ShiftID = &shift.ID
 	}
 
-	// Check if order is closed
-	if !order.IsClosed() {
-		u.logger.Warn("Order is not closed", "orderID", req.OrderID)
-		return nil, errs.ErrOrderNotClosed
+	// The balance is worked out with the order locked, so two payments taken
+	// at the same time cannot both be checked against the same balance
+	var order *entity.Order
+	var createdPayment *entity.Payment
+	err = runInTransaction(ctx, u.tx, func(ctx context.Context) error {
+		order, err = u.orderRepo.GetByIDForUpdate(ctx, req.OrderID)
+		if err != nil {
+			u.logger.Error("Error getting order", "error", err, "orderID", req.OrderID)
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if order == nil {
+			u.logger.Warn("Order not found", "orderID", req.OrderID)
+			return errs.ErrOrderNotFound
+		}
+
+		// Deposits may be taken while the order is still open, but not once it is cancelled
+		if order.OrderStatus == vo.OrderCancelled {
+			u.logger.Warn("Order is cancelled", "orderID", req.OrderID)
+			return errs.ErrOrderCancelled
+		}
+
+		// Payments already taken against the order, e.g. other splits of the bill
+		payments, err := u.paymentRepo.ListByOrderID(ctx, req.OrderID)
+		if err != nil {
+			u.logger.Error("Error listing order payments", "error", err, "orderID", req.OrderID)
+			return fmt.Errorf("failed to list order payments: %w", err)
+		}
+
+		// Calculate order total
+		total, err := u.orderService.CalculateOrderTotal(ctx, order)
+		if err != nil {
+			u.logger.Error("Error calculating order total", "error", err, "orderID", req.OrderID)
+			return fmt.Errorf("failed to calculate order total: %w", err)
+		}
+
+		balance, err := outstandingBalance(total, payments)
+		if err != nil {
+			return err
+		}
+		if balance.IsZero() {
+			u.logger.Warn("Order already paid", "orderID", req.OrderID)
+			return errs.ErrOrderAlreadyPaid
+		}
+
+		// Validate payment amount: a split may not exceed what is left to pay, and
+		// a split by item must pay exactly for its items
+		if payment.Amount.AmountSatang() > balance.AmountSatang() {
+			u.logger.Warn("Payment exceeds balance", "orderID", req.OrderID, "balance", balance.AmountBaht(), "actual", req.Amount)
+			return errs.ErrPaymentExceedsBalance
+		}
+		if len(req.OrderItemIDs) > 0 {
+			shares, err := u.itemShares(ctx, order, payments, [][]int{req.OrderItemIDs})
+			if err != nil {
+				return err
+			}
+			if payment.Amount.AmountSatang() != shares[0].Amount.AmountSatang() {
+				u.logger.Warn("Invalid payment amount", "orderID", req.OrderID, "expected", shares[0].Amount.AmountBaht(), "actual", req.Amount)
+				return errs.ErrInvalidPaymentAmount
+			}
+			payment.OrderItemIDs = req.OrderItemIDs
+		}
+
+		// Save payment and mark the order paid once the balance reaches zero
+		createdPayment, err = u.paymentRepo.Create(ctx, payment)
+		if err != nil {
+			u.logger.Error("Error creating payment", "error", err, "orderID", req.OrderID)
+			return fmt.Errorf("failed to create payment: %w", err)
+		}
+
+		if order.SettlePayments(total, append(payments, createdPayment)) {
+			if _, err := u.orderRepo.Update(ctx, order); err != nil {
+				u.logger.Error("Error updating order payment status", "error", err, "orderID", req.OrderID)
+				return fmt.Errorf("failed to update order payment status: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if payment already exists
-	existingPayment, err := u.paymentRepo.GetByOrderID(ctx, req.OrderID)
+	u.logger.Info("Payment processed successfully", "paymentID", createdPayment.ID, "orderID", req.OrderID, "paymentStatus", order.PaymentStatus)
+
+	return u.toPaymentResponse(createdPayment), nil
+}
+
+// GetPayment retrieves payment by ID
+func (u *paymentUsecase) GetPayment(ctx context.Context, id int) (*PaymentResponse, error) {
+	u.logger.Debug("Getting payment", "paymentID", id)
+
+	payment, err := u.paymentRepo.GetByID(ctx, id)
 	if err != nil {
-		u.logger.Error("Error checking existing payment", "error", err, "orderID", req.OrderID)
-		return nil, fmt.Errorf("failed to check existing payment: %w", err)
+		u.logger.Error("Error getting payment", "error", err, "paymentID", id)
+		return nil, fmt.Errorf("failed to get payment: %w", err)
 	}
-	if existingPayment != nil {
-		u.logger.Warn("Payment already exists", "orderID", req.OrderID)
-		return nil, errs.ErrPaymentAlreadyExists
+	if payment == nil {
+		u.logger.Warn("Payment not found", "paymentID", id)
+		return nil, errs.ErrPaymentNotFound
 	}
 
-	// Calculate order total
-	total, err := u.orderService.CalculateOrderTotal(ctx, order)
+	return u.toPaymentResponse(payment), nil
+}
+
+// ListPaymentsByOrder retrieves the payments of an order with its outstanding balance
+func (u *paymentUsecase) ListPaymentsByOrder(ctx context.Context, orderID int) (*OrderPaymentsResponse, error) {
+	u.logger.Debug("Listing payments by order", "orderID", orderID)
+
+	order, total, payments, err := u.loadOrderBill(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := outstandingBalance(total, payments)
 	if err != nil {
-		u.logger.Error("Error calculating order total", "error", err, "orderID", req.OrderID)
-		return nil, fmt.Errorf("failed to calculate order total: %w", err)
+		return nil, err
 	}
 
-	// Validate payment amount
-	if req.Amount != total.AmountBaht() {
-		u.logger.Warn("Invalid payment amount", "orderID", req.OrderID, "expected", total.AmountBaht(), "actual", req.Amount)
-		return nil, errs.ErrInvalidPaymentAmount
+	return &OrderPaymentsResponse{
+		OrderID:       order.ID,
+		PaymentStatus: order.PaymentStatus.String(),
+		Total:         total.AmountBaht(),
+		Paid:          entity.SettledAmount(payments).AmountBaht(),
+		Balance:       balance.AmountBaht(),
+		Payments:      u.toPaymentResponses(payments),
+	}, nil
+}
+
+// SplitBill works out the shares of an order's outstanding balance. Each
+// share is then settled by a payment of its own, in any method.
+func (u *paymentUsecase) SplitBill(ctx context.Context, orderID int, req *SplitBillRequest) (*SplitBillResponse, error) {
+	u.logger.Debug("Splitting bill", "orderID", orderID, "mode", req.Mode)
+
+	mode, err := vo.NewSplitMode(req.Mode)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create payment entity
-	payment, err := entity.NewPayment(req.OrderID, req.Amount, req.Method)
+	order, total, payments, err := u.loadOrderBill(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := outstandingBalance(total, payments)
 	if err != nil {
-		u.logger.Error("Error creating payment entity", "error", err, "orderID", req.OrderID)
 		return nil, err
 	}
+	if balance.IsZero() {
+		return nil, errs.ErrOrderAlreadyPaid
+	}
 
-	// Save payment to database
-	createdPayment, err := u.paymentRepo.Create(ctx, payment)
+	var shares []*entity.BillShare
+	switch mode {
+	case vo.SplitModeEven:
+		shares, err = entity.SplitEvenly(balance, req.Parts)
+	case vo.SplitModeItem:
+		shares, err = u.itemShares(ctx, order, payments, req.ItemGroups)
+	case vo.SplitModeCustom:
+		shares, err = customShares(balance, req.Amounts)
+	}
 	if err != nil {
-		u.logger.Error("Error creating payment", "error", err, "orderID", req.OrderID)
-		return nil, fmt.Errorf("failed to create payment: %w", err)
+		return nil, err
 	}
 
-	u.logger.Info("Payment processed successfully", "paymentID", createdPayment.ID, "orderID", req.OrderID)
+	responses := make([]*BillShareResponse, len(shares))
+	for i, share := range shares {
+		responses[i] = &BillShareResponse{
+			Amount:       share.Amount.AmountBaht(),
+			OrderItemIDs: share.OrderItemIDs,
+		}
+	}
 
-	return u.toPaymentResponse(createdPayment), nil
+	return &SplitBillResponse{
+		OrderID: order.ID,
+		Mode:    mode.String(),
+		Total:   total.AmountBaht(),
+		Balance: balance.AmountBaht(),
+		Shares:  responses,
+	}, nil
 }
 
-// GetPayment retrieves payment by ID
-func (u *paymentUsecase) GetPayment(ctx context.Context, id int) (*PaymentResponse, error) {
-	u.logger.Debug("Getting payment", "paymentID", id)
+// PrintPaymentReceipt prints the receipt for one payment of a split bill
+func (u *paymentUsecase) PrintPaymentReceipt(ctx context.Context, id int) error {
+	u.logger.Info("Printing payment receipt", "paymentID", id)
 
 	payment, err := u.paymentRepo.GetByID(ctx, id)
 	if err != nil {
 		u.logger.Error("Error getting payment", "error", err, "paymentID", id)
-		return nil, fmt.Errorf("failed to get payment: %w", err)
+		return fmt.Errorf("failed to get payment: %w", err)
 	}
 	if payment == nil {
-		u.logger.Warn("Payment not found", "paymentID", id)
-		return nil, errs.ErrPaymentNotFound
+		return errs.ErrPaymentNotFound
 	}
 
-	return u.toPaymentResponse(payment), nil
-}
+	order, total, payments, err := u.loadOrderBill(ctx, payment.OrderID)
+	if err != nil {
+		return err
+	}
 
-// GetPaymentByOrder retrieves payment by order ID
-func (u *paymentUsecase) GetPaymentByOrder(ctx context.Context, orderID int) (*PaymentResponse, error) {
-	u.logger.Debug("Getting payment by order", "orderID", orderID)
+	// Show what was left to pay right after this payment
+	var upToPayment []*entity.Payment
+	for _, p := range payments {
+		upToPayment = append(upToPayment, p)
+		if p.ID == payment.ID {
+			break
+		}
+	}
+	balance, err := outstandingBalance(total, upToPayment)
+	if err != nil {
+		return err
+	}
 
-	payment, err := u.paymentRepo.GetByOrderID(ctx, orderID)
+	receiptPDF, err := u.orderService.PaymentReceiptPdf(ctx, order, payment, balance)
 	if err != nil {
-		u.logger.Error("Error getting payment by order", "error", err, "orderID", orderID)
-		return nil, fmt.Errorf("failed to get payment by order: %w", err)
+		u.logger.Error("Error generating payment receipt PDF", "error", err, "paymentID", id)
+		return fmt.Errorf("failed to generate payment receipt PDF: %w", err)
 	}
-	if payment == nil {
-		u.logger.Warn("Payment not found for order", "orderID", orderID)
-		return nil, errs.ErrPaymentNotFound
+	if err := u.printerService.Print(ctx, receiptPDF, "PDF"); err != nil {
+		u.logger.Error("Error printing payment receipt", "error", err, "paymentID", id)
+		return fmt.Errorf("failed to print payment receipt: %w", err)
 	}
-
-	return u.toPaymentResponse(payment), nil
+	return nil
 }
 
 // ListPayments retrieves all payments with pagination
@@ -204,16 +370,186 @@ func (u *paymentUsecase) ListPaymentsByMethod(ctx context.Context, method string
 	}, nil
 }
 
+// RefundPayment refunds a payment under a manager's approval
+func (u *paymentUsecase) RefundPayment(ctx context.Context, id int, req *RefundPaymentRequest) (*PaymentResponse, error) {
+	u.logger.Info("Refunding payment", "paymentID", id)
+
+	// The payment is locked while it is refunded, so it is refunded once
+	// however many requests ask at the same time
+	var payment, refundedPayment *entity.Payment
+	err := runInTransaction(ctx, u.tx, func(ctx context.Context) error {
+		var err error
+		payment, err = u.paymentRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			u.logger.Error("Error getting payment", "error", err, "paymentID", id)
+			return fmt.Errorf("failed to get payment: %w", err)
+		}
+		if payment == nil {
+			return errs.ErrPaymentNotFound
+		}
+
+		before := u.toPaymentResponse(payment)
+		if err := payment.Refund(actorIDFromContext(ctx)); err != nil {
+			return err
+		}
+
+		approvalID, err := u.approvalUsecase.AuthorizeAction(ctx, req.Approval, entity.ApprovalTarget{
+			Action:    vo.ApprovalActionRefund,
+			OrderID:   payment.OrderID,
+			PaymentID: &id,
+		})
+		if err != nil {
+			return err
+		}
+
+		refundedPayment, err = u.paymentRepo.Refund(ctx, payment)
+		if err != nil {
+			u.logger.Error("Error refunding payment", "error", err, "paymentID", id)
+			return fmt.Errorf("failed to refund payment: %w", err)
+		}
+
+		if err := recordApprovedAudit(ctx, u.auditLogRepo, approvalID, vo.AuditActionPaymentRefund, entity.AuditEntityPayment, id, before, u.toPaymentResponse(refundedPayment)); err != nil {
+			u.logger.Error("Error recording payment refund", "error", err, "paymentID", id)
+			return err
+		}
+
+		if err := u.syncOrderPaymentStatus(ctx, payment.OrderID); err != nil {
+			u.logger.Error("Error updating order payment status", "error", err, "orderID", payment.OrderID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.Info("Payment refunded successfully", "paymentID", id, "orderID", payment.OrderID)
+
+	return u.toPaymentResponse(refundedPayment), nil
+}
+
+// loadOrderBill loads an order with its total and the payments taken against it
+func (u *paymentUsecase) loadOrderBill(ctx context.Context, orderID int) (*entity.Order, vo.Money, []*entity.Payment, error) {
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error getting order", "error", err, "orderID", orderID)
+		return nil, vo.Money{}, nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, vo.Money{}, nil, errs.ErrOrderNotFound
+	}
+
+	total, err := u.orderService.CalculateOrderTotal(ctx, order)
+	if err != nil {
+		u.logger.Error("Error calculating order total", "error", err, "orderID", orderID)
+		return nil, vo.Money{}, nil, fmt.Errorf("failed to calculate order total: %w", err)
+	}
+
+	payments, err := u.paymentRepo.ListByOrderID(ctx, orderID)
+	if err != nil {
+		u.logger.Error("Error listing order payments", "error", err, "orderID", orderID)
+		return nil, vo.Money{}, nil, fmt.Errorf("failed to list order payments: %w", err)
+	}
+
+	return order, total, payments, nil
+}
+
+// syncOrderPaymentStatus recomputes the order payment status after a payment
+// is removed or refunded
+func (u *paymentUsecase) syncOrderPaymentStatus(ctx context.Context, orderID int) error {
+	order, total, payments, err := u.loadOrderBill(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.SettlePayments(total, payments) {
+		if _, err := u.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order payment status: %w", err)
+		}
+	}
+	return nil
+}
+
+// itemShares prices groups of order items, one share per group. Items must
+// belong to the order, not be paid for already and appear in one group only.
+func (u *paymentUsecase) itemShares(ctx context.Context, order *entity.Order, payments []*entity.Payment, groups [][]int) ([]*entity.BillShare, error) {
+	if len(groups) == 0 {
+		return nil, errs.ErrInvalidSplitItems
+	}
+
+	itemTotals, err := u.orderService.CalculateItemTotals(ctx, order)
+	if err != nil {
+		u.logger.Error("Error calculating item totals", "error", err, "orderID", order.ID)
+		return nil, fmt.Errorf("failed to calculate item totals: %w", err)
+	}
+
+	taken := entity.PaidItemIDs(payments)
+	shares := make([]*entity.BillShare, len(groups))
+	for i, group := range groups {
+		if len(group) == 0 {
+			return nil, errs.ErrInvalidSplitItems
+		}
+
+		amount, _ := vo.NewMoneyFromSatang(0)
+		for _, itemID := range group {
+			itemTotal, ok := itemTotals[itemID]
+			if !ok || taken[itemID] {
+				return nil, errs.ErrInvalidSplitItems.WithField("order_item_id", itemID)
+			}
+			taken[itemID] = true
+			amount = amount.Add(itemTotal)
+		}
+		shares[i] = &entity.BillShare{Amount: amount, OrderItemIDs: group}
+	}
+	return shares, nil
+}
+
+// customShares checks custom split amounts add up to the outstanding balance
+func customShares(balance vo.Money, amounts []float64) ([]*entity.BillShare, error) {
+	if len(amounts) < 2 {
+		return nil, errs.ErrInvalidSplitParts
+	}
+
+	sum, _ := vo.NewMoneyFromSatang(0)
+	shares := make([]*entity.BillShare, len(amounts))
+	for i, amount := range amounts {
+		share, err := vo.NewMoneyFromBaht(amount)
+		if err != nil || share.IsZero() {
+			return nil, errs.ErrInvalidSplitAmounts
+		}
+		sum = sum.Add(share)
+		shares[i] = &entity.BillShare{Amount: share}
+	}
+	if sum.AmountSatang() != balance.AmountSatang() {
+		return nil, errs.ErrInvalidSplitAmounts
+	}
+	return shares, nil
+}
+
+// outstandingBalance returns what is left to pay on an order
+func outstandingBalance(total vo.Money, payments []*entity.Payment) (vo.Money, error) {
+	settled := entity.SettledAmount(payments)
+	if settled.AmountSatang() >= total.AmountSatang() {
+		return vo.NewMoneyFromSatang(0)
+	}
+	return total.Subtract(settled)
+}
+
 // Helper methods for conversion
 
 // toPaymentResponse converts entity to response
 func (u *paymentUsecase) toPaymentResponse(payment *entity.Payment) *PaymentResponse {
 	return &PaymentResponse{
-		ID:      payment.ID,
-		OrderID: payment.OrderID,
-		Amount:  payment.Amount.AmountBaht(),
-		Method:  payment.Method.String(),
-		PaidAt:  payment.PaidAt,
+		ID:           payment.ID,
+		OrderID:      payment.OrderID,
+		Amount:       payment.Amount.AmountBaht(),
+		Method:       payment.Method.String(),
+		PaidAt:       payment.PaidAt,
+		ProcessedBy:  payment.ProcessedBy,
+		ShiftID:      payment.ShiftID,
+		OrderItemIDs: payment.OrderItemIDs,
+		RefundedAt:   payment.RefundedAt,
+		RefundedBy:   payment.RefundedBy,
 	}
 }
 
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/config"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// promotionUsecase implements PromotionUsecase interface
+type promotionUsecase struct {
+	promotionRepo repository.PromotionRepository
+	logger        infra.Logger
+	config        *config.Config
+}
+
+// NewPromotionUsecase creates a new promotion usecase
+func NewPromotionUsecase(
+	promotionRepo repository.PromotionRepository,
+	logger infra.Logger,
+	config *config.Config,
+) PromotionUsecase {
+	return &promotionUsecase{
+		promotionRepo: promotionRepo,
+		logger:        logger,
+		config:        config,
+	}
+}
+
+// CreatePromotion creates a new promotion
+func (u *promotionUsecase) CreatePromotion(ctx context.Context, req *PromotionRequest) (*PromotionResponse, error) {
+	u.logger.Info("Creating promotion", "name", req.Name, "type", req.Type)
+
+	promotion := &entity.Promotion{CreatedBy: actorIDFromContext(ctx)}
+	if err := u.applyRequest(ctx, promotion, req); err != nil {
+		u.logger.Warn("Invalid promotion", "error", err, "name", req.Name)
+		return nil, err
+	}
+
+	createdPromotion, err := u.promotionRepo.Create(ctx, promotion)
+	if err != nil {
+		u.logger.Error("Error creating promotion", "error", err, "name", req.Name)
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	u.logger.Info("Promotion created successfully", "promotionID", createdPromotion.ID, "name", createdPromotion.Name)
+
+	return u.toPromotionResponse(createdPromotion), nil
+}
+
+// GetPromotion retrieves promotion by ID
+func (u *promotionUsecase) GetPromotion(ctx context.Context, id int) (*PromotionResponse, error) {
+	u.logger.Debug("Getting promotion", "promotionID", id)
+
+	promotion, err := u.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting promotion", "error", err, "promotionID", id)
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+	if promotion == nil {
+		u.logger.Warn("Promotion not found", "promotionID", id)
+		return nil, errs.ErrPromotionNotFound
+	}
+
+	return u.toPromotionResponse(promotion), nil
+}
+
+// UpdatePromotion replaces the rules of a promotion
+func (u *promotionUsecase) UpdatePromotion(ctx context.Context, id int, req *PromotionRequest) (*PromotionResponse, error) {
+	u.logger.Info("Updating promotion", "promotionID", id, "name", req.Name)
+
+	currentPromotion, err := u.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting current promotion", "error", err, "promotionID", id)
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+	if currentPromotion == nil {
+		return nil, errs.ErrPromotionNotFound
+	}
+
+	if err := u.applyRequest(ctx, currentPromotion, req); err != nil {
+		u.logger.Warn("Invalid promotion", "error", err, "promotionID", id)
+		return nil, err
+	}
+
+	updatedPromotion, err := u.promotionRepo.Update(ctx, currentPromotion)
+	if err != nil {
+		u.logger.Error("Error updating promotion", "error", err, "promotionID", id)
+		return nil, fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	u.logger.Info("Promotion updated successfully", "promotionID", id)
+
+	return u.toPromotionResponse(updatedPromotion), nil
+}
+
+// DeletePromotion deletes a promotion. Bills that already used it keep their
+// promotion lines.
+func (u *promotionUsecase) DeletePromotion(ctx context.Context, id int) error {
+	u.logger.Info("Deleting promotion", "promotionID", id)
+
+	promotion, err := u.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get promotion: %w", err)
+	}
+	if promotion == nil {
+		return errs.ErrPromotionNotFound
+	}
+
+	if err := u.promotionRepo.Delete(ctx, id); err != nil {
+		u.logger.Error("Error deleting promotion", "error", err, "promotionID", id)
+		return fmt.Errorf("failed to delete promotion: %w", err)
+	}
+
+	u.logger.Info("Promotion deleted successfully", "promotionID", id)
+	return nil
+}
+
+// ListPromotions retrieves promotions, highest priority first
+func (u *promotionUsecase) ListPromotions(ctx context.Context, onlyActive bool) ([]*PromotionResponse, error) {
+	u.logger.Debug("Listing promotions", "onlyActive", onlyActive)
+
+	promotions, err := u.promotionRepo.List(ctx, onlyActive, 0, 0)
+	if err != nil {
+		u.logger.Error("Error listing promotions", "error", err)
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	responses := make([]*PromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		responses[i] = u.toPromotionResponse(promotion)
+	}
+	return responses, nil
+}
+
+// applyRequest sets the promotion rules from a request, checking they are
+// valid and the coupon code is not taken by another promotion
+func (u *promotionUsecase) applyRequest(ctx context.Context, promotion *entity.Promotion, req *PromotionRequest) error {
+	promotionType, err := vo.NewPromotionType(req.Type)
+	if err != nil {
+		return err
+	}
+	amountOff, err := vo.NewMoneyFromBaht(req.AmountOff)
+	if err != nil {
+		return errs.ErrInvalidPromotionValue
+	}
+	minSpend, err := vo.NewMoneyFromBaht(req.MinSpend)
+	if err != nil {
+		return err
+	}
+
+	promotion.Name = req.Name
+	promotion.Code = entity.NormalizePromoCode(req.Code)
+	promotion.Type = promotionType
+	promotion.PercentOff = req.PercentOff
+	promotion.AmountOff = amountOff
+	promotion.BuyQuantity = req.BuyQuantity
+	promotion.FreeQuantity = req.FreeQuantity
+	promotion.MenuItemIDs = req.MenuItemIDs
+	promotion.CategoryIDs = req.CategoryIDs
+	promotion.MinSpend = minSpend
+	promotion.StartsAt = req.StartsAt
+	promotion.EndsAt = req.EndsAt
+	promotion.HappyHourStart = req.HappyHourStart
+	promotion.HappyHourEnd = req.HappyHourEnd
+	promotion.Priority = req.Priority
+	promotion.Stackable = req.Stackable
+	promotion.UsageLimit = req.UsageLimit
+	promotion.IsActive = req.IsActive == nil || *req.IsActive
+	if err := promotion.Validate(); err != nil {
+		return err
+	}
+
+	if promotion.RequiresCode() {
+		existing, err := u.promotionRepo.GetByCode(ctx, promotion.Code)
+		if err != nil {
+			return fmt.Errorf("failed to check promo code: %w", err)
+		}
+		if existing != nil && existing.ID != promotion.ID {
+			return errs.ErrDuplicatePromoCode
+		}
+	}
+	return nil
+}
+
+// Helper methods
+
+// toPromotionResponse converts entity to response
+func (u *promotionUsecase) toPromotionResponse(promotion *entity.Promotion) *PromotionResponse {
+	return &PromotionResponse{
+		ID:             promotion.ID,
+		Name:           promotion.Name,
+		Code:           promotion.Code,
+		Type:           promotion.Type.String(),
+		PercentOff:     promotion.PercentOff,
+		AmountOff:      promotion.AmountOff.AmountBaht(),
+		BuyQuantity:    promotion.BuyQuantity,
+		FreeQuantity:   promotion.FreeQuantity,
+		MenuItemIDs:    promotion.MenuItemIDs,
+		CategoryIDs:    promotion.CategoryIDs,
+		MinSpend:       promotion.MinSpend.AmountBaht(),
+		StartsAt:       promotion.StartsAt,
+		EndsAt:         promotion.EndsAt,
+		HappyHourStart: promotion.HappyHourStart,
+		HappyHourEnd:   promotion.HappyHourEnd,
+		Priority:       promotion.Priority,
+		Stackable:      promotion.Stackable,
+		UsageLimit:     promotion.UsageLimit,
+		UsageCount:     promotion.UsageCount,
+		IsActive:       promotion.IsActive,
+		CreatedBy:      promotion.CreatedBy,
+		CreatedAt:      promotion.CreatedAt,
+		UpdatedAt:      promotion.UpdatedAt,
+	}
+}
@@ -1,9 +1,11 @@
 package usecase
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
 // Request DTOs
@@ -12,13 +14,14 @@ import (
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
-	Role     string `json:"role" validate:"required,oneof=candidate company_hr admin"`
+	Role     string `json:"role" validate:"required,oneof=owner manager cashier waiter chef admin"`
 }
 
 // LoginRequest represents user login request
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required"`
+	IPAddress string `json:"-"` // client address, counted for brute-force protection
 }
 
 // UpdateProfileRequest represents user profile update request
@@ -33,6 +36,23 @@ type ChangePasswordRequest struct {
 	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
 }
 
+// VerifyEmailRequest redeems an email verification link
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ForgotPasswordRequest asks for a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest sets a new password with a password reset link
+type ResetPasswordRequest struct {
+	Token           string `json:"token" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
+}
+
 // Response DTOs
 
 // UserResponse represents user data in responses
@@ -49,8 +69,41 @@ type UserResponse struct {
 
 // LoginResponse represents login response with token
 type LoginResponse struct {
-	User  *UserResponse `json:"user"`
-	Token string        `json:"token"`
+	User                  *UserResponse `json:"user"`
+	Token                 string        `json:"token"`
+	TokenType             string        `json:"token_type"`
+	ExpiresAt             time.Time     `json:"expires_at"`
+	RefreshToken          string        `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time     `json:"refresh_token_expires_at"`
+}
+
+// PinLoginRequest represents a PIN login on a shared terminal
+type PinLoginRequest struct {
+	TerminalToken string `json:"terminal_token" validate:"required"`
+	UserID        int    `json:"user_id" validate:"required,gt=0"`
+	Pin           string `json:"pin" validate:"required,numeric,min=4,max=6"`
+}
+
+// SetPinRequest represents a quick-login PIN change request
+type SetPinRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	Pin             string `json:"pin" validate:"required,numeric,min=4,max=6"`
+}
+
+// RefreshTokenRequest represents a refresh token exchange request
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// AuthUser represents the authenticated caller resolved from an access token,
+// or the integration resolved from an API key
+type AuthUser struct {
+	UserID     int
+	Role       string
+	SessionID  string
+	TerminalID int
+	APIKeyID   int             // set instead of the user fields for API keys
+	Scopes     []vo.Permission // permissions granted to the API key
 }
 
 // Category DTOs
@@ -110,8 +163,8 @@ type MenuItemResponse struct {
 	IsRecommended    bool                     `json:"is_recommended"`
 	DisplayOrder     int                      `json:"display_order"`
 	MenuOption       []*entity.MenuItemOption `json:"menu_option"`
-	// DiscountPercent float64 `json:"discount_percent"`
-	// IsDiscounted    bool    `json:"is_discounted"`
+	DiscountPercent  float64                  `json:"discount_percent"`
+	IsDiscounted     bool                     `json:"is_discounted"`
 	// Category       *CategoryResponse           `json:"category,omitempty"`
 	// KitchenStation *KitchenStationOnlyResponse `json:"kitchen_station,omitempty"`
 }
@@ -156,32 +209,76 @@ type OrderTableDetails struct {
 
 // Order DTOs
 type CreateOrderRequest struct {
-	TableID int `json:"table_id" validate:"required,gt=0"`
+	TableID         int    `json:"table_id" validate:"omitempty,gt=0"` // required for dine-in orders
+	OrderType       string `json:"order_type,omitempty" validate:"omitempty,oneof=dine_in takeaway delivery pickup"`
+	CustomerName    string `json:"customer_name,omitempty" validate:"max=100"`
+	CustomerPhone   string `json:"customer_phone,omitempty" validate:"max=20"`
+	DeliveryAddress string `json:"delivery_address,omitempty" validate:"max=500"`
+	GuestCount      int    `json:"guest_count,omitempty" validate:"gte=0"` // covers; 0 when not recorded
+	OverrideSeating bool   `json:"override_seating,omitempty"`             // seat more guests than the table has seats
+}
+
+// UpdateGuestCountRequest changes the number of guests an order is for
+type UpdateGuestCountRequest struct {
+	GuestCount      int  `json:"guest_count" validate:"gte=0"`
+	OverrideSeating bool `json:"override_seating,omitempty"` // seat more guests than the table has seats
+	Version         *int `json:"version,omitempty"`
 }
 
 type UpdateOrderRequest struct {
-	Status string `json:"status" validate:"required,oneof=open closed"`
+	Status  string `json:"status" validate:"required,oneof=open ordered completed cancelled"`
+	Version *int   `json:"version,omitempty"` // version the client last saw; a mismatch is a conflict
+}
+
+type TransferOrderRequest struct {
+	TableID         int  `json:"table_id" validate:"required,gt=0"`
+	OverrideSeating bool `json:"override_seating,omitempty"` // seat more guests than the new table has seats
+	Version         *int `json:"version,omitempty"`          // order version the client last saw
+}
+
+type MergeOrderRequest struct {
+	TargetOrderID   int  `json:"target_order_id" validate:"required,gt=0"`
+	OverrideSeating bool `json:"override_seating,omitempty"` // seat more guests than the target's table has seats
+	Version         *int `json:"version,omitempty"`          // version of the merged order the client last saw
+	TargetVersion   *int `json:"target_version,omitempty"`   // version of the target order the client last saw
 }
 
 type OrderResponse struct {
-	ID        int            `json:"id"`
-	TableID   int            `json:"table_id"`
-	Status    string         `json:"status"`
-	QRcode    string         `json:"qr_code,omitempty"`
-	CreatedAt time.Time      `json:"created_at"`
-	ClosedAt  *time.Time     `json:"closed_at,omitempty"`
-	Table     *TableResponse `json:"table,omitempty"`
+	ID              int            `json:"id"`
+	TableID         int            `json:"table_id"`
+	OrderType       string         `json:"order_type"`
+	CustomerName    string         `json:"customer_name,omitempty"`
+	CustomerPhone   string         `json:"customer_phone,omitempty"`
+	DeliveryAddress string         `json:"delivery_address,omitempty"`
+	QueueNumber     int            `json:"queue_number,omitempty"`
+	GuestCount      int            `json:"guest_count,omitempty"`
+	Status          string         `json:"status"`
+	QRcode          string         `json:"qr_code,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ClosedAt        *time.Time     `json:"closed_at,omitempty"`
+	CreatedBy       *int           `json:"created_by,omitempty"`
+	ClosedBy        *int           `json:"closed_by,omitempty"`
+	MergedIntoID    *int           `json:"merged_into_id,omitempty"`
+	Version         int            `json:"version"`
+	Table           *TableResponse `json:"table,omitempty"`
 }
 
 type OrderWithItemsResponse struct {
-	ID        int                  `json:"id"`
-	TableID   int                  `json:"table_id"`
-	Status    string               `json:"status"`
-	Items     []*OrderItemResponse `json:"items"`
-	Total     float64              `json:"total"`
-	CreatedAt time.Time            `json:"created_at"`
-	ClosedAt  *time.Time           `json:"closed_at,omitempty"`
-	Table     *TableResponse       `json:"table,omitempty"`
+	ID           int                   `json:"id"`
+	TableID      int                   `json:"table_id"`
+	OrderType    string                `json:"order_type"`
+	CustomerName string                `json:"customer_name,omitempty"`
+	QueueNumber  int                   `json:"queue_number,omitempty"`
+	GuestCount   int                   `json:"guest_count,omitempty"`
+	Status       string                `json:"status"`
+	Items        []*OrderItemResponse  `json:"items"`            // submitted items
+	Rounds       []*OrderRoundResponse `json:"rounds,omitempty"` // submitted items by round
+	Cart         []*OrderItemResponse  `json:"cart,omitempty"`   // items not yet submitted
+	Total        float64               `json:"total"`
+	CreatedAt    time.Time             `json:"created_at"`
+	ClosedAt     *time.Time            `json:"closed_at,omitempty"`
+	Version      int                   `json:"version"`
+	Table        *TableResponse        `json:"table,omitempty"`
 }
 
 type OrderListResponse struct {
@@ -205,46 +302,102 @@ type AddOrderItemRequest struct {
 }
 
 type UpdateOrderItemRequest struct {
-	Quantity int `json:"quantity" validate:"required,gt=0"`
+	Quantity int  `json:"quantity" validate:"required,gt=0"` // submitted items may only grow; reducing them is a void
+	Version  *int `json:"version,omitempty"`
+}
+
+// ApplyItemDiscountRequest sets a manual discount on an order item
+type ApplyItemDiscountRequest struct {
+	Discount float64        `json:"discount" validate:"gte=0"`
+	Approval *ApprovalInput `json:"approval"`
+	Version  *int           `json:"version,omitempty"`
+}
+
+// VoidOrderItemRequest takes a submitted item off the bill with a reason
+// code. Items the kitchen already started need a manager's approval.
+type VoidOrderItemRequest struct {
+	ReasonCode string         `json:"reason_code"`
+	Approval   *ApprovalInput `json:"approval,omitempty"`
+	Version    *int           `json:"version,omitempty"`
+}
+
+// CompOrderItemRequest keeps a submitted item on the bill at zero price under
+// a manager's approval
+type CompOrderItemRequest struct {
+	ReasonCode string         `json:"reason_code" validate:"required"`
+	Approval   *ApprovalInput `json:"approval"`
+	Version    *int           `json:"version,omitempty"`
+}
+
+// AdjustmentReasonsResponse lists the reason codes items may be voided and
+// comped with
+type AdjustmentReasonsResponse struct {
+	Void []string `json:"void"`
+	Comp []string `json:"comp"`
 }
 
 type OrderItemResponse struct {
-	ID             int               `json:"id"`
-	OrderID        int               `json:"order_id"`
-	ItemID         int               `json:"item_id"`
-	Quantity       int               `json:"quantity"`
-	UnitPrice      float64           `json:"unit_price"`
-	Subtotal       float64           `json:"subtotal"`
-	CreatedAt      time.Time         `json:"created_at"`
-	MenuItem       *MenuItemResponse `json:"menu_item,omitempty"`
-	Name           string            `json:"name"`
-	KitchenStation string            `json:"kitchen_station,omitempty"` // optional kitchen ID for tracking
+	ID               int               `json:"id"`
+	OrderID          int               `json:"order_id"`
+	ItemID           int               `json:"item_id"`
+	Quantity         int               `json:"quantity"`
+	UnitPrice        float64           `json:"unit_price"`
+	DiscountPercent  float64           `json:"discount_percent,omitempty"` // menu markdown
+	Discount         float64           `json:"discount,omitempty"`         // markdown and manual discount together
+	Subtotal         float64           `json:"subtotal"`                   // after discounts
+	CreatedAt        time.Time         `json:"created_at"`
+	MenuItem         *MenuItemResponse `json:"menu_item,omitempty"`
+	Version          int               `json:"version"`
+	Name             string            `json:"name"`
+	Status           string            `json:"status,omitempty"`
+	Course           int               `json:"course,omitempty"`
+	Seat             int               `json:"seat,omitempty"`            // 0 for shared items
+	Round            int               `json:"round,omitempty"`           // 0 while in the cart
+	HoldOnSubmit     bool              `json:"hold_on_submit,omitempty"`  // cart item to be held once submitted
+	KitchenStation   string            `json:"kitchen_station,omitempty"` // optional kitchen ID for tracking
+	Adjustment       string            `json:"adjustment,omitempty"`      // void or comp
+	AdjustmentReason string            `json:"adjustment_reason,omitempty"`
+	AdjustedAmount   float64           `json:"adjusted_amount,omitempty"` // taken off the bill, options included
 
 }
 
 type OrderTotalResponse struct {
-	OrderID   int                  `json:"order_id"`
-	Items     []*OrderItemResponse `json:"items"`
-	Total     float64              `json:"total"`
-	ItemCount int                  `json:"item_count"`
+	OrderID       int                         `json:"order_id"`
+	Items         []*OrderItemResponse        `json:"items"`
+	ItemDiscount  float64                     `json:"item_discount"` // item markdowns and discounts, already out of the subtotal
+	Subtotal      float64                     `json:"subtotal"`
+	Discount      float64                     `json:"discount"`
+	ServiceCharge float64                     `json:"service_charge"`
+	Tax           float64                     `json:"tax"`
+	VATInclusive  bool                        `json:"vat_inclusive"` // tax is already part of the item prices
+	Total         float64                     `json:"total"`
+	ItemCount     int                         `json:"item_count"`
+	CouponCode    string                      `json:"coupon_code,omitempty"`
+	Promotions    []*AppliedPromotionResponse `json:"promotions,omitempty"` // included in the discount
 }
 
 // internal/application/dto/payment_dto.go
 
 // Payment DTOs
 type ProcessPaymentRequest struct {
-	OrderID int     `json:"order_id" validate:"required,gt=0"`
-	Amount  float64 `json:"amount" validate:"required,gt=0"`
-	Method  string  `json:"method" validate:"required,oneof=cash credit_card wallet"`
+	OrderID      int     `json:"order_id" validate:"required,gt=0"`
+	Amount       float64 `json:"amount" validate:"required,gt=0"` // may be part of the bill when splitting
+	Method       string  `json:"method" validate:"required,oneof=cash credit_card wallet"`
+	OrderItemIDs []int   `json:"order_item_ids,omitempty"` // items this payment covers when splitting by item
 }
 
 type PaymentResponse struct {
-	ID      int            `json:"id"`
-	OrderID int            `json:"order_id"`
-	Amount  float64        `json:"amount"`
-	Method  string         `json:"method"`
-	PaidAt  time.Time      `json:"paid_at"`
-	Order   *OrderResponse `json:"order,omitempty"`
+	ID           int            `json:"id"`
+	OrderID      int            `json:"order_id"`
+	Amount       float64        `json:"amount"`
+	Method       string         `json:"method"`
+	PaidAt       time.Time      `json:"paid_at"`
+	ProcessedBy  *int           `json:"processed_by,omitempty"`
+	ShiftID      *int           `json:"shift_id,omitempty"`
+	OrderItemIDs []int          `json:"order_item_ids,omitempty"`
+	RefundedAt   *time.Time     `json:"refunded_at,omitempty"`
+	RefundedBy   *int           `json:"refunded_by,omitempty"`
+	Order        *OrderResponse `json:"order,omitempty"`
 }
 
 type PaymentListResponse struct {
@@ -254,26 +407,252 @@ type PaymentListResponse struct {
 	Offset   int                `json:"offset"`
 }
 
+// OrderPaymentsResponse lists the payments of an order and what is left to pay
+type OrderPaymentsResponse struct {
+	OrderID       int                `json:"order_id"`
+	PaymentStatus string             `json:"payment_status"`
+	Total         float64            `json:"total"`
+	Paid          float64            `json:"paid"`
+	Balance       float64            `json:"balance"`
+	Payments      []*PaymentResponse `json:"payments"`
+}
+
+// SplitBillRequest describes how to divide the outstanding balance: into
+// Parts equal shares, by ItemGroups of order item IDs, or by custom Amounts
+type SplitBillRequest struct {
+	Mode       string    `json:"mode" validate:"required,oneof=even item custom"`
+	Parts      int       `json:"parts,omitempty"`
+	ItemGroups [][]int   `json:"item_groups,omitempty"`
+	Amounts    []float64 `json:"amounts,omitempty"`
+}
+
+type BillShareResponse struct {
+	Amount       float64 `json:"amount"`
+	OrderItemIDs []int   `json:"order_item_ids,omitempty"`
+}
+
+type SplitBillResponse struct {
+	OrderID int                  `json:"order_id"`
+	Mode    string               `json:"mode"`
+	Total   float64              `json:"total"`
+	Balance float64              `json:"balance"`
+	Shares  []*BillShareResponse `json:"shares"`
+}
+
+// ApprovalInput carries a manager's sign-off with a sensitive change: either
+// the ID of an approval granted remotely, or a manager's ID and PIN entered
+// on the spot together with the reason code
+type ApprovalInput struct {
+	ApprovalID *int   `json:"approval_id,omitempty"`
+	ManagerID  int    `json:"manager_id,omitempty"`
+	ManagerPin string `json:"manager_pin,omitempty"`
+	ReasonCode string `json:"reason_code,omitempty"`
+	Note       string `json:"note,omitempty"`
+}
+
+// RequestApprovalRequest asks a manager to approve a change remotely
+type RequestApprovalRequest struct {
+	Action      string `json:"action" validate:"required"`
+	OrderID     int    `json:"order_id" validate:"required,gt=0"`
+	OrderItemID *int   `json:"order_item_id,omitempty"`
+	PaymentID   *int   `json:"payment_id,omitempty"`
+	ReasonCode  string `json:"reason_code" validate:"required"`
+	Note        string `json:"note,omitempty"`
+}
+
+type ApprovalResponse struct {
+	ID          int        `json:"id"`
+	Action      string     `json:"action"`
+	Status      string     `json:"status"`
+	OrderID     int        `json:"order_id"`
+	OrderItemID *int       `json:"order_item_id,omitempty"`
+	PaymentID   *int       `json:"payment_id,omitempty"`
+	ReasonCode  string     `json:"reason_code"`
+	Note        string     `json:"note,omitempty"`
+	RequestedBy *int       `json:"requested_by,omitempty"`
+	DecidedBy   *int       `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	UsedAt      *time.Time `json:"used_at,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type ApprovalListResponse struct {
+	Approvals []*ApprovalResponse `json:"approvals"`
+	Total     int                 `json:"total"`
+	Limit     int                 `json:"limit"`
+	Offset    int                 `json:"offset"`
+}
+
+// RefundPaymentRequest refunds a payment under a manager approval
+type RefundPaymentRequest struct {
+	Approval *ApprovalInput `json:"approval"`
+}
+
+// ReopenOrderRequest reopens a completed order under a manager approval
+type ReopenOrderRequest struct {
+	Approval *ApprovalInput `json:"approval"`
+}
+
+// Shift DTOs
+type ClockInRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+type ClockOutRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// ShiftFilterRequest narrows the shift query; empty fields are ignored
+type ShiftFilterRequest struct {
+	UserID *int       `json:"user_id,omitempty"`
+	From   *time.Time `json:"from,omitempty"`
+	To     *time.Time `json:"to,omitempty"`
+}
+
+type ShiftBreakResponse struct {
+	ID        int        `json:"id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+type ShiftResponse struct {
+	ID         int                   `json:"id"`
+	UserID     int                   `json:"user_id"`
+	ClockInAt  time.Time             `json:"clock_in_at"`
+	ClockOutAt *time.Time            `json:"clock_out_at,omitempty"`
+	ClosedBy   *int                  `json:"closed_by,omitempty"`
+	Note       string                `json:"note,omitempty"`
+	OnBreak    bool                  `json:"on_break"`
+	Breaks     []*ShiftBreakResponse `json:"breaks"`
+}
+
+type ShiftListResponse struct {
+	Shifts []*ShiftResponse `json:"shifts"`
+	Total  int              `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+// ShiftPaymentMethodSummary totals the payments of one method on a shift;
+// refunded payments are counted but not included in the amount
+type ShiftPaymentMethodSummary struct {
+	Method        string  `json:"method"`
+	Count         int     `json:"count"`
+	RefundedCount int     `json:"refunded_count"`
+	Amount        float64 `json:"amount"`
+}
+
+// ShiftSummaryResponse is the end-of-shift report for payroll and cash-up
+type ShiftSummaryResponse struct {
+	Shift            *ShiftResponse               `json:"shift"`
+	HoursWorked      float64                      `json:"hours_worked"`
+	BreakMinutes     int                          `json:"break_minutes"`
+	OrdersHandled    int                          `json:"orders_handled"`
+	PaymentCount     int                          `json:"payment_count"`
+	TotalCollected   float64                      `json:"total_collected"`
+	RefundedAmount   float64                      `json:"refunded_amount"`
+	PaymentsByMethod []*ShiftPaymentMethodSummary `json:"payments_by_method"`
+}
+
+// AuditLogFilterRequest narrows the audit log query; empty fields are ignored
+type AuditLogFilterRequest struct {
+	ActorID    *int       `json:"actor_id,omitempty"`
+	Action     string     `json:"action,omitempty"`
+	EntityType string     `json:"entity_type,omitempty"`
+	EntityID   int        `json:"entity_id,omitempty"`
+	From       *time.Time `json:"from,omitempty"`
+	To         *time.Time `json:"to,omitempty"`
+}
+
+type AuditLogResponse struct {
+	ID         int             `json:"id"`
+	ActorID    *int            `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   int             `json:"entity_id"`
+	ApprovalID *int            `json:"approval_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type AuditLogListResponse struct {
+	AuditLogs []*AuditLogResponse `json:"audit_logs"`
+	Total     int                 `json:"total"`
+	Limit     int                 `json:"limit"`
+	Offset    int                 `json:"offset"`
+}
+
 // internal/application/dto/revenue_dto.go
 
 // Revenue DTOs
 type DailyRevenueResponse struct {
-	Date         time.Time `json:"date"`
-	TotalRevenue float64   `json:"total_revenue"`
-	OrderCount   int       `json:"order_count,omitempty"`
+	Date           time.Time `json:"date"`
+	TotalRevenue   float64   `json:"total_revenue"`
+	PartialRevenue float64   `json:"partial_revenue"`
+	OrderCount     int       `json:"order_count,omitempty"`
 }
 
 type MonthlyRevenueResponse struct {
-	Month        time.Time `json:"month"`
-	TotalRevenue float64   `json:"total_revenue"`
-	OrderCount   int       `json:"order_count,omitempty"`
+	Month          time.Time `json:"month"`
+	TotalRevenue   float64   `json:"total_revenue"`
+	PartialRevenue float64   `json:"partial_revenue"`
+	OrderCount     int       `json:"order_count,omitempty"`
+}
+
+type OrderTypeRevenueResponse struct {
+	OrderType    string  `json:"order_type"`
+	TotalRevenue float64 `json:"total_revenue"`
+	OrderCount   int     `json:"order_count"`
+}
+
+// PromotionRevenueResponse is the discount one promotion gave on completed orders
+type PromotionRevenueResponse struct {
+	PromotionID    int     `json:"promotion_id"`
+	Name           string  `json:"name"`
+	Code           string  `json:"code,omitempty"`
+	DiscountAmount float64 `json:"discount_amount"`
+	OrderCount     int     `json:"order_count"`
+}
+
+// ItemDiscountRevenueResponse is the discounts one menu item was sold with on
+// completed orders
+type ItemDiscountRevenueResponse struct {
+	MenuItemID     int     `json:"menu_item_id"`
+	Name           string  `json:"name"`
+	Quantity       int     `json:"quantity"`
+	MarkdownAmount float64 `json:"markdown_amount"`
+	DiscountAmount float64 `json:"discount_amount"`
+}
+
+// ItemAdjustmentRevenueResponse is the value of the items voided or comped
+// with one reason code
+type ItemAdjustmentRevenueResponse struct {
+	Adjustment string  `json:"adjustment"`
+	ReasonCode string  `json:"reason_code"`
+	ItemCount  int     `json:"item_count"`
+	Quantity   int     `json:"quantity"`
+	Amount     float64 `json:"amount"`
+}
+
+// CoverRevenueResponse is the guests served on completed orders that recorded
+// a guest count, and their average spend
+type CoverRevenueResponse struct {
+	StartDate            time.Time `json:"start_date"`
+	EndDate              time.Time `json:"end_date"`
+	Covers               int       `json:"covers"`
+	OrderCount           int       `json:"order_count"`
+	TotalRevenue         float64   `json:"total_revenue"`
+	AverageSpendPerCover float64   `json:"average_spend_per_cover"`
 }
 
 type TotalRevenueResponse struct {
-	StartDate    time.Time `json:"start_date"`
-	EndDate      time.Time `json:"end_date"`
-	TotalRevenue float64   `json:"total_revenue"`
-	OrderCount   int       `json:"order_count,omitempty"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	TotalRevenue   float64   `json:"total_revenue"`
+	PartialRevenue float64   `json:"partial_revenue"`
+	OrderCount     int       `json:"order_count,omitempty"`
 }
 
 // internal/application/dto/qr_code_dto.go
@@ -421,10 +800,13 @@ type KitchenOrderResponse struct {
 	OrderID       int                         `json:"order_id"`
 	OrderNumber   int                         `json:"order_number"`
 	TableNumber   *int                        `json:"table_number,omitempty"`
+	QueueNumber   int                         `json:"queue_number,omitempty"`
 	CustomerName  string                      `json:"customer_name,omitempty"`
 	OrderType     string                      `json:"order_type"`
+	Round         int                         `json:"round,omitempty"` // each round of an order is queued on its own
 	Items         []*KitchenOrderItemResponse `json:"items"`
 	CreatedAt     time.Time                   `json:"created_at"`
+	SubmittedAt   *time.Time                  `json:"submitted_at,omitempty"`
 	EstimatedTime int                         `json:"estimated_time,omitempty"` // minutes
 }
 
@@ -434,10 +816,14 @@ type KitchenOrderItemResponse struct {
 	Name            string                     `json:"name"`
 	Quantity        int                        `json:"quantity"`
 	Status          string                     `json:"status"`
+	Course          int                        `json:"course,omitempty"`
+	Seat            int                        `json:"seat,omitempty"`
+	Round           int                        `json:"round,omitempty"`
 	PreparationTime int                        `json:"preparation_time,omitempty"`
 	KitchenStation  string                     `json:"kitchen_station,omitempty"`
 	KitchenNotes    string                     `json:"kitchen_notes,omitempty"`
 	Notes           string                     `json:"notes,omitempty"`
+	CancelReason    string                     `json:"cancel_reason,omitempty"` // reason code of a voided item
 	Options         []*OrderItemOptionResponse `json:"options,omitempty"`
 	CreatedAt       time.Time                  `json:"created_at"`
 	StartedAt       *time.Time                 `json:"started_at,omitempty"`
@@ -469,8 +855,8 @@ type KitchenStationResponse struct {
 type OrderFilterRequest struct {
 	PaginationRequest
 	Status        string     `json:"status,omitempty" validate:"omitempty,oneof=open ordered completed cancelled"`
-	OrderType     string     `json:"order_type,omitempty" validate:"omitempty,oneof=dine_in phone online"`
-	PaymentStatus string     `json:"payment_status,omitempty" validate:"omitempty,oneof=unpaid paid refunded"`
+	OrderType     string     `json:"order_type,omitempty" validate:"omitempty,oneof=dine_in takeaway delivery pickup"`
+	PaymentStatus string     `json:"payment_status,omitempty" validate:"omitempty,oneof=unpaid partial paid refunded"`
 	TableID       *int       `json:"table_id,omitempty" validate:"omitempty,gt=0"`
 	StartDate     *time.Time `json:"start_date,omitempty"`
 	EndDate       *time.Time `json:"end_date,omitempty"`
@@ -505,12 +891,16 @@ type KitchenStationOnlyResponse struct {
 
 type AddOrderItemListRequest struct {
 	OrderID int                 `json:"order_id" validate:"required,gt=0"`
+	Version *int                `json:"version,omitempty"` // order version the client last saw
 	Items   []*OrderItemRequest `json:"items" validate:"required,dive,required"`
 }
 type OrderItemRequest struct {
 	MenuItemID int                       `json:"menu_item_id" validate:"required,gt=0"`
 	Quantity   int                       `json:"quantity" validate:"required,gt=0"`
 	Options    []*OrderItemOptionRequest `json:"options,omitempty"`
+	Course     int                       `json:"course,omitempty" validate:"gte=0"` // 0 when the item is not coursed
+	Seat       int                       `json:"seat,omitempty" validate:"gte=0"`   // 0 for items the table shares
+	Hold       bool                      `json:"hold,omitempty"`                    // keep out of the kitchen until the course is fired
 }
 
 type OrderItemOptionRequest struct {
@@ -520,6 +910,7 @@ type OrderItemOptionRequest struct {
 
 type UpdateOrderItemListRequest struct {
 	OrderID int                        `json:"order_id" validate:"required,gt=0"`
+	Version *int                       `json:"version,omitempty"` // order version the client last saw
 	Items   []*UpdateOrderItemRequest2 `json:"items" validate:"required,dive,required"`
 }
 
@@ -531,6 +922,7 @@ type UpdateOrderItemRequest2 struct {
 	Quantity    int                             `json:"quantity" validate:"required,gt=0"`
 	Options     []*OrderItemOptionUpdateRequest `json:"options,omitempty"`
 	Action      string                          `json:"action,omitempty" validate:"omitempty,oneof=update delete"`
+	Version     *int                            `json:"version,omitempty"` // item version the client last saw
 }
 
 type OrderItemOptionUpdateRequest struct {
@@ -544,6 +936,7 @@ type OrderItemOptionUpdateRequest struct {
 // ManageOrderItemListRequest - รวม add และ update เข้าด้วยกัน
 type ManageOrderItemListRequest struct {
 	OrderID int                           `json:"order_id" validate:"required,gt=0"`
+	Version *int                          `json:"version,omitempty"` // order version the client last saw
 	Items   []*ManageOrderItemItemRequest `json:"items" validate:"required,dive,required"`
 }
 
@@ -553,6 +946,10 @@ type ManageOrderItemItemRequest struct {
 	Quantity    int                             `json:"quantity" validate:"required,gte=0"` // 0 หมายถึง delete
 	Options     []*OrderItemOptionManageRequest `json:"options,omitempty"`
 	Action      string                          `json:"action,omitempty" validate:"omitempty,oneof=add update delete"` // default: add
+	Version     *int                            `json:"version,omitempty"`                                             // item version the client last saw, for update/delete
+	Course      int                             `json:"course,omitempty" validate:"gte=0"`                             // for add, 0 when the item is not coursed
+	Seat        int                             `json:"seat,omitempty" validate:"gte=0"`                               // for add, 0 for items the table shares
+	Hold        bool                            `json:"hold,omitempty"`                                                // for add, keep out of the kitchen until the course is fired
 }
 
 type OrderItemOptionManageRequest struct {
@@ -566,6 +963,7 @@ type OrderSearchRequest struct {
 	PaginationRequest
 	TableID   *int       `json:"table_id,omitempty"`
 	Status    string     `json:"status,omitempty"`
+	OrderType string     `json:"order_type,omitempty"`
 	StartDate *time.Time `json:"start_date,omitempty"`
 	EndDate   *time.Time `json:"end_date,omitempty"`
 	Search    string     `json:"search,omitempty"` // search by order number or customer info
@@ -573,45 +971,76 @@ type OrderSearchRequest struct {
 
 // Enhanced Order Detail Response with full information
 type OrderDetailResponse struct {
-	ID                  int                        `json:"id"`
-	OrderNumber         int                        `json:"order_number,omitempty"`
-	TableID             int                        `json:"table_id"`
-	TableNumber         int                        `json:"table_number,omitempty"`
-	Status              string                     `json:"status"`
-	QRcode              string                     `json:"qr_code,omitempty"`
-	PaymentStatus       string                     `json:"payment_status,omitempty"`
-	Notes               string                     `json:"notes,omitempty"`
-	SpecialInstructions string                     `json:"special_instructions,omitempty"`
-	Items               []*OrderItemDetailResponse `json:"items"`
-	ItemCount           int                        `json:"item_count"`
-	Subtotal            float64                    `json:"subtotal"`
-	Discount            float64                    `json:"discount,omitempty"`
-	Tax                 float64                    `json:"tax,omitempty"`
-	ServiceCharge       float64                    `json:"service_charge,omitempty"`
-	Total               float64                    `json:"total"`
-	CreatedAt           time.Time                  `json:"created_at"`
-	UpdatedAt           time.Time                  `json:"updated_at"`
-	ClosedAt            *time.Time                 `json:"closed_at,omitempty"`
-	Table               *TableResponse             `json:"table,omitempty"`
-	Payment             *PaymentResponse           `json:"payment,omitempty"`
+	ID                  int                          `json:"id"`
+	OrderNumber         int                          `json:"order_number,omitempty"`
+	TableID             int                          `json:"table_id"`
+	TableNumber         int                          `json:"table_number,omitempty"`
+	OrderType           string                       `json:"order_type"`
+	CustomerName        string                       `json:"customer_name,omitempty"`
+	CustomerPhone       string                       `json:"customer_phone,omitempty"`
+	DeliveryAddress     string                       `json:"delivery_address,omitempty"`
+	QueueNumber         int                          `json:"queue_number,omitempty"`
+	GuestCount          int                          `json:"guest_count,omitempty"`
+	Status              string                       `json:"status"`
+	QRcode              string                       `json:"qr_code,omitempty"`
+	PaymentStatus       string                       `json:"payment_status,omitempty"`
+	Notes               string                       `json:"notes,omitempty"`
+	SpecialInstructions string                       `json:"special_instructions,omitempty"`
+	Items               []*OrderItemDetailResponse   `json:"items"`            // submitted items
+	Rounds              []*OrderRoundResponse        `json:"rounds,omitempty"` // submitted items by round
+	Cart                []*OrderItemDetailResponse   `json:"cart,omitempty"`   // items not yet submitted
+	ItemCount           int                          `json:"item_count"`
+	Subtotal            float64                      `json:"subtotal"`
+	Discount            float64                      `json:"discount,omitempty"`
+	CouponCode          string                       `json:"coupon_code,omitempty"`
+	Promotions          []*AppliedPromotionResponse  `json:"promotions,omitempty"` // included in the discount
+	Tax                 float64                      `json:"tax,omitempty"`
+	ServiceCharge       float64                      `json:"service_charge,omitempty"`
+	Total               float64                      `json:"total"`
+	PaidAmount          float64                      `json:"paid_amount"`
+	RemainingAmount     float64                      `json:"remaining_amount"`
+	CreatedAt           time.Time                    `json:"created_at"`
+	UpdatedAt           time.Time                    `json:"updated_at"`
+	ClosedAt            *time.Time                   `json:"closed_at,omitempty"`
+	Table               *TableResponse               `json:"table,omitempty"`
+	Payment             *PaymentResponse             `json:"payment,omitempty"`
+	StatusHistory       []*OrderStatusChangeResponse `json:"status_history,omitempty"`
+	Version             int                          `json:"version"`
+}
+
+type OrderStatusChangeResponse struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  *int      `json:"changed_by,omitempty"`
+	ChangedAt  time.Time `json:"changed_at"`
 }
 
 // Enhanced Order Item Response with options
 type OrderItemDetailResponse struct {
-	ID             int                        `json:"id"`
-	OrderID        int                        `json:"order_id"`
-	ItemID         int                        `json:"item_id"`
-	Name           string                     `json:"name"`
-	Quantity       int                        `json:"quantity"`
-	UnitPrice      float64                    `json:"unit_price"`
-	Subtotal       float64                    `json:"subtotal"`
-	Status         string                     `json:"status,omitempty"`
-	KitchenStation string                     `json:"kitchen_station,omitempty"`
-	KitchenNotes   string                     `json:"kitchen_notes,omitempty"`
-	Options        []*OrderItemOptionResponse `json:"options,omitempty"`
-	CreatedAt      time.Time                  `json:"created_at"`
-	UpdatedAt      time.Time                  `json:"updated_at"`
-	MenuItem       *MenuItemResponse          `json:"menu_item,omitempty"`
+	ID               int                        `json:"id"`
+	OrderID          int                        `json:"order_id"`
+	ItemID           int                        `json:"item_id"`
+	Name             string                     `json:"name"`
+	Quantity         int                        `json:"quantity"`
+	UnitPrice        float64                    `json:"unit_price"`
+	DiscountPercent  float64                    `json:"discount_percent,omitempty"`
+	Discount         float64                    `json:"discount,omitempty"`
+	Subtotal         float64                    `json:"subtotal"`
+	Status           string                     `json:"status,omitempty"`
+	Course           int                        `json:"course,omitempty"`
+	Seat             int                        `json:"seat,omitempty"`           // 0 for shared items
+	Round            int                        `json:"round,omitempty"`          // 0 while in the cart
+	HoldOnSubmit     bool                       `json:"hold_on_submit,omitempty"` // cart item to be held once submitted
+	KitchenStation   string                     `json:"kitchen_station,omitempty"`
+	KitchenNotes     string                     `json:"kitchen_notes,omitempty"`
+	Adjustment       string                     `json:"adjustment,omitempty"` // void or comp
+	AdjustmentReason string                     `json:"adjustment_reason,omitempty"`
+	AdjustedAmount   float64                    `json:"adjusted_amount,omitempty"` // taken off the bill, options included
+	Options          []*OrderItemOptionResponse `json:"options,omitempty"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
+	MenuItem         *MenuItemResponse          `json:"menu_item,omitempty"`
+	Version          int                        `json:"version"`
 }
 
 // ==================== Menu Item with Options DTOs ====================
@@ -624,6 +1053,8 @@ type CreateMenuItemWithOptionsRequest struct {
 	Price            float64                        `json:"price" validate:"required,gte=0"`
 	IsActive         bool                           `json:"is_active"`
 	IsRecommended    bool                           `json:"is_recommended"`
+	DiscountPercent  float64                        `json:"discount_percent,omitempty" validate:"gte=0,lte=100"`
+	IsDiscounted     bool                           `json:"is_discounted"`
 	DisplayOrder     int                            `json:"display_order,omitempty"`
 	AssignedOptions  []*AssignMenuItemOptionRequest `json:"assigned_options,omitempty"`
 }
@@ -636,6 +1067,8 @@ type UpdateMenuItemWithOptionsRequest struct {
 	Price            float64                        `json:"price" validate:"required,gte=0"`
 	IsActive         bool                           `json:"is_active"`
 	IsRecommended    bool                           `json:"is_recommended"`
+	DiscountPercent  float64                        `json:"discount_percent,omitempty" validate:"gte=0,lte=100"`
+	IsDiscounted     bool                           `json:"is_discounted"`
 	DisplayOrder     int                            `json:"display_order,omitempty"`
 	AssignedOptions  []*AssignMenuItemOptionRequest `json:"assigned_options,omitempty"`
 }
@@ -654,6 +1087,8 @@ type MenuItemWithOptionsResponse struct {
 	Price            float64                         `json:"price"`
 	IsActive         bool                            `json:"is_active"`
 	IsRecommended    bool                            `json:"is_recommended"`
+	DiscountPercent  float64                         `json:"discount_percent"`
+	IsDiscounted     bool                            `json:"is_discounted"`
 	DisplayOrder     int                             `json:"display_order"`
 	Category         string                          `json:"category"`
 	KitchenStation   string                          `json:"kitchen_station"`
@@ -829,3 +1264,159 @@ type SelectedOptionResponse struct {
 	ValueName       string  `json:"value_name"`
 	AdditionalPrice float64 `json:"additional_price"`
 }
+
+// Terminal DTOs
+type RegisterTerminalRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+type TerminalResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	IsActive   bool       `json:"is_active"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TerminalRegistrationResponse carries the device token, which is only shown once
+type TerminalRegistrationResponse struct {
+	Terminal *TerminalResponse `json:"terminal"`
+	Token    string            `json:"token"`
+}
+
+// API Key DTOs
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty" validate:"omitempty,gt=0"` // never expires when zero
+}
+
+type APIKeyResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  *int       `json:"created_by,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeyCreatedResponse carries the API key, which is only shown once
+type APIKeyCreatedResponse struct {
+	APIKey *APIKeyResponse `json:"api_key"`
+	Key    string          `json:"key"`
+}
+
+// Promotion DTOs
+
+// PromotionRequest creates a promotion or replaces its rules
+type PromotionRequest struct {
+	Name           string     `json:"name" validate:"required,min=1,max=100"`
+	Code           string     `json:"code,omitempty" validate:"omitempty,max=50"` // coupon code; empty to apply automatically
+	Type           string     `json:"type" validate:"required,oneof=percentage fixed_amount buy_x_get_y"`
+	PercentOff     float64    `json:"percent_off,omitempty" validate:"omitempty,gt=0,lte=100"`
+	AmountOff      float64    `json:"amount_off,omitempty" validate:"omitempty,gt=0"`
+	BuyQuantity    int        `json:"buy_quantity,omitempty" validate:"omitempty,gt=0"`
+	FreeQuantity   int        `json:"free_quantity,omitempty" validate:"omitempty,gt=0"`
+	MenuItemIDs    []int      `json:"menu_item_ids,omitempty"` // with no categories either, the whole order
+	CategoryIDs    []int      `json:"category_ids,omitempty"`
+	MinSpend       float64    `json:"min_spend,omitempty" validate:"omitempty,gte=0"`
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	HappyHourStart string     `json:"happy_hour_start,omitempty"` // HH:MM
+	HappyHourEnd   string     `json:"happy_hour_end,omitempty"`   // HH:MM
+	Priority       int        `json:"priority"`
+	Stackable      bool       `json:"stackable"`
+	UsageLimit     int        `json:"usage_limit,omitempty" validate:"omitempty,gte=0"` // 1 for single-use codes
+	IsActive       *bool      `json:"is_active,omitempty"`                              // defaults to true
+}
+
+type PromotionResponse struct {
+	ID             int        `json:"id"`
+	Name           string     `json:"name"`
+	Code           string     `json:"code,omitempty"`
+	Type           string     `json:"type"`
+	PercentOff     float64    `json:"percent_off,omitempty"`
+	AmountOff      float64    `json:"amount_off,omitempty"`
+	BuyQuantity    int        `json:"buy_quantity,omitempty"`
+	FreeQuantity   int        `json:"free_quantity,omitempty"`
+	MenuItemIDs    []int      `json:"menu_item_ids,omitempty"`
+	CategoryIDs    []int      `json:"category_ids,omitempty"`
+	MinSpend       float64    `json:"min_spend,omitempty"`
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	HappyHourStart string     `json:"happy_hour_start,omitempty"`
+	HappyHourEnd   string     `json:"happy_hour_end,omitempty"`
+	Priority       int        `json:"priority"`
+	Stackable      bool       `json:"stackable"`
+	UsageLimit     int        `json:"usage_limit,omitempty"`
+	UsageCount     int        `json:"usage_count"`
+	IsActive       bool       `json:"is_active"`
+	CreatedBy      *int       `json:"created_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// AppliedPromotionResponse is a promotion line on a bill
+type AppliedPromotionResponse struct {
+	PromotionID int     `json:"promotion_id"`
+	Name        string  `json:"name"`
+	Code        string  `json:"code,omitempty"`
+	Amount      float64 `json:"amount"`
+}
+
+// ApplyCouponRequest gives an order a promo code
+type ApplyCouponRequest struct {
+	Code string `json:"code" validate:"required,max=50"`
+}
+
+// SubmitRoundRequest sends the items in an order's cart to the kitchen as
+// the next round
+type SubmitRoundRequest struct {
+	OrderID int  `json:"order_id,omitempty"`
+	Version *int `json:"version,omitempty"` // order version the client last saw
+}
+
+// OrderRoundResponse is a round of items submitted to the kitchen together
+type OrderRoundResponse struct {
+	Number      int        `json:"number"` // 0 for items ordered before rounds were numbered
+	Status      string     `json:"status"` // status of the least advanced item
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	ItemIDs     []int      `json:"item_ids"`
+}
+
+// RepeatOrderItemsRequest copies a round, or chosen items, of this or an
+// earlier order into the order's cart at today's prices
+type RepeatOrderItemsRequest struct {
+	SourceOrderID int   `json:"source_order_id,omitempty" validate:"omitempty,gt=0"` // 0 repeats from the same order
+	Round         *int  `json:"round,omitempty" validate:"omitempty,gte=0"`
+	OrderItemIDs  []int `json:"order_item_ids,omitempty" validate:"omitempty,dive,gt=0"`
+	Version       *int  `json:"version,omitempty"` // order version the client last saw
+}
+
+// RepeatOrderItemsResponse lists the items added to the cart and those that
+// can no longer be ordered
+type RepeatOrderItemsResponse struct {
+	Items   []*OrderItemResponse   `json:"items"`
+	Skipped []*SkippedItemResponse `json:"skipped"`
+}
+
+// SkippedItemResponse is an item that was not repeated, and why
+type SkippedItemResponse struct {
+	OrderItemID int    `json:"order_item_id"`
+	MenuItemID  int    `json:"menu_item_id"`
+	Name        string `json:"name"`
+	Reason      string `json:"reason"`
+}
+
+// FireCourseRequest sends held items of an order to the kitchen
+type FireCourseRequest struct {
+	Course *int `json:"course,omitempty" validate:"omitempty,gte=0"` // nil fires every held item
+}
+
+// HoldCourseRequest keeps pending items of an order from the kitchen
+type HoldCourseRequest struct {
+	Course *int `json:"course,omitempty" validate:"omitempty,gte=0"` // nil holds every pending item
+}
@@ -10,6 +10,7 @@ import (
 	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
 // revenueUsecase implements RevenueUsecase interface
@@ -77,9 +78,10 @@ func (u *revenueUsecase) GetDailyRevenue(ctx context.Context, date time.Time) (*
 	orderCount := len(orders)
 
 	return &DailyRevenueResponse{
-		Date:         dailyRevenue.Date,
-		TotalRevenue: dailyRevenue.TotalRevenue.AmountBaht(),
-		OrderCount:   orderCount,
+		Date:           dailyRevenue.Date,
+		TotalRevenue:   dailyRevenue.TotalRevenue.AmountBaht(),
+		PartialRevenue: dailyRevenue.PartialRevenue.AmountBaht(),
+		OrderCount:     orderCount,
 	}, nil
 }
 
@@ -127,9 +129,10 @@ func (u *revenueUsecase) GetMonthlyRevenue(ctx context.Context, year int, month
 	orderCount := len(orders)
 
 	return &MonthlyRevenueResponse{
-		Month:        monthlyRevenue.Month,
-		TotalRevenue: monthlyRevenue.TotalRevenue.AmountBaht(),
-		OrderCount:   orderCount,
+		Month:          monthlyRevenue.Month,
+		TotalRevenue:   monthlyRevenue.TotalRevenue.AmountBaht(),
+		PartialRevenue: monthlyRevenue.PartialRevenue.AmountBaht(),
+		OrderCount:     orderCount,
 	}, nil
 }
 
@@ -170,9 +173,10 @@ func (u *revenueUsecase) GetDailyRevenueRange(ctx context.Context, startDate, en
 		}
 
 		responses[i] = &DailyRevenueResponse{
-			Date:         revenue.Date,
-			TotalRevenue: revenue.TotalRevenue.AmountBaht(),
-			OrderCount:   orderCount,
+			Date:           revenue.Date,
+			TotalRevenue:   revenue.TotalRevenue.AmountBaht(),
+			PartialRevenue: revenue.PartialRevenue.AmountBaht(),
+			OrderCount:     orderCount,
 		}
 	}
 
@@ -209,9 +213,10 @@ func (u *revenueUsecase) GetMonthlyRevenueRange(ctx context.Context, startDate,
 		}
 
 		responses[i] = &MonthlyRevenueResponse{
-			Month:        revenue.Month,
-			TotalRevenue: revenue.TotalRevenue.AmountBaht(),
-			OrderCount:   orderCount,
+			Month:          revenue.Month,
+			TotalRevenue:   revenue.TotalRevenue.AmountBaht(),
+			PartialRevenue: revenue.PartialRevenue.AmountBaht(),
+			OrderCount:     orderCount,
 		}
 	}
 
@@ -235,6 +240,13 @@ func (u *revenueUsecase) GetTotalRevenue(ctx context.Context, startDate, endDate
 		return nil, fmt.Errorf("failed to get total revenue: %w", err)
 	}
 
+	// Part of the revenue taken against orders not yet paid in full
+	partialRevenue, err := u.revenueRepo.GetPartialRevenue(ctx, startDate, endDate)
+	if err != nil {
+		u.logger.Error("Error getting partial revenue", "error", err, "startDate", startDate, "endDate", endDate)
+		return nil, fmt.Errorf("failed to get partial revenue: %w", err)
+	}
+
 	// Get order count for the period
 	orders, err := u.orderRepo.ListByDateRange(ctx, startDate, endDate, 100000, 0) // Get all orders for count
 	orderCount := 0
@@ -246,9 +258,172 @@ func (u *revenueUsecase) GetTotalRevenue(ctx context.Context, startDate, endDate
 	}
 
 	return &TotalRevenueResponse{
-		StartDate:    startDate,
-		EndDate:      endDate,
-		TotalRevenue: totalRevenue,
-		OrderCount:   orderCount,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		TotalRevenue:   totalRevenue,
+		PartialRevenue: partialRevenue,
+		OrderCount:     orderCount,
+	}, nil
+}
+
+// GetRevenueByOrderType retrieves revenue per order type for a date range,
+// optionally only for one order type
+func (u *revenueUsecase) GetRevenueByOrderType(ctx context.Context, startDate, endDate time.Time, orderType string) ([]*OrderTypeRevenueResponse, error) {
+	u.logger.Debug("Getting revenue by order type", "startDate", startDate, "endDate", endDate, "orderType", orderType)
+
+	// Validate date range
+	if startDate.After(endDate) {
+		u.logger.Error("Invalid date range", "startDate", startDate, "endDate", endDate)
+		return nil, errs.ErrInvalidDateRange
+	}
+
+	var filter vo.OrderType
+	if orderType != "" {
+		t, err := vo.NewOrderType(orderType)
+		if err != nil {
+			u.logger.Error("Invalid order type", "error", err, "orderType", orderType)
+			return nil, err
+		}
+		filter = t
+	}
+
+	revenues, err := u.revenueRepo.GetRevenueByOrderType(ctx, startDate, endDate)
+	if err != nil {
+		u.logger.Error("Error getting revenue by order type", "error", err, "startDate", startDate, "endDate", endDate)
+		return nil, fmt.Errorf("failed to get revenue by order type: %w", err)
+	}
+
+	responses := make([]*OrderTypeRevenueResponse, 0, len(revenues))
+	for _, revenue := range revenues {
+		if filter != "" && revenue.OrderType != filter {
+			continue
+		}
+		responses = append(responses, &OrderTypeRevenueResponse{
+			OrderType:    revenue.OrderType.String(),
+			TotalRevenue: revenue.TotalRevenue.AmountBaht(),
+			OrderCount:   revenue.OrderCount,
+		})
+	}
+
+	return responses, nil
+}
+
+// GetPromotionRevenue retrieves the discount each promotion gave on orders
+// completed in a date range
+func (u *revenueUsecase) GetPromotionRevenue(ctx context.Context, startDate, endDate time.Time) ([]*PromotionRevenueResponse, error) {
+	u.logger.Debug("Getting promotion revenue", "startDate", startDate, "endDate", endDate)
+
+	// Validate date range
+	if startDate.After(endDate) {
+		u.logger.Error("Invalid date range", "startDate", startDate, "endDate", endDate)
+		return nil, errs.ErrInvalidDateRange
+	}
+
+	revenues, err := u.revenueRepo.GetPromotionRevenue(ctx, startDate, endDate)
+	if err != nil {
+		u.logger.Error("Error getting promotion revenue", "error", err, "startDate", startDate, "endDate", endDate)
+		return nil, fmt.Errorf("failed to get promotion revenue: %w", err)
+	}
+
+	responses := make([]*PromotionRevenueResponse, len(revenues))
+	for i, revenue := range revenues {
+		responses[i] = &PromotionRevenueResponse{
+			PromotionID:    revenue.PromotionID,
+			Name:           revenue.Name,
+			Code:           revenue.Code,
+			DiscountAmount: revenue.DiscountAmount.AmountBaht(),
+			OrderCount:     revenue.OrderCount,
+		}
+	}
+
+	return responses, nil
+}
+
+// GetItemDiscountRevenue retrieves the markdowns and manual discounts each
+// menu item was sold with on orders completed in a date range
+func (u *revenueUsecase) GetItemDiscountRevenue(ctx context.Context, startDate, endDate time.Time) ([]*ItemDiscountRevenueResponse, error) {
+	u.logger.Debug("Getting item discount revenue", "startDate", startDate, "endDate", endDate)
+
+	// Validate date range
+	if startDate.After(endDate) {
+		u.logger.Error("Invalid date range", "startDate", startDate, "endDate", endDate)
+		return nil, errs.ErrInvalidDateRange
+	}
+
+	revenues, err := u.revenueRepo.GetItemDiscountRevenue(ctx, startDate, endDate)
+	if err != nil {
+		u.logger.Error("Error getting item discount revenue", "error", err, "startDate", startDate, "endDate", endDate)
+		return nil, fmt.Errorf("failed to get item discount revenue: %w", err)
+	}
+
+	responses := make([]*ItemDiscountRevenueResponse, len(revenues))
+	for i, revenue := range revenues {
+		responses[i] = &ItemDiscountRevenueResponse{
+			MenuItemID:     revenue.MenuItemID,
+			Name:           revenue.Name,
+			Quantity:       revenue.Quantity,
+			MarkdownAmount: revenue.MarkdownAmount.AmountBaht(),
+			DiscountAmount: revenue.DiscountAmount.AmountBaht(),
+		}
+	}
+
+	return responses, nil
+}
+
+// GetItemAdjustmentRevenue retrieves the value of the items voided and comped
+// in a date range, per reason code
+func (u *revenueUsecase) GetItemAdjustmentRevenue(ctx context.Context, startDate, endDate time.Time) ([]*ItemAdjustmentRevenueResponse, error) {
+	u.logger.Debug("Getting item adjustment revenue", "startDate", startDate, "endDate", endDate)
+
+	// Validate date range
+	if startDate.After(endDate) {
+		u.logger.Error("Invalid date range", "startDate", startDate, "endDate", endDate)
+		return nil, errs.ErrInvalidDateRange
+	}
+
+	revenues, err := u.revenueRepo.GetItemAdjustmentRevenue(ctx, startDate, endDate)
+	if err != nil {
+		u.logger.Error("Error getting item adjustment revenue", "error", err, "startDate", startDate, "endDate", endDate)
+		return nil, fmt.Errorf("failed to get item adjustment revenue: %w", err)
+	}
+
+	responses := make([]*ItemAdjustmentRevenueResponse, len(revenues))
+	for i, revenue := range revenues {
+		responses[i] = &ItemAdjustmentRevenueResponse{
+			Adjustment: revenue.Adjustment.String(),
+			ReasonCode: revenue.ReasonCode,
+			ItemCount:  revenue.ItemCount,
+			Quantity:   revenue.Quantity,
+			Amount:     revenue.Amount.AmountBaht(),
+		}
+	}
+
+	return responses, nil
+}
+
+// GetCoverRevenue retrieves the guests served on orders completed in a date
+// range and their average spend
+func (u *revenueUsecase) GetCoverRevenue(ctx context.Context, startDate, endDate time.Time) (*CoverRevenueResponse, error) {
+	u.logger.Debug("Getting cover revenue", "startDate", startDate, "endDate", endDate)
+
+	// Validate date range
+	if startDate.After(endDate) {
+		u.logger.Error("Invalid date range", "startDate", startDate, "endDate", endDate)
+		return nil, errs.ErrInvalidDateRange
+	}
+
+	revenue, err := u.revenueRepo.GetCoverRevenue(ctx, startDate, endDate)
+	if err != nil {
+		u.logger.Error("Error getting cover revenue", "error", err, "startDate", startDate, "endDate", endDate)
+		return nil, fmt.Errorf("failed to get cover revenue: %w", err)
+	}
+
+	return &CoverRevenueResponse{
+		StartDate:            startDate,
+		EndDate:              endDate,
+		Covers:               revenue.Covers,
+		OrderCount:           revenue.OrderCount,
+		TotalRevenue:         revenue.TotalRevenue.AmountBaht(),
+		AverageSpendPerCover: revenue.AverageSpendPerCover().AmountBaht(),
 	}, nil
 }
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/config"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// shiftUsecase implements ShiftUsecase interface
+type shiftUsecase struct {
+	shiftRepo   repository.ShiftRepository
+	paymentRepo repository.PaymentRepository
+	orderRepo   repository.OrderRepository
+	tx          repository.TxManager
+	logger      infra.Logger
+	config      *config.Config
+}
+
+// NewShiftUsecase creates a new shift usecase
+func NewShiftUsecase(
+	shiftRepo repository.ShiftRepository,
+	paymentRepo repository.PaymentRepository,
+	orderRepo repository.OrderRepository,
+	tx repository.TxManager,
+	logger infra.Logger,
+	config *config.Config,
+) ShiftUsecase {
+	return &shiftUsecase{
+		shiftRepo:   shiftRepo,
+		paymentRepo: paymentRepo,
+		orderRepo:   orderRepo,
+		tx:          tx,
+		logger:      logger,
+		config:      config,
+	}
+}
+
+// ClockIn opens a shift for the current staff member
+func (u *shiftUsecase) ClockIn(ctx context.Context, req *ClockInRequest) (*ShiftResponse, error) {
+	userID := actorIDFromContext(ctx)
+	if userID == nil {
+		return nil, errs.ErrPermissionDenied
+	}
+	u.logger.Info("Clocking in", "userID", *userID)
+
+	openShift, err := u.shiftRepo.GetOpenByUser(ctx, *userID)
+	if err != nil {
+		u.logger.Error("Error getting open shift", "error", err, "userID", *userID)
+		return nil, fmt.Errorf("failed to get open shift: %w", err)
+	}
+	if openShift != nil {
+		return nil, errs.ErrShiftAlreadyOpen
+	}
+
+	createdShift, err := u.shiftRepo.Create(ctx, entity.NewShift(*userID, req.Note))
+	if err != nil {
+		u.logger.Error("Error creating shift", "error", err, "userID", *userID)
+		return nil, fmt.Errorf("failed to create shift: %w", err)
+	}
+
+	u.logger.Info("Clocked in successfully", "shiftID", createdShift.ID, "userID", *userID)
+
+	return u.toShiftResponse(createdShift), nil
+}
+
+// ClockOut closes the current staff member's shift and returns its summary
+func (u *shiftUsecase) ClockOut(ctx context.Context, req *ClockOutRequest) (*ShiftSummaryResponse, error) {
+	shift, err := u.currentShift(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Note != "" {
+		shift.Note = req.Note
+	}
+
+	return u.clockOut(ctx, shift)
+}
+
+// CloseShift clocks out another staff member's shift, e.g. when they forgot
+func (u *shiftUsecase) CloseShift(ctx context.Context, id int) (*ShiftSummaryResponse, error) {
+	shift, err := u.getShift(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.clockOut(ctx, shift)
+}
+
+// StartBreak begins a break on the current staff member's shift
+func (u *shiftUsecase) StartBreak(ctx context.Context) (*ShiftResponse, error) {
+	shift, err := u.currentShift(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shiftBreak, err := shift.StartBreak()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.shiftRepo.SaveBreak(ctx, shiftBreak); err != nil {
+		u.logger.Error("Error starting break", "error", err, "shiftID", shift.ID)
+		return nil, fmt.Errorf("failed to start break: %w", err)
+	}
+
+	u.logger.Info("Break started", "shiftID", shift.ID, "userID", shift.UserID)
+
+	return u.toShiftResponse(shift), nil
+}
+
+// EndBreak ends the break in progress on the current staff member's shift
+func (u *shiftUsecase) EndBreak(ctx context.Context) (*ShiftResponse, error) {
+	shift, err := u.currentShift(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shiftBreak, err := shift.EndBreak()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.shiftRepo.SaveBreak(ctx, shiftBreak); err != nil {
+		u.logger.Error("Error ending break", "error", err, "shiftID", shift.ID)
+		return nil, fmt.Errorf("failed to end break: %w", err)
+	}
+
+	u.logger.Info("Break ended", "shiftID", shift.ID, "userID", shift.UserID)
+
+	return u.toShiftResponse(shift), nil
+}
+
+// GetCurrentShift retrieves the current staff member's open shift
+func (u *shiftUsecase) GetCurrentShift(ctx context.Context) (*ShiftResponse, error) {
+	shift, err := u.currentShift(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toShiftResponse(shift), nil
+}
+
+// GetShift retrieves a shift by ID
+func (u *shiftUsecase) GetShift(ctx context.Context, id int) (*ShiftResponse, error) {
+	shift, err := u.getShift(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toShiftResponse(shift), nil
+}
+
+// GetShiftSummary summarises a shift; for an open shift it covers the shift so far
+func (u *shiftUsecase) GetShiftSummary(ctx context.Context, id int) (*ShiftSummaryResponse, error) {
+	shift, err := u.getShift(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.summarize(ctx, shift)
+}
+
+// ListShifts retrieves shifts matching the filter
+func (u *shiftUsecase) ListShifts(ctx context.Context, req *ShiftFilterRequest, limit, offset int) (*ShiftListResponse, error) {
+	u.logger.Debug("Listing shifts", "filter", req, "limit", limit, "offset", offset)
+
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, errs.ErrInvalidDateRange
+	}
+	filter := repository.ShiftFilter{
+		UserID: req.UserID,
+		From:   req.From,
+		To:     req.To,
+	}
+
+	shifts, err := u.shiftRepo.List(ctx, filter, limit, offset)
+	if err != nil {
+		u.logger.Error("Error listing shifts", "error", err)
+		return nil, fmt.Errorf("failed to list shifts: %w", err)
+	}
+
+	total, err := u.shiftRepo.Count(ctx, filter)
+	if err != nil {
+		u.logger.Error("Error counting shifts", "error", err)
+		return nil, fmt.Errorf("failed to count shifts: %w", err)
+	}
+
+	responses := make([]*ShiftResponse, len(shifts))
+	for i, shift := range shifts {
+		responses[i] = u.toShiftResponse(shift)
+	}
+
+	return &ShiftListResponse{
+		Shifts: responses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+func (u *shiftUsecase) clockOut(ctx context.Context, shift *entity.Shift) (*ShiftSummaryResponse, error) {
+	u.logger.Info("Clocking out", "shiftID", shift.ID, "userID", shift.UserID)
+
+	endedBreak, err := shift.ClockOut(actorIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	err = runInTransaction(ctx, u.tx, func(ctx context.Context) error {
+		if endedBreak != nil {
+			if _, err := u.shiftRepo.SaveBreak(ctx, endedBreak); err != nil {
+				return fmt.Errorf("failed to end break: %w", err)
+			}
+		}
+		if _, err := u.shiftRepo.Update(ctx, shift); err != nil {
+			return fmt.Errorf("failed to clock out: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		u.logger.Error("Error clocking out", "error", err, "shiftID", shift.ID)
+		return nil, err
+	}
+
+	u.logger.Info("Clocked out successfully", "shiftID", shift.ID, "userID", shift.UserID)
+
+	return u.summarize(ctx, shift)
+}
+
+// summarize reports the orders handled, payments taken and hours worked on a shift
+func (u *shiftUsecase) summarize(ctx context.Context, shift *entity.Shift) (*ShiftSummaryResponse, error) {
+	payments, err := u.paymentRepo.ListByShift(ctx, shift.ID)
+	if err != nil {
+		u.logger.Error("Error listing shift payments", "error", err, "shiftID", shift.ID)
+		return nil, fmt.Errorf("failed to list shift payments: %w", err)
+	}
+
+	orderIDs, err := u.orderRepo.ListIDsHandledBy(ctx, shift.UserID, shift.ClockInAt, shift.End())
+	if err != nil {
+		u.logger.Error("Error listing shift orders", "error", err, "shiftID", shift.ID)
+		return nil, fmt.Errorf("failed to list shift orders: %w", err)
+	}
+
+	ordersHandled := make(map[int]bool, len(orderIDs)+len(payments))
+	for _, id := range orderIDs {
+		ordersHandled[id] = true
+	}
+
+	var collected, refunded vo.Money
+	byMethod := make(map[vo.PaymentMethod]*ShiftPaymentMethodSummary)
+	var methods []*ShiftPaymentMethodSummary
+	for _, payment := range payments {
+		ordersHandled[payment.OrderID] = true
+
+		summary, ok := byMethod[payment.Method]
+		if !ok {
+			summary = &ShiftPaymentMethodSummary{Method: payment.Method.String()}
+			byMethod[payment.Method] = summary
+			methods = append(methods, summary)
+		}
+		summary.Count++
+
+		if payment.IsRefunded() {
+			summary.RefundedCount++
+			refunded = refunded.Add(payment.Amount)
+			continue
+		}
+		summary.Amount += payment.Amount.AmountBaht()
+		collected = collected.Add(payment.Amount)
+	}
+
+	return &ShiftSummaryResponse{
+		Shift:            u.toShiftResponse(shift),
+		HoursWorked:      shift.WorkedDuration().Hours(),
+		BreakMinutes:     int(shift.BreakDuration().Minutes()),
+		OrdersHandled:    len(ordersHandled),
+		PaymentCount:     len(payments),
+		TotalCollected:   collected.AmountBaht(),
+		RefundedAmount:   refunded.AmountBaht(),
+		PaymentsByMethod: methods,
+	}, nil
+}
+
+// currentShift returns the open shift of the staff member making the request
+func (u *shiftUsecase) currentShift(ctx context.Context) (*entity.Shift, error) {
+	userID := actorIDFromContext(ctx)
+	if userID == nil {
+		return nil, errs.ErrPermissionDenied
+	}
+
+	shift, err := u.shiftRepo.GetOpenByUser(ctx, *userID)
+	if err != nil {
+		u.logger.Error("Error getting open shift", "error", err, "userID", *userID)
+		return nil, fmt.Errorf("failed to get open shift: %w", err)
+	}
+	if shift == nil {
+		return nil, errs.ErrNoOpenShift
+	}
+	return shift, nil
+}
+
+func (u *shiftUsecase) getShift(ctx context.Context, id int) (*entity.Shift, error) {
+	shift, err := u.shiftRepo.GetByID(ctx, id)
+	if err != nil {
+		u.logger.Error("Error getting shift", "error", err, "shiftID", id)
+		return nil, fmt.Errorf("failed to get shift: %w", err)
+	}
+	if shift == nil {
+		return nil, errs.ErrShiftNotFound
+	}
+	return shift, nil
+}
+
+// toShiftResponse converts entity to response
+func (u *shiftUsecase) toShiftResponse(shift *entity.Shift) *ShiftResponse {
+	breaks := make([]*ShiftBreakResponse, len(shift.Breaks))
+	for i, b := range shift.Breaks {
+		breaks[i] = &ShiftBreakResponse{
+			ID:        b.ID,
+			StartedAt: b.StartedAt,
+			EndedAt:   b.EndedAt,
+		}
+	}
+
+	return &ShiftResponse{
+		ID:         shift.ID,
+		UserID:     shift.UserID,
+		ClockInAt:  shift.ClockInAt,
+		ClockOutAt: shift.ClockOutAt,
+		ClosedBy:   shift.ClosedBy,
+		Note:       shift.Note,
+		OnBreak:    shift.CurrentBreak() != nil,
+		Breaks:     breaks,
+	}
+}
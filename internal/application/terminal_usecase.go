@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/config"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+	"github.com/hydr0g3nz/poc_pos_restuarant/utils"
+)
+
+// terminalUsecase implements TerminalUsecase interface
+type terminalUsecase struct {
+	terminalRepo     repository.TerminalRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	logger           infra.Logger
+	config           *config.Config
+}
+
+// NewTerminalUsecase creates a new terminal usecase
+func NewTerminalUsecase(
+	terminalRepo repository.TerminalRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	logger infra.Logger,
+	config *config.Config,
+) TerminalUsecase {
+	return &terminalUsecase{
+		terminalRepo:     terminalRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		logger:           logger,
+		config:           config,
+	}
+}
+
+// RegisterTerminal registers a shared device and returns its device token
+func (u *terminalUsecase) RegisterTerminal(ctx context.Context, req *RegisterTerminalRequest) (*TerminalRegistrationResponse, error) {
+	u.logger.Info("Registering terminal", "name", req.Name)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errs.ErrInvalidTerminalName
+	}
+
+	token, err := utils.RandomHex(32)
+	if err != nil {
+		u.logger.Error("Error generating terminal token", "error", err)
+		return nil, fmt.Errorf("failed to generate terminal token: %w", err)
+	}
+
+	terminal, err := u.terminalRepo.Create(ctx, &entity.Terminal{
+		Name:      name,
+		TokenHash: utils.HashSha256([]byte(token)),
+		IsActive:  true,
+	})
+	if err != nil {
+		u.logger.Error("Error creating terminal", "error", err, "name", name)
+		return nil, fmt.Errorf("failed to create terminal: %w", err)
+	}
+
+	u.logger.Info("Terminal registered successfully", "terminalID", terminal.ID, "name", terminal.Name)
+
+	return &TerminalRegistrationResponse{
+		Terminal: u.toTerminalResponse(terminal),
+		Token:    token,
+	}, nil
+}
+
+// ListTerminals retrieves all registered terminals
+func (u *terminalUsecase) ListTerminals(ctx context.Context) ([]*TerminalResponse, error) {
+	u.logger.Debug("Listing terminals")
+
+	terminals, err := u.terminalRepo.List(ctx)
+	if err != nil {
+		u.logger.Error("Error listing terminals", "error", err)
+		return nil, fmt.Errorf("failed to list terminals: %w", err)
+	}
+
+	responses := make([]*TerminalResponse, len(terminals))
+	for i, terminal := range terminals {
+		responses[i] = u.toTerminalResponse(terminal)
+	}
+	return responses, nil
+}
+
+// DeactivateTerminal blocks PIN login on a terminal and closes its sessions
+func (u *terminalUsecase) DeactivateTerminal(ctx context.Context, id int) error {
+	u.logger.Info("Deactivating terminal", "terminalID", id)
+
+	terminal, err := u.terminalRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get terminal: %w", err)
+	}
+	if terminal == nil {
+		return errs.ErrTerminalNotFound
+	}
+
+	terminal.IsActive = false
+	if _, err := u.terminalRepo.Update(ctx, terminal); err != nil {
+		return fmt.Errorf("failed to deactivate terminal: %w", err)
+	}
+
+	if err := u.refreshTokenRepo.RevokeByTerminal(ctx, id); err != nil {
+		u.logger.Error("Error revoking terminal sessions", "error", err, "terminalID", id)
+		return fmt.Errorf("failed to revoke terminal sessions: %w", err)
+	}
+
+	u.logger.Info("Terminal deactivated successfully", "terminalID", id)
+	return nil
+}
+
+// DeleteTerminal removes a terminal and closes its sessions
+func (u *terminalUsecase) DeleteTerminal(ctx context.Context, id int) error {
+	u.logger.Info("Deleting terminal", "terminalID", id)
+
+	terminal, err := u.terminalRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get terminal: %w", err)
+	}
+	if terminal == nil {
+		return errs.ErrTerminalNotFound
+	}
+
+	if err := u.refreshTokenRepo.RevokeByTerminal(ctx, id); err != nil {
+		u.logger.Error("Error revoking terminal sessions", "error", err, "terminalID", id)
+		return fmt.Errorf("failed to revoke terminal sessions: %w", err)
+	}
+
+	if err := u.terminalRepo.Delete(ctx, id); err != nil {
+		u.logger.Error("Error deleting terminal", "error", err, "terminalID", id)
+		return fmt.Errorf("failed to delete terminal: %w", err)
+	}
+
+	u.logger.Info("Terminal deleted successfully", "terminalID", id)
+	return nil
+}
+
+// toTerminalResponse converts entity to response
+func (u *terminalUsecase) toTerminalResponse(terminal *entity.Terminal) *TerminalResponse {
+	return &TerminalResponse{
+		ID:         terminal.ID,
+		Name:       terminal.Name,
+		IsActive:   terminal.IsActive,
+		LastUsedAt: terminal.LastUsedAt,
+		CreatedAt:  terminal.CreatedAt,
+	}
+}
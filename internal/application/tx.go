@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
+)
+
+// runInTransaction runs fn in a transaction, rolling back when it fails
+func runInTransaction(ctx context.Context, tx repository.TxManager, fn func(ctx context.Context) error) error {
+	txCtx, err := tx.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(txCtx); err != nil {
+		if rollbackErr := tx.RollbackTx(txCtx); rollbackErr != nil {
+			return fmt.Errorf("transaction failed: %w, rollback failed: %w", err, rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.CommitTx(txCtx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -2,13 +2,18 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/hydr0g3nz/poc_pos_restuarant/config"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/repository"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+	"github.com/hydr0g3nz/poc_pos_restuarant/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -16,33 +21,68 @@ import (
 type UserUsecase interface {
 	Register(ctx context.Context, req *RegisterRequest) (*UserResponse, error)
 	Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error)
+	PinLogin(ctx context.Context, req *PinLoginRequest) (*LoginResponse, error)
+	SetPin(ctx context.Context, userID int, req *SetPinRequest) error
+	RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*LoginResponse, error)
+	Logout(ctx context.Context, sessionID string) error
+	Authenticate(ctx context.Context, accessToken string) (*AuthUser, error)
 	GetProfile(ctx context.Context, userID int) (*UserResponse, error)
 	UpdateProfile(ctx context.Context, userID int, req *UpdateProfileRequest) (*UserResponse, error)
 	ChangePassword(ctx context.Context, userID int, req *ChangePasswordRequest) error
-	VerifyEmail(ctx context.Context, userID int) error
+	SendEmailVerification(ctx context.Context, userID int) error
+	VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error
+	ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
 	DeactivateUser(ctx context.Context, userID int) error
 	ActivateUser(ctx context.Context, userID int) error
 	GetUsersByRole(ctx context.Context, role string, limit, offset int) ([]*UserResponse, error)
 	DeleteUser(ctx context.Context, userID int) error
+	EnsureOwner(ctx context.Context, email, password string) error
 }
 
 // userUsecase implements UserUsecase interface
 type userUsecase struct {
-	userRepo repository.UserRepository
-	logger   infra.Logger
-	config   *config.Config
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	terminalRepo     repository.TerminalRepository
+	userTokenRepo    repository.UserTokenRepository
+	tokenService     infra.TokenService
+	cache            infra.CacheService
+	rateLimiter      infra.RateLimiter
+	mailSender       infra.MailSender
+	tx               repository.TxManager
+	logger           infra.Logger
+	config           *config.Config
+	lockout          *loginLockout
 }
 
 // NewUserUsecase creates a new user usecase
 func NewUserUsecase(
 	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	terminalRepo repository.TerminalRepository,
+	userTokenRepo repository.UserTokenRepository,
+	tokenService infra.TokenService,
+	cache infra.CacheService,
+	rateLimiter infra.RateLimiter,
+	mailSender infra.MailSender,
+	tx repository.TxManager,
 	logger infra.Logger,
 	config *config.Config,
 ) UserUsecase {
 	return &userUsecase{
-		userRepo: userRepo,
-		logger:   logger,
-		config:   config,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		terminalRepo:     terminalRepo,
+		userTokenRepo:    userTokenRepo,
+		tokenService:     tokenService,
+		cache:            cache,
+		rateLimiter:      rateLimiter,
+		mailSender:       mailSender,
+		tx:               tx,
+		logger:           logger,
+		config:           config,
+		lockout:          newLoginLockout(cache, rateLimiter, logger, config),
 	}
 }
 
@@ -73,6 +113,10 @@ func (u *userUsecase) Register(ctx context.Context, req *RegisterRequest) (*User
 		u.logger.Error("Invalid user role", "error", err, "role", req.Role)
 		return nil, err
 	}
+	if err := checkCanManage(ctx, 0, role); err != nil {
+		u.logger.Warn("Registration of a senior role refused", "role", req.Role)
+		return nil, err
+	}
 
 	// Create user entity
 	user := &entity.User{
@@ -92,12 +136,22 @@ func (u *userUsecase) Register(ctx context.Context, req *RegisterRequest) (*User
 
 	u.logger.Info("User registered successfully", "userID", createdUser.ID, "email", createdUser.Email)
 
+	// Registration succeeds even if the email can't be sent; it can be resent
+	if err := u.sendEmailVerification(ctx, createdUser); err != nil {
+		u.logger.Error("Error sending verification email", "error", err, "userID", createdUser.ID)
+	}
+
 	return u.toUserResponse(createdUser), nil
 }
 
 // Login authenticates a user and updates last login
 func (u *userUsecase) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
-	u.logger.Info("User login attempt", "email", req.Email)
+	u.logger.Info("User login attempt", "email", req.Email, "ip", req.IPAddress)
+
+	// Reject attempts while the email or client is locked out
+	if err := u.checkLoginLockout(ctx, req.Email, req.IPAddress); err != nil {
+		return nil, err
+	}
 
 	// Get user by email
 	user, err := u.userRepo.GetByEmail(ctx, req.Email)
@@ -107,19 +161,22 @@ func (u *userUsecase) Login(ctx context.Context, req *LoginRequest) (*LoginRespo
 	}
 	if user == nil {
 		u.logger.Warn("User not found", "email", req.Email)
-		return nil, fmt.Errorf("invalid credentials")
-	}
-
-	// Check if user is active
-	if !user.IsActive {
-		u.logger.Warn("Inactive user login attempt", "userID", user.ID, "email", req.Email)
-		return nil, fmt.Errorf("user account is deactivated")
+		return nil, u.recordLoginFailure(ctx, req.Email, req.IPAddress)
 	}
 
 	// Verify password
 	if !u.verifyPassword(req.Password, user.PasswordHash) {
 		u.logger.Warn("Invalid password", "userID", user.ID, "email", req.Email)
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, u.recordLoginFailure(ctx, req.Email, req.IPAddress)
+	}
+
+	// A successful login clears the failures counted against the email
+	u.lockout.reset(ctx, lockoutSubject{scope: "email", value: req.Email})
+
+	// Check if user is active
+	if !user.IsActive {
+		u.logger.Warn("Inactive user login attempt", "userID", user.ID, "email", req.Email)
+		return nil, errs.ErrUserInactive
 	}
 
 	// Update last login
@@ -128,11 +185,251 @@ func (u *userUsecase) Login(ctx context.Context, req *LoginRequest) (*LoginRespo
 		// Don't fail login for this error
 	}
 
+	// Start a new session
+	response, err := u.issueTokens(ctx, user, uuid.NewString(), nil)
+	if err != nil {
+		u.logger.Error("Error issuing tokens", "error", err, "userID", user.ID)
+		return nil, err
+	}
+
 	u.logger.Info("User logged in successfully", "userID", user.ID, "email", user.Email)
 
-	return &LoginResponse{
-		User:  u.toUserResponse(user),
-		Token: "jwt_token_placeholder", // You'll need to implement JWT generation
+	return response, nil
+}
+
+// PinLogin authenticates a staff member by PIN on a registered shared terminal.
+// Any session previously open on the terminal is closed, so the PIN login
+// doubles as switching the active user.
+func (u *userUsecase) PinLogin(ctx context.Context, req *PinLoginRequest) (*LoginResponse, error) {
+	u.logger.Info("PIN login attempt", "userID", req.UserID)
+
+	terminal, err := u.terminalRepo.GetByTokenHash(ctx, utils.HashSha256([]byte(req.TerminalToken)))
+	if err != nil {
+		u.logger.Error("Error getting terminal", "error", err)
+		return nil, fmt.Errorf("failed to get terminal: %w", err)
+	}
+	if terminal == nil || !terminal.IsActive {
+		u.logger.Warn("PIN login from unknown terminal", "userID", req.UserID)
+		return nil, errs.ErrInvalidTerminal
+	}
+
+	// PINs are short, so each user's is locked out after repeated failures
+	pin := pinSubject(req.UserID, u.config)
+	if err := u.lockout.check(ctx, pin); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		u.logger.Error("Error getting user", "error", err, "userID", req.UserID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || !user.HasPin() || !u.verifyPassword(req.Pin, user.PinHash) {
+		u.logger.Warn("Invalid PIN", "userID", req.UserID, "terminalID", terminal.ID)
+		return nil, u.lockout.recordFailure(ctx, errs.ErrInvalidPin, pin)
+	}
+	u.lockout.reset(ctx, pin)
+	if !user.IsActive {
+		u.logger.Warn("Inactive user PIN login attempt", "userID", user.ID, "terminalID", terminal.ID)
+		return nil, errs.ErrUserInactive
+	}
+
+	// Close the session of whoever used the terminal before
+	if err := u.refreshTokenRepo.RevokeByTerminal(ctx, terminal.ID); err != nil {
+		u.logger.Error("Error revoking terminal sessions", "error", err, "terminalID", terminal.ID)
+		return nil, fmt.Errorf("failed to revoke terminal sessions: %w", err)
+	}
+
+	sessionID := uuid.NewString()
+	response, err := u.issueTokens(ctx, user, sessionID, &terminal.ID)
+	if err != nil {
+		u.logger.Error("Error issuing tokens", "error", err, "userID", user.ID)
+		return nil, err
+	}
+	if err := u.touchTerminalSession(ctx, sessionID, terminal.ID); err != nil {
+		u.logger.Error("Error starting terminal session", "error", err, "sessionID", sessionID)
+		return nil, err
+	}
+
+	if err := u.terminalRepo.UpdateLastUsed(ctx, terminal.ID); err != nil {
+		u.logger.Error("Error updating terminal last used", "error", err, "terminalID", terminal.ID)
+	}
+	if err := u.userRepo.UpdateLastLogin(ctx, user.ID); err != nil {
+		u.logger.Error("Error updating last login", "error", err, "userID", user.ID)
+	}
+
+	u.logger.Info("User logged in by PIN", "userID", user.ID, "terminalID", terminal.ID)
+
+	return response, nil
+}
+
+// SetPin sets the quick-login PIN of a user after re-checking the account password
+func (u *userUsecase) SetPin(ctx context.Context, userID int, req *SetPinRequest) error {
+	u.logger.Info("Setting user PIN", "userID", userID)
+
+	if !isValidPin(req.Pin) {
+		return errs.ErrInvalidPinFormat
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		u.logger.Error("Error getting user", "error", err, "userID", userID)
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return errs.ErrUserNotFound
+	}
+
+	if !u.verifyPassword(req.CurrentPassword, user.PasswordHash) {
+		u.logger.Warn("Invalid current password", "userID", userID)
+		return errs.ErrInvalidCredentials
+	}
+
+	pinHash, err := u.hashPassword(req.Pin)
+	if err != nil {
+		u.logger.Error("Error hashing PIN", "error", err, "userID", userID)
+		return fmt.Errorf("failed to hash PIN: %w", err)
+	}
+
+	user.PinHash = pinHash
+	if _, err := u.userRepo.Update(ctx, user); err != nil {
+		u.logger.Error("Error updating PIN", "error", err, "userID", userID)
+		return fmt.Errorf("failed to update PIN: %w", err)
+	}
+
+	u.logger.Info("PIN set successfully", "userID", userID)
+	return nil
+}
+
+// RefreshToken exchanges a valid refresh token for a new token pair.
+// The presented refresh token is rotated; presenting an already rotated
+// token is treated as theft and revokes the whole session.
+func (u *userUsecase) RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*LoginResponse, error) {
+	u.logger.Debug("Refreshing token")
+
+	if req.RefreshToken == "" {
+		return nil, errs.ErrMissingToken
+	}
+
+	storedToken, err := u.refreshTokenRepo.GetByTokenHash(ctx, utils.HashSha256([]byte(req.RefreshToken)))
+	if err != nil {
+		u.logger.Error("Error getting refresh token", "error", err)
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if storedToken == nil {
+		u.logger.Warn("Refresh token not found")
+		return nil, errs.ErrInvalidToken
+	}
+
+	// Reuse of a rotated token - revoke every token in the session
+	if storedToken.IsRevoked() {
+		return nil, u.revokeReusedSession(ctx, storedToken)
+	}
+	if storedToken.IsExpired() {
+		u.logger.Warn("Expired refresh token", "userID", storedToken.UserID, "sessionID", storedToken.SessionID)
+		return nil, errs.ErrInvalidToken
+	}
+
+	user, err := u.userRepo.GetByID(ctx, storedToken.UserID)
+	if err != nil {
+		u.logger.Error("Error getting user", "error", err, "userID", storedToken.UserID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		u.logger.Warn("Refresh for unavailable user", "userID", storedToken.UserID)
+		if err := u.refreshTokenRepo.RevokeAllByUser(ctx, storedToken.UserID); err != nil {
+			u.logger.Error("Error revoking user tokens", "error", err, "userID", storedToken.UserID)
+		}
+		return nil, errs.ErrUserInactive
+	}
+
+	// Terminal sessions cannot be refreshed once they have locked
+	if storedToken.TerminalID != nil {
+		if err := u.checkTerminalSession(ctx, storedToken.SessionID, *storedToken.TerminalID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rotate: revoke the presented token and issue a new pair in the same session.
+	// Losing the race to revoke it means the token was presented twice
+	if err := u.refreshTokenRepo.Revoke(ctx, storedToken.ID); err != nil {
+		if errors.Is(err, errs.ErrTokenRevoked) {
+			return nil, u.revokeReusedSession(ctx, storedToken)
+		}
+		u.logger.Error("Error revoking refresh token", "error", err, "tokenID", storedToken.ID)
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	response, err := u.issueTokens(ctx, user, storedToken.SessionID, storedToken.TerminalID)
+	if err != nil {
+		u.logger.Error("Error issuing tokens", "error", err, "userID", user.ID)
+		return nil, err
+	}
+
+	u.logger.Info("Token refreshed successfully", "userID", user.ID, "sessionID", storedToken.SessionID)
+
+	return response, nil
+}
+
+// revokeReusedSession treats a refresh token presented after it was rotated
+// as stolen and revokes every token in its session
+func (u *userUsecase) revokeReusedSession(ctx context.Context, token *entity.RefreshToken) error {
+	u.logger.Warn("Revoked refresh token reused", "userID", token.UserID, "sessionID", token.SessionID)
+	if err := u.refreshTokenRepo.RevokeBySession(ctx, token.SessionID); err != nil {
+		u.logger.Error("Error revoking session", "error", err, "sessionID", token.SessionID)
+	}
+	return errs.ErrTokenRevoked
+}
+
+// Logout revokes all refresh tokens of the session, invalidating its access tokens
+func (u *userUsecase) Logout(ctx context.Context, sessionID string) error {
+	u.logger.Info("User logout", "sessionID", sessionID)
+
+	if err := u.refreshTokenRepo.RevokeBySession(ctx, sessionID); err != nil {
+		u.logger.Error("Error revoking session", "error", err, "sessionID", sessionID)
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if err := u.cache.Delete(ctx, terminalSessionKey(sessionID)); err != nil {
+		u.logger.Error("Error clearing terminal session", "error", err, "sessionID", sessionID)
+	}
+
+	u.logger.Info("User logged out successfully", "sessionID", sessionID)
+	return nil
+}
+
+// Authenticate validates an access token and checks that its session has not been revoked
+func (u *userUsecase) Authenticate(ctx context.Context, accessToken string) (*AuthUser, error) {
+	if accessToken == "" {
+		return nil, errs.ErrMissingToken
+	}
+
+	claims, err := u.tokenService.ParseAccessToken(accessToken)
+	if err != nil {
+		u.logger.Debug("Invalid access token", "error", err)
+		return nil, errs.ErrInvalidToken
+	}
+
+	active, err := u.refreshTokenRepo.HasActiveSession(ctx, claims.SessionID)
+	if err != nil {
+		u.logger.Error("Error checking session", "error", err, "sessionID", claims.SessionID)
+		return nil, fmt.Errorf("failed to check session: %w", err)
+	}
+	if !active {
+		u.logger.Warn("Access token for revoked session", "userID", claims.UserID, "sessionID", claims.SessionID)
+		return nil, errs.ErrTokenRevoked
+	}
+
+	if claims.TerminalID != 0 {
+		if err := u.checkTerminalSession(ctx, claims.SessionID, claims.TerminalID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &AuthUser{
+		UserID:     claims.UserID,
+		Role:       claims.Role,
+		SessionID:  claims.SessionID,
+		TerminalID: claims.TerminalID,
 	}, nil
 }
 
@@ -166,9 +463,13 @@ func (u *userUsecase) UpdateProfile(ctx context.Context, userID int, req *Update
 	if currentUser == nil {
 		return nil, fmt.Errorf("user not found")
 	}
+	if err := checkCanManage(ctx, currentUser.ID, currentUser.Role); err != nil {
+		return nil, err
+	}
 
 	// Check if email is being changed and if it's unique
-	if req.Email != "" && req.Email != currentUser.Email {
+	emailChanged := req.Email != "" && req.Email != currentUser.Email
+	if emailChanged {
 		existingUser, err := u.userRepo.GetByEmail(ctx, req.Email)
 		if err != nil {
 			u.logger.Error("Error checking email uniqueness", "error", err, "email", req.Email)
@@ -179,6 +480,14 @@ func (u *userUsecase) UpdateProfile(ctx context.Context, userID int, req *Update
 		}
 		currentUser.Email = req.Email
 		currentUser.EmailVerified = false // Reset verification if email changed
+
+		// Links mailed to the old address must not verify the new one or reset the password
+		for _, purpose := range []vo.TokenPurpose{vo.TokenPurposeEmailVerification, vo.TokenPurposePasswordReset} {
+			if err := u.userTokenRepo.InvalidateByUser(ctx, userID, purpose); err != nil {
+				u.logger.Error("Error invalidating user tokens", "error", err, "userID", userID, "purpose", purpose)
+				return nil, fmt.Errorf("failed to invalidate user tokens: %w", err)
+			}
+		}
 	}
 
 	// Update user
@@ -188,6 +497,13 @@ func (u *userUsecase) UpdateProfile(ctx context.Context, userID int, req *Update
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
+	// The new address has to be verified; the change stands even if the email can't be sent
+	if emailChanged {
+		if err := u.sendEmailVerification(ctx, updatedUser); err != nil {
+			u.logger.Error("Error sending verification email", "error", err, "userID", userID)
+		}
+	}
+
 	u.logger.Info("User profile updated successfully", "userID", userID)
 
 	return u.toUserResponse(updatedUser), nil
@@ -206,6 +522,9 @@ func (u *userUsecase) ChangePassword(ctx context.Context, userID int, req *Chang
 	if user == nil {
 		return fmt.Errorf("user not found")
 	}
+	if err := checkCanManage(ctx, user.ID, user.Role); err != nil {
+		return err
+	}
 
 	// Verify current password
 	if !u.verifyPassword(req.CurrentPassword, user.PasswordHash) {
@@ -232,28 +551,152 @@ func (u *userUsecase) ChangePassword(ctx context.Context, userID int, req *Chang
 	return nil
 }
 
-// VerifyEmail marks user email as verified
-func (u *userUsecase) VerifyEmail(ctx context.Context, userID int) error {
-	u.logger.Info("Verifying user email", "userID", userID)
+// SendEmailVerification mails the user a link to verify their email
+func (u *userUsecase) SendEmailVerification(ctx context.Context, userID int) error {
+	u.logger.Info("Sending email verification", "userID", userID)
 
 	user, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return fmt.Errorf("user not found")
+		return errs.ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return errs.ErrEmailAlreadyVerified
 	}
 
-	user.EmailVerified = true
-	_, err = u.userRepo.Update(ctx, user)
+	return u.sendEmailVerification(ctx, user)
+}
+
+// VerifyEmail marks the user's email as verified with a link from SendEmailVerification
+func (u *userUsecase) VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error {
+	var userID int
+	err := runInTransaction(ctx, u.tx, func(ctx context.Context) error {
+		token, err := u.redeemUserToken(ctx, req.Token, vo.TokenPurposeEmailVerification)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return errs.ErrInvalidEmailToken
+		}
+		userID = token.UserID
+
+		user, err := u.userRepo.GetByID(ctx, token.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil {
+			return errs.ErrInvalidEmailToken
+		}
+
+		user.EmailVerified = true
+		if _, err := u.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to verify email: %w", err)
+		u.logger.Warn("Email verification failed", "error", err)
+		return err
 	}
 
 	u.logger.Info("Email verified successfully", "userID", userID)
 	return nil
 }
 
+// ForgotPassword mails a password reset link. It succeeds for unknown emails
+// too, so the response doesn't reveal which emails have accounts.
+func (u *userUsecase) ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) error {
+	u.logger.Info("Password reset requested", "email", req.Email)
+
+	user, err := u.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		u.logger.Error("Error getting user", "error", err, "email", req.Email)
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		u.logger.Warn("Password reset for unknown or inactive user", "email", req.Email)
+		return nil
+	}
+
+	ttl := time.Duration(u.config.App.PasswordResetExpiration) * time.Minute
+	rawToken, err := u.issueUserToken(ctx, user.ID, vo.TokenPurposePasswordReset, ttl)
+	if err != nil {
+		u.logger.Error("Error issuing password reset token", "error", err, "userID", user.ID)
+		return err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", u.config.App.FrontendURL, rawToken)
+	if err := u.mailSender.Send(ctx, &infra.MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use the link below to set a new password. It expires in %d minutes and works once.\n\n%s\n\n"+
+			"If you didn't ask for this, you can ignore this email.\n", u.config.App.PasswordResetExpiration, link),
+	}); err != nil {
+		u.logger.Error("Error sending password reset email", "error", err, "userID", user.ID)
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	u.logger.Info("Password reset email sent", "userID", user.ID)
+	return nil
+}
+
+// ResetPassword sets a new password with a link from ForgotPassword and signs
+// the user out of every session
+func (u *userUsecase) ResetPassword(ctx context.Context, req *ResetPasswordRequest) error {
+	if len(req.NewPassword) < 8 {
+		return errs.ErrInvalidPassword
+	}
+
+	hashedPassword, err := u.hashPassword(req.NewPassword)
+	if err != nil {
+		u.logger.Error("Error hashing new password", "error", err)
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	var userID int
+	err = runInTransaction(ctx, u.tx, func(ctx context.Context) error {
+		token, err := u.redeemUserToken(ctx, req.Token, vo.TokenPurposePasswordReset)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return errs.ErrInvalidResetToken
+		}
+		userID = token.UserID
+
+		user, err := u.userRepo.GetByID(ctx, token.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil || !user.IsActive {
+			return errs.ErrInvalidResetToken
+		}
+
+		user.PasswordHash = hashedPassword
+		if _, err := u.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to update password: %w", err)
+		}
+
+		// Other reset links die with this one, and every session signs out
+		if err := u.userTokenRepo.InvalidateByUser(ctx, user.ID, vo.TokenPurposePasswordReset); err != nil {
+			return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+		}
+		if err := u.refreshTokenRepo.RevokeAllByUser(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		u.logger.Warn("Password reset failed", "error", err)
+		return err
+	}
+
+	u.logger.Info("Password reset successfully", "userID", userID)
+	return nil
+}
+
 // DeactivateUser deactivates a user account
 func (u *userUsecase) DeactivateUser(ctx context.Context, userID int) error {
 	u.logger.Info("Deactivating user", "userID", userID)
@@ -265,6 +708,9 @@ func (u *userUsecase) DeactivateUser(ctx context.Context, userID int) error {
 	if user == nil {
 		return fmt.Errorf("user not found")
 	}
+	if err := checkCanManage(ctx, user.ID, user.Role); err != nil {
+		return err
+	}
 
 	user.IsActive = false
 	_, err = u.userRepo.Update(ctx, user)
@@ -272,6 +718,12 @@ func (u *userUsecase) DeactivateUser(ctx context.Context, userID int) error {
 		return fmt.Errorf("failed to deactivate user: %w", err)
 	}
 
+	// Sign the user out everywhere
+	if err := u.refreshTokenRepo.RevokeAllByUser(ctx, userID); err != nil {
+		u.logger.Error("Error revoking user tokens", "error", err, "userID", userID)
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+
 	u.logger.Info("User deactivated successfully", "userID", userID)
 	return nil
 }
@@ -287,6 +739,9 @@ func (u *userUsecase) ActivateUser(ctx context.Context, userID int) error {
 	if user == nil {
 		return fmt.Errorf("user not found")
 	}
+	if err := checkCanManage(ctx, user.ID, user.Role); err != nil {
+		return err
+	}
 
 	user.IsActive = true
 	_, err = u.userRepo.Update(ctx, user)
@@ -323,6 +778,9 @@ func (u *userUsecase) DeleteUser(ctx context.Context, userID int) error {
 	if user == nil {
 		return fmt.Errorf("user not found")
 	}
+	if err := checkCanManage(ctx, user.ID, user.Role); err != nil {
+		return err
+	}
 
 	// Delete user
 	if err := u.userRepo.Delete(ctx, userID); err != nil {
@@ -334,8 +792,226 @@ func (u *userUsecase) DeleteUser(ctx context.Context, userID int) error {
 	return nil
 }
 
+// EnsureOwner creates the bootstrap owner account if it does not exist yet
+func (u *userUsecase) EnsureOwner(ctx context.Context, email, password string) error {
+	existingUser, err := u.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to check existing user: %w", err)
+	}
+	if existingUser != nil {
+		return nil
+	}
+
+	_, err = u.Register(ctx, &RegisterRequest{
+		Email:    email,
+		Password: password,
+		Role:     vo.RoleOwner.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	u.logger.Info("Bootstrap owner account created", "email", email)
+	return nil
+}
+
 // Helper methods
 
+// checkCanManage refuses to let staff administer an account whose role is not
+// below their own, so no one can create or take over a more senior account.
+// Staff may manage their own account, and calls made outside a request, such
+// as the bootstrap owner, are not restricted.
+func checkCanManage(ctx context.Context, userID int, role vo.UserRole) error {
+	actorID := actorIDFromContext(ctx)
+	if actorID == nil || (userID > 0 && *actorID == userID) {
+		return nil
+	}
+	if !actorRoleFromContext(ctx).Outranks(role) {
+		return errs.ErrCannotManageRole
+	}
+	return nil
+}
+
+// issueTokens creates a signed access token and a persisted refresh token for the session
+func (u *userUsecase) issueTokens(ctx context.Context, user *entity.User, sessionID string, terminalID *int) (*LoginResponse, error) {
+	claims := &infra.TokenClaims{
+		UserID:    user.ID,
+		Role:      user.Role.String(),
+		SessionID: sessionID,
+	}
+	if terminalID != nil {
+		claims.TerminalID = *terminalID
+	}
+
+	accessToken, accessExpiresAt, err := u.tokenService.GenerateAccessToken(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	rawRefreshToken, err := utils.RandomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	refreshToken, err := u.refreshTokenRepo.Create(ctx, &entity.RefreshToken{
+		UserID:     user.ID,
+		SessionID:  sessionID,
+		TerminalID: terminalID,
+		TokenHash:  utils.HashSha256([]byte(rawRefreshToken)),
+		ExpiresAt:  time.Now().Add(time.Duration(u.config.JWT.RefreshExpiration) * time.Hour),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return &LoginResponse{
+		User:                  u.toUserResponse(user),
+		Token:                 accessToken,
+		TokenType:             "Bearer",
+		ExpiresAt:             accessExpiresAt,
+		RefreshToken:          rawRefreshToken,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+	}, nil
+}
+
+// sendEmailVerification mails the user a fresh email verification link
+func (u *userUsecase) sendEmailVerification(ctx context.Context, user *entity.User) error {
+	ttl := time.Duration(u.config.App.EmailVerifyExpiration) * time.Hour
+	rawToken, err := u.issueUserToken(ctx, user.ID, vo.TokenPurposeEmailVerification, ttl)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", u.config.App.FrontendURL, rawToken)
+	if err := u.mailSender.Send(ctx, &infra.MailMessage{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body:    fmt.Sprintf("Use the link below to verify your email. It expires in %d hours.\n\n%s\n", u.config.App.EmailVerifyExpiration, link),
+	}); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	u.logger.Info("Verification email sent", "userID", user.ID)
+	return nil
+}
+
+// issueUserToken creates a single-use token for the purpose, invalidating the
+// user's earlier ones, and returns the raw token to mail
+func (u *userUsecase) issueUserToken(ctx context.Context, userID int, purpose vo.TokenPurpose, ttl time.Duration) (string, error) {
+	if err := u.userTokenRepo.InvalidateByUser(ctx, userID, purpose); err != nil {
+		return "", fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+
+	rawToken, err := utils.RandomHex(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	if _, err := u.userTokenRepo.Create(ctx, &entity.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: utils.HashSha256([]byte(rawToken)),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", fmt.Errorf("failed to save token: %w", err)
+	}
+
+	return rawToken, nil
+}
+
+// redeemUserToken marks a usable token as used and returns it, or nil when
+// the token is unknown, expired, already used or for another purpose
+func (u *userUsecase) redeemUserToken(ctx context.Context, rawToken string, purpose vo.TokenPurpose) (*entity.UserToken, error) {
+	if rawToken == "" {
+		return nil, nil
+	}
+
+	token, err := u.userTokenRepo.GetByTokenHash(ctx, utils.HashSha256([]byte(rawToken)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if token == nil || !token.IsUsable(purpose) {
+		return nil, nil
+	}
+
+	// Only one of concurrent requests with the same token gets to use it
+	used, err := u.userTokenRepo.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use token: %w", err)
+	}
+	if !used {
+		return nil, nil
+	}
+
+	return token, nil
+}
+
+// checkTerminalSession locks a terminal session that has been idle for longer
+// than the configured time and otherwise extends it
+func (u *userUsecase) checkTerminalSession(ctx context.Context, sessionID string, terminalID int) error {
+	active, err := u.cache.Exists(ctx, terminalSessionKey(sessionID))
+	if err != nil {
+		u.logger.Error("Error checking terminal session", "error", err, "sessionID", sessionID)
+		return fmt.Errorf("failed to check terminal session: %w", err)
+	}
+	if !active {
+		u.logger.Info("Terminal session locked", "sessionID", sessionID, "terminalID", terminalID)
+		if err := u.refreshTokenRepo.RevokeBySession(ctx, sessionID); err != nil {
+			u.logger.Error("Error revoking session", "error", err, "sessionID", sessionID)
+		}
+		return errs.ErrSessionLocked
+	}
+	return u.touchTerminalSession(ctx, sessionID, terminalID)
+}
+
+// touchTerminalSession records activity on a terminal session
+func (u *userUsecase) touchTerminalSession(ctx context.Context, sessionID string, terminalID int) error {
+	idle := time.Duration(u.config.App.TerminalIdleLock) * time.Minute
+	if err := u.cache.Set(ctx, terminalSessionKey(sessionID), terminalID, idle); err != nil {
+		return fmt.Errorf("failed to update terminal session: %w", err)
+	}
+	return nil
+}
+
+// checkLoginLockout rejects logins for an email or client IP that is locked
+// out after repeated failures
+func (u *userUsecase) checkLoginLockout(ctx context.Context, email, ip string) error {
+	return u.lockout.check(ctx, u.loginSubjects(email, ip)...)
+}
+
+// recordLoginFailure counts a failed login against the email and the client
+// IP and starts a lockout once either reaches its limit within the window.
+// It returns the error to report for the failed attempt.
+func (u *userUsecase) recordLoginFailure(ctx context.Context, email, ip string) error {
+	return u.lockout.recordFailure(ctx, errs.ErrInvalidCredentials, u.loginSubjects(email, ip)...)
+}
+
+// loginSubjects are what failed password logins are counted against
+func (u *userUsecase) loginSubjects(email, ip string) []lockoutSubject {
+	return []lockoutSubject{
+		{"email", email, u.config.RateLimit.LoginMaxAttempts},
+		{"ip", ip, u.config.RateLimit.LoginIPMaxAttempts},
+	}
+}
+
+// terminalSessionKey is the cache key tracking activity of a terminal session
+func terminalSessionKey(sessionID string) string {
+	return "terminal_session:" + sessionID
+}
+
+// isValidPin checks that a PIN consists of 4 to 6 digits
+func isValidPin(pin string) bool {
+	if len(pin) < 4 || len(pin) > 6 {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // hashPassword hashes a password using bcrypt
 func (u *userUsecase) hashPassword(password string) (string, error) {
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
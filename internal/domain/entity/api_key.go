@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// APIKey lets an integration such as a kitchen display or the printer bridge
+// call the API without a staff login. Its scopes are the permissions guarding
+// the route groups it may use. Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Prefix     string          `json:"prefix"` // first characters of the key, to tell keys apart
+	KeyHash    string          `json:"-"`
+	Scopes     []vo.Permission `json:"scopes"`
+	CreatedBy  *int            `json:"created_by,omitempty"`
+	LastUsedAt *time.Time      `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time      `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time      `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// IsRevoked checks if the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired checks if the key has expired
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt)
+}
+
+// IsUsable checks if the key is accepted for authentication
+func (k *APIKey) IsUsable() bool {
+	return !k.IsRevoked() && !k.IsExpired()
+}
+
+// HasScope reports whether the key is granted the permission
+func (k *APIKey) HasScope(permission vo.Permission) bool {
+	for _, scope := range k.Scopes {
+		if scope == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Revoke stops the key from being accepted
+func (k *APIKey) Revoke() {
+	now := time.Now()
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
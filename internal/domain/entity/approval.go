@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// Approval is a manager's sign-off for one sensitive change. It is either
+// requested ahead and granted from a manager's device, or granted on the
+// spot with the manager's PIN, and is used exactly once.
+type Approval struct {
+	ID          int               `json:"id"`
+	Action      vo.ApprovalAction `json:"action"`
+	Status      vo.ApprovalStatus `json:"status"`
+	OrderID     int               `json:"order_id"`
+	OrderItemID *int              `json:"order_item_id,omitempty"`
+	PaymentID   *int              `json:"payment_id,omitempty"`
+	ReasonCode  string            `json:"reason_code"`
+	Note        string            `json:"note,omitempty"`
+	RequestedBy *int              `json:"requested_by,omitempty"`
+	DecidedBy   *int              `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time        `json:"decided_at,omitempty"`
+	UsedAt      *time.Time        `json:"used_at,omitempty"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// ApprovalTarget identifies the change an approval is for
+type ApprovalTarget struct {
+	Action      vo.ApprovalAction
+	OrderID     int
+	OrderItemID *int
+	PaymentID   *int
+}
+
+// NewApproval creates a pending approval request
+func NewApproval(target ApprovalTarget, reasonCode, note string, requestedBy *int, ttl time.Duration) (*Approval, error) {
+	if !target.Action.IsValid() {
+		return nil, errs.ErrInvalidApprovalAction
+	}
+	reasonCode = strings.TrimSpace(reasonCode)
+	if reasonCode == "" {
+		return nil, errs.ErrInvalidReasonCode
+	}
+
+	now := time.Now()
+	return &Approval{
+		Action:      target.Action,
+		Status:      vo.ApprovalStatusPending,
+		OrderID:     target.OrderID,
+		OrderItemID: target.OrderItemID,
+		PaymentID:   target.PaymentID,
+		ReasonCode:  reasonCode,
+		Note:        note,
+		RequestedBy: requestedBy,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// IsExpired checks if the approval can no longer be decided or used
+func (a *Approval) IsExpired() bool {
+	return time.Now().After(a.ExpiresAt)
+}
+
+// Matches checks if the approval was granted for the given change
+func (a *Approval) Matches(target ApprovalTarget) bool {
+	return a.Action == target.Action &&
+		a.OrderID == target.OrderID &&
+		sameID(a.OrderItemID, target.OrderItemID) &&
+		sameID(a.PaymentID, target.PaymentID)
+}
+
+// Approve grants a pending approval
+func (a *Approval) Approve(managerID int) error {
+	if err := a.decide(); err != nil {
+		return err
+	}
+	a.Status = vo.ApprovalStatusApproved
+	a.DecidedBy = &managerID
+	return nil
+}
+
+// Reject declines a pending approval
+func (a *Approval) Reject(managerID int) error {
+	if err := a.decide(); err != nil {
+		return err
+	}
+	a.Status = vo.ApprovalStatusRejected
+	a.DecidedBy = &managerID
+	return nil
+}
+
+// Use consumes an approved approval for the change it was granted for
+func (a *Approval) Use(target ApprovalTarget) error {
+	if a.Status != vo.ApprovalStatusApproved {
+		return errs.ErrApprovalNotGranted
+	}
+	if a.IsExpired() {
+		return errs.ErrApprovalExpired
+	}
+	if !a.Matches(target) {
+		return errs.ErrApprovalMismatch
+	}
+
+	now := time.Now()
+	a.Status = vo.ApprovalStatusUsed
+	a.UsedAt = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+func (a *Approval) decide() error {
+	if a.Status != vo.ApprovalStatusPending {
+		return errs.ErrApprovalAlreadyDecided
+	}
+	if a.IsExpired() {
+		return errs.ErrApprovalExpired
+	}
+
+	now := time.Now()
+	a.DecidedAt = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+func sameID(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
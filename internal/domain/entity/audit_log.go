@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// Entity types referenced by audit log entries
+const (
+	AuditEntityOrder     = "order"
+	AuditEntityOrderItem = "order_item"
+	AuditEntityMenuItem  = "menu_item"
+	AuditEntityPayment   = "payment"
+)
+
+// AuditLog is an append-only record of a sensitive action
+type AuditLog struct {
+	ID         int             `json:"id"`
+	ActorID    *int            `json:"actor_id,omitempty"` // nil for customer or system actions
+	Action     vo.AuditAction  `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   int             `json:"entity_id"`
+	ApprovalID *int            `json:"approval_id,omitempty"` // manager approval the change was made under
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// NewAuditLog creates an audit log entry with before/after snapshots
+// serialized as JSON; a nil snapshot is left empty
+func NewAuditLog(actorID *int, action vo.AuditAction, entityType string, entityID int, before, after any) (*AuditLog, error) {
+	beforeJSON, err := marshalSnapshot(before)
+	if err != nil {
+		return nil, err
+	}
+	afterJSON, err := marshalSnapshot(after)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditLog{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func marshalSnapshot(snapshot any) (json.RawMessage, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit snapshot: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// BillShare is one person's part of a split bill, to be settled by one payment
+type BillShare struct {
+	Amount       vo.Money
+	OrderItemIDs []int // items the share pays for, when splitting by item
+}
+
+// SplitEvenly divides an amount into parts that differ by at most one satang,
+// with the leftover satang going to the first shares
+func SplitEvenly(amount vo.Money, parts int) ([]*BillShare, error) {
+	if parts < 2 {
+		return nil, errs.ErrInvalidSplitParts
+	}
+
+	total := amount.AmountSatang()
+	base, remainder := total/int64(parts), total%int64(parts)
+	shares := make([]*BillShare, parts)
+	for i := range shares {
+		satang := base
+		if int64(i) < remainder {
+			satang++
+		}
+		share, err := vo.NewMoneyFromSatang(satang)
+		if err != nil {
+			return nil, err
+		}
+		shares[i] = &BillShare{Amount: share}
+	}
+	return shares, nil
+}
+
+// SettledAmount sums the payments that have not been refunded
+func SettledAmount(payments []*Payment) vo.Money {
+	settled, _ := vo.NewMoneyFromSatang(0)
+	for _, payment := range payments {
+		if !payment.IsRefunded() {
+			settled = settled.Add(payment.Amount)
+		}
+	}
+	return settled
+}
+
+// PaidItemIDs returns the order items already covered by payments that have
+// not been refunded
+func PaidItemIDs(payments []*Payment) map[int]bool {
+	paid := make(map[int]bool)
+	for _, payment := range payments {
+		if payment.IsRefunded() {
+			continue
+		}
+		for _, itemID := range payment.OrderItemIDs {
+			paid[itemID] = true
+		}
+	}
+	return paid
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"time"
 
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
@@ -52,6 +53,27 @@ func NewMenuItem(categoryID int, name, description string, price float64) (*Menu
 	}, nil
 }
 
+// SetMarkdown sets the discount percentage and whether the item is on discount
+func (m *MenuItem) SetMarkdown(discountPercent float64, isDiscounted bool) error {
+	if discountPercent < 0 || discountPercent > 100 {
+		return errs.ErrInvalidMarkdown
+	}
+
+	m.DiscountPercent = discountPercent
+	m.IsDiscounted = isDiscounted
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkdownPercent returns the discount percentage taken off the menu price
+// while the item is on discount
+func (m *MenuItem) MarkdownPercent() float64 {
+	if !m.IsDiscounted || m.DiscountPercent <= 0 || m.DiscountPercent > 100 {
+		return 0
+	}
+	return m.DiscountPercent
+}
+
 // Activate activates the menu item
 func (m *MenuItem) Activate() {
 	m.IsActive = true
@@ -3,6 +3,7 @@ package entity
 import (
 	"time"
 
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
@@ -10,7 +11,12 @@ import (
 type Order struct {
 	ID                  int              `json:"id"`
 	OrderNumber         int              `json:"order_number"`
-	TableID             int              `json:"table_id"`
+	TableID             int              `json:"table_id"` // zero for orders without a table
+	OrderType           vo.OrderType     `json:"order_type"`
+	CustomerName        string           `json:"customer_name,omitempty"`
+	CustomerPhone       string           `json:"customer_phone,omitempty"`
+	DeliveryAddress     string           `json:"delivery_address,omitempty"`
+	QueueNumber         int              `json:"queue_number,omitempty"` // daily pickup/queue number for orders without a table
 	OrderStatus         vo.OrderStatus   `json:"status"`
 	PaymentStatus       vo.PaymentStatus `json:"payment_status"`
 	QRCode              string           `json:"qr_code,omitempty"` // QR code for the order
@@ -24,22 +30,72 @@ type Order struct {
 	TaxAmount           vo.Money         `json:"tax_amount,omitempty"`       // calculated tax for the order
 	ServiceCharge       vo.Money         `json:"service_charge,omitempty"`   // calculated service charge for the order
 	Total               vo.Money         `json:"total,omitempty"`            // calculated total for the order
+	PaidAmount          vo.Money         `json:"paid_amount,omitempty"`      // payments taken so far, less refunds
+	CouponCode          string           `json:"coupon_code,omitempty"`      // promo code given for the order
+	CreatedBy           *int             `json:"created_by,omitempty"`       // staff member who opened the order
+	UpdatedBy           *int             `json:"updated_by,omitempty"`       // staff member who last changed the order
+	ClosedBy            *int             `json:"closed_by,omitempty"`        // staff member who closed the order
+	MergedIntoID        *int             `json:"merged_into_id,omitempty"`   // order that absorbed this one
+	RoundCount          int              `json:"round_count"`                // rounds submitted to the kitchen so far
+	GuestCount          int              `json:"guest_count,omitempty"`      // covers seated; 0 when not recorded
+	Version             int              `json:"version"`                    // bumped on every save, for optimistic locking
 	// extension for order items
-	Items []*OrderItem `json:"items,omitempty"`
+	Items      []*OrderItem       `json:"items,omitempty"`
+	Promotions []AppliedPromotion `json:"promotions,omitempty"` // promotion lines of the priced bill
+}
+
+// CheckVersion checks the order is still at the version a client last saw.
+// A nil version skips the check.
+func (o *Order) CheckVersion(expected *int) error {
+	if expected != nil && *expected != o.Version {
+		return errs.ErrOrderVersionConflict
+	}
+	return nil
 }
 
 // IsValid validates order data
 func (o *Order) IsValid() bool {
-	return o.TableID > 0 && o.OrderStatus.IsValid()
+	if o.OrderType.RequiresTable() && o.TableID <= 0 {
+		return false
+	}
+	return o.OrderType.IsValid() && o.OrderStatus.IsValid()
 }
 
-// NewOrder creates a new order
+// NewOrder creates a new dine-in order
 func NewOrder(tableID int) (*Order, error) {
+	return NewOrderOfType(vo.OrderTypeDineIn, tableID, CustomerInfo{})
+}
+
+// CustomerInfo identifies the customer of an order without a table
+type CustomerInfo struct {
+	Name            string
+	Phone           string
+	DeliveryAddress string
+}
+
+// NewOrderOfType creates a new order of the given type. Dine-in orders need a
+// table; the others need a customer to call, and deliveries an address.
+func NewOrderOfType(orderType vo.OrderType, tableID int, customer CustomerInfo) (*Order, error) {
+	switch {
+	case !orderType.IsValid():
+		return nil, errs.ErrInvalidOrderType
+	case orderType.RequiresTable() && tableID <= 0:
+		return nil, errs.ErrTableRequired
+	case !orderType.RequiresTable() && customer.Name == "" && customer.Phone == "":
+		return nil, errs.ErrCustomerContactRequired
+	case orderType == vo.OrderTypeDelivery && customer.DeliveryAddress == "":
+		return nil, errs.ErrDeliveryAddressRequired
+	}
+
 	return &Order{
-		TableID:     tableID,
-		OrderStatus: vo.OrderStatusOpen,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		TableID:         tableID,
+		OrderType:       orderType,
+		CustomerName:    customer.Name,
+		CustomerPhone:   customer.Phone,
+		DeliveryAddress: customer.DeliveryAddress,
+		OrderStatus:     vo.OrderStatusOpen,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
 	}, nil
 }
 
@@ -53,9 +109,197 @@ func (o *Order) IsClosed() bool {
 	return o.OrderStatus == vo.OrderStatusCompleted
 }
 
-// Close closes the order
-func (o *Order) Close() {
-	o.OrderStatus = vo.OrderStatusCompleted
+// InService checks if the order is still being served, i.e. neither closed nor cancelled
+func (o *Order) InService() bool {
+	return o.OrderStatus != vo.OrderStatusCompleted && o.OrderStatus != vo.OrderCancelled
+}
+
+// SetGuestCount records how many guests the order is for. More guests than
+// the table seats, when its seating is known, need an override.
+func (o *Order) SetGuestCount(count, seating int, override bool) error {
+	if count < 0 {
+		return errs.ErrInvalidGuestCount
+	}
+	if seating > 0 && count > seating && !override {
+		return errs.ErrGuestCountExceedsSeating.WithDetails(map[string]interface{}{
+			"guest_count": count,
+			"seating":     seating,
+		})
+	}
+	o.GuestCount = count
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// CheckSeat checks a seat number fits the order: 0 for shared items, or a
+// seat no higher than the guest count when covers were recorded
+func (o *Order) CheckSeat(seat int) error {
+	if seat < 0 || (o.GuestCount > 0 && seat > o.GuestCount) {
+		return errs.ErrInvalidSeat
+	}
+	return nil
+}
+
+// TransferTo moves the order to another table. A takeaway customer who
+// sits down becomes a dine-in order; delivery and pickup orders are not
+// eaten in and cannot be moved to a table.
+func (o *Order) TransferTo(tableID int) error {
+	switch o.OrderType {
+	case vo.OrderTypeDelivery, vo.OrderTypePickup:
+		return errs.ErrOrderTypeNotSeatable
+	case vo.OrderTypeTakeaway:
+		o.OrderType = vo.OrderTypeDineIn
+	}
+	o.TableID = tableID
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// TransitionTo moves the order to the next status if the lifecycle allows it
+// and returns the change to record in the status history. Completing the
+// order checks its items, so they must be loaded.
+func (o *Order) TransitionTo(next vo.OrderStatus, by *int) (*OrderStatusChange, error) {
+	if !o.OrderStatus.CanTransitionTo(next) {
+		return nil, errs.ErrInvalidOrderTransitionWithStatus(o.OrderStatus.String(), next.String())
+	}
+
+	switch next {
+	case vo.OrderStatusCompleted:
+		for _, item := range o.Items {
+			if item.ItemStatus != vo.ItemStatusServed && item.ItemStatus != vo.ItemStatusCancelled {
+				return nil, errs.ErrOrderHasUnservedItems.WithField("order_item_id", item.ID)
+			}
+		}
+	case vo.OrderCancelled:
+		if !o.PaidAmount.IsZero() {
+			return nil, errs.ErrCannotCancelPaidOrder
+		}
+	}
+
+	now := time.Now()
+	change := NewOrderStatusChange(o.ID, o.OrderStatus, next, by)
+
+	switch {
+	case next == vo.OrderStatusCompleted:
+		o.ClosedAt = &now
+		o.ClosedBy = by
+	case o.OrderStatus == vo.OrderStatusCompleted:
+		o.ClosedAt = nil
+		o.ClosedBy = nil
+		if o.PaymentStatus == vo.PaymentStatusPaid {
+			// more may be ordered, so the bill is no longer settled
+			o.PaymentStatus = vo.PaymentStatusPartial
+		}
+	}
+
+	o.OrderStatus = next
+	o.UpdatedBy = by
+	o.UpdatedAt = now
+	return change, nil
+}
+
+// StartRound numbers the next round of cart items sent to the kitchen and
+// moves the order to ordered. The returned change is nil when the order was
+// already ordered.
+func (o *Order) StartRound(by *int) (int, *OrderStatusChange, error) {
+	var change *OrderStatusChange
+	if o.OrderStatus != vo.OrderStatusOrdered {
+		var err error
+		if change, err = o.TransitionTo(vo.OrderStatusOrdered, by); err != nil {
+			return 0, nil, err
+		}
+	}
+	o.RoundCount++
+	return o.RoundCount, change, nil
+}
+
+// Close completes the order
+func (o *Order) Close(by *int) (*OrderStatusChange, error) {
+	return o.TransitionTo(vo.OrderStatusCompleted, by)
+}
+
+// Reopen puts a completed order back into service
+func (o *Order) Reopen(by *int) (*OrderStatusChange, error) {
+	if !o.IsClosed() {
+		return nil, errs.ErrOrderNotClosed
+	}
+	return o.TransitionTo(vo.OrderStatusOpen, by)
+}
+
+// Cancel cancels an order that has not been paid
+func (o *Order) Cancel(by *int) (*OrderStatusChange, error) {
+	return o.TransitionTo(vo.OrderCancelled, by)
+}
+
+// MergeInto cancels the order once its items and payments have moved to the
+// target order. Its QR code stops granting access as the order is no longer
+// in service.
+func (o *Order) MergeInto(targetID int, by *int) (*OrderStatusChange, error) {
+	o.PaidAmount, _ = vo.NewMoneyFromSatang(0)
+	o.PaymentStatus = vo.PaymentStatusUnpaid
+
+	change, err := o.Cancel(by)
+	if err != nil {
+		return nil, err
+	}
+	o.MergedIntoID = &targetID
+	return change, nil
+}
+
+// SettlePayments records the payments taken against the order and updates
+// its payment status. It reports whether the order changed.
+func (o *Order) SettlePayments(total vo.Money, payments []*Payment) bool {
+	settled := SettledAmount(payments)
+	changed := settled.AmountSatang() != o.PaidAmount.AmountSatang()
+	o.PaidAmount = settled
+
+	status := o.paymentStatusFor(total)
+	if settled.IsZero() && len(payments) > 0 {
+		status = vo.PaymentStatusRefunded
+	}
+	return o.setPaymentStatus(status) || changed
+}
+
+// RefreshPaymentStatus re-evaluates the payment status against the order
+// total, e.g. once the order is closed and nothing more can be added to it.
+// It reports whether the status changed.
+func (o *Order) RefreshPaymentStatus(total vo.Money) bool {
+	if o.PaymentStatus == vo.PaymentStatusRefunded && o.PaidAmount.IsZero() {
+		return false
+	}
+	return o.setPaymentStatus(o.paymentStatusFor(total))
+}
+
+// OutstandingBalance returns what is left to pay of the order total
+func (o *Order) OutstandingBalance(total vo.Money) vo.Money {
+	balance, err := total.Subtract(o.PaidAmount)
+	if err != nil {
+		// paid in full or more
+		balance, _ = vo.NewMoneyFromSatang(0)
+	}
+	return balance
+}
+
+// paymentStatusFor works out the payment status from the amount paid. An
+// order still being served is only partially paid, as more may be added.
+func (o *Order) paymentStatusFor(total vo.Money) vo.PaymentStatus {
+	switch {
+	case o.PaidAmount.IsZero():
+		return vo.PaymentStatusUnpaid
+	case o.IsClosed() && o.PaidAmount.AmountSatang() >= total.AmountSatang():
+		return vo.PaymentStatusPaid
+	default:
+		return vo.PaymentStatusPartial
+	}
+}
+
+func (o *Order) setPaymentStatus(status vo.PaymentStatus) bool {
+	if o.PaymentStatus == status {
+		return false
+	}
+	o.PaymentStatus = status
+	o.UpdatedAt = time.Now()
+	return true
 }
 
 // AddNotes adds notes to the order
@@ -83,13 +327,23 @@ func (o *Order) GetItemCount() int {
 	return count
 }
 
-func (o *Order) CalculateDiscount() vo.Money {
-	// Placeholder for discount logic
-	// This can be extended to apply discounts based on business rules
-	return o.CalculateTotal().Multiply(0.1) // Example: 10% discount
+// ApplyCoupon gives the order a promo code, or takes it away when empty
+func (o *Order) ApplyCoupon(code string, by *int) error {
+	if !o.InService() {
+		return errs.ErrOrderNotInService
+	}
+	o.CouponCode = NormalizePromoCode(code)
+	o.UpdatedBy = by
+	o.UpdatedAt = time.Now()
+	return nil
 }
-func (o *Order) CalculateTax() vo.Money {
-	// Placeholder for tax calculation logic
-	// This can be extended to apply tax based on business rules
-	return o.CalculateTotal().Multiply(0.07) // Example: 7% tax
+
+// ApplyPricing records a priced bill on the order
+func (o *Order) ApplyPricing(b *PriceBreakdown) {
+	o.Subtotal = b.Subtotal
+	o.Discount = b.Discount
+	o.Promotions = b.Promotions
+	o.ServiceCharge = b.ServiceCharge
+	o.TaxAmount = b.TaxAmount
+	o.Total = b.Total
 }
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"math"
+	"sort"
 	"time"
 
 	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
@@ -9,23 +11,46 @@ import (
 
 // OrderItem represents an order item domain entity
 type OrderItem struct {
-	ID              int           `json:"id"`
-	OrderID         int           `json:"order_id"`
-	ItemID          int           `json:"item_id"`
-	Quantity        int           `json:"quantity"`
-	UnitPrice       vo.Money      `json:"unit_price"`
-	Name            string        `json:"name"`
-	Discount        vo.Money      `json:"discount,omitempty"` // optional discount for this item
-	Total           vo.Money      `json:"total"`
-	SpecialReq      string        `json:"special_requests,omitempty"` // any special requests for this item
-	ItemStatus      vo.ItemStatus `json:"item_status"`                // status of the item in the order
-	OrderNumber     string        `json:"order_number"`               // order number for reference
-	KitchenTicketID int           `json:"kitchen_id,omitempty"`
-	KitchenStation  string        `json:"kitchen_station,omitempty"` // optional kitchen ID for tracking
-	KitchenNotes    string        `json:"kitchen_notes,omitempty"`   // notes for the kitchen
-	ServedAt        *time.Time    `json:"served_at,omitempty"`       // time when the item was served
-	CreatedAt       time.Time     `json:"created_at"`
-	UpdatedAt       time.Time     `json:"updated_at"`
+	ID               int               `json:"id"`
+	OrderID          int               `json:"order_id"`
+	ItemID           int               `json:"item_id"`
+	Quantity         int               `json:"quantity"`
+	UnitPrice        vo.Money          `json:"unit_price"`
+	Name             string            `json:"name"`
+	DiscountPercent  float64           `json:"discount_percent,omitempty"` // menu markdown captured when the item was added
+	Discount         vo.Money          `json:"discount,omitempty"`         // manual discount on top of the markdown
+	Total            vo.Money          `json:"total"`                      // line amount after discounts, options excluded
+	SpecialReq       string            `json:"special_requests,omitempty"` // any special requests for this item
+	ItemStatus       vo.ItemStatus     `json:"item_status"`                // status of the item in the order
+	Course           int               `json:"course,omitempty"`           // course the item is served in, 1 for the first; 0 for none
+	Seat             int               `json:"seat,omitempty"`             // seat of the guest the item is for, 1 for the first; 0 for shared
+	Round            int               `json:"round,omitempty"`            // round the item was submitted in; 0 while in the cart
+	HoldOnSubmit     bool              `json:"hold_on_submit,omitempty"`   // cart item to be held for its course once submitted
+	SubmittedAt      *time.Time        `json:"submitted_at,omitempty"`     // time the item's round was submitted
+	OrderNumber      string            `json:"order_number"`               // order number for reference
+	KitchenTicketID  int               `json:"kitchen_id,omitempty"`
+	KitchenStation   string            `json:"kitchen_station,omitempty"`   // optional kitchen ID for tracking
+	KitchenNotes     string            `json:"kitchen_notes,omitempty"`     // notes for the kitchen
+	ServedAt         *time.Time        `json:"served_at,omitempty"`         // time when the item was served
+	Adjustment       vo.ItemAdjustment `json:"adjustment,omitempty"`        // void or comp; empty for items billed as usual
+	AdjustmentReason string            `json:"adjustment_reason,omitempty"` // reason code the item was voided or comped with
+	AdjustedAmount   vo.Money          `json:"adjusted_amount,omitempty"`   // amount taken off the bill, options included
+	AdjustedBy       *int              `json:"adjusted_by,omitempty"`
+	AdjustedAt       *time.Time        `json:"adjusted_at,omitempty"`
+	CreatedBy        *int              `json:"created_by,omitempty"` // staff member who added the item, nil for customer orders
+	UpdatedBy        *int              `json:"updated_by,omitempty"` // staff member who last changed the item
+	Version          int               `json:"version"`              // bumped on every save, for optimistic locking
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+}
+
+// CheckVersion checks the item is still at the version a client last saw.
+// A nil version skips the check.
+func (oi *OrderItem) CheckVersion(expected *int) error {
+	if expected != nil && *expected != oi.Version {
+		return errs.ErrOrderItemVersionConflict
+	}
+	return nil
 }
 
 // IsValid validates order item data
@@ -44,32 +69,216 @@ func (oi *OrderItem) IsValid() bool {
 }
 
 // NewOrderItem creates a new order item
-func NewOrderItem(orderID, itemID int, quantity int, unitPrice float64, name string) (*OrderItem, error) {
+func NewOrderItem(orderID, itemID int, quantity int, unitPrice float64, name string, discountPercent float64) (*OrderItem, error) {
 	p, err := vo.NewMoneyFromBaht(unitPrice)
 	if err != nil {
 		return nil, err
 	}
-	// d, err := vo.NewMoneyFromBaht(discount)
-	// if err != nil {
-	// 	return nil, err
-	// }
+	if discountPercent < 0 || discountPercent > 100 {
+		return nil, errs.ErrInvalidMarkdown
+	}
+
+	item := &OrderItem{
+		OrderID:         orderID,
+		ItemID:          itemID,
+		Quantity:        quantity,
+		UnitPrice:       p,
+		Name:            name,
+		DiscountPercent: discountPercent,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	item.refreshTotal()
+	return item, nil
+}
+
+// GrossAmount returns the unit price times quantity, before discounts
+func (oi *OrderItem) GrossAmount() vo.Money {
+	return oi.UnitPrice.Multiply(float64(oi.Quantity))
+}
+
+// Markdown returns the amount the menu markdown takes off the item
+func (oi *OrderItem) Markdown() vo.Money {
+	return percentOf(oi.GrossAmount(), oi.DiscountPercent/100)
+}
 
-	return &OrderItem{
-		OrderID:   orderID,
-		ItemID:    itemID,
-		Quantity:  quantity,
-		UnitPrice: p,
-		Name:      name,
-		// Discount:  d,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}, nil
+// DiscountAmount returns the markdown and manual discount together
+func (oi *OrderItem) DiscountAmount() vo.Money {
+	return oi.Markdown().Add(oi.Discount)
 }
 
-// CalculateSubtotal calculates subtotal for this order item
+// CalculateSubtotal calculates subtotal for this order item after discounts.
+// Voided and comped items are billed nothing.
 func (oi *OrderItem) CalculateSubtotal() vo.Money {
-	subtotal, _ := vo.NewMoneyFromBaht(oi.UnitPrice.AmountBaht() * float64(oi.Quantity))
-	return subtotal
+	if oi.IsAdjusted() {
+		m, _ := vo.NewMoneyFromSatang(0)
+		return m
+	}
+	return oi.NetAmount()
+}
+
+// NetAmount returns the line amount after discounts, as it would be billed
+// without a void or comp
+func (oi *OrderItem) NetAmount() vo.Money {
+	net, err := oi.GrossAmount().Subtract(oi.DiscountAmount())
+	if err != nil {
+		net, _ = vo.NewMoneyFromSatang(0)
+	}
+	return net
+}
+
+// refreshTotal keeps the stored line amount in step with quantity and discounts
+func (oi *OrderItem) refreshTotal() {
+	oi.Total = oi.CalculateSubtotal()
+}
+
+// HasStarted checks if the kitchen has started on the item, so removing or
+// reducing it is a void
+func (oi *OrderItem) HasStarted() bool {
+	switch oi.ItemStatus {
+	case vo.ItemStatusPreparing, vo.ItemStatusReady, vo.ItemStatusServed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsInKitchen checks if the kitchen has the item on a ticket and has not
+// served it yet
+func (oi *OrderItem) IsInKitchen() bool {
+	switch oi.ItemStatus {
+	case vo.ItemStatusPending, vo.ItemStatusPreparing, vo.ItemStatusReady:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsAdjusted checks if the item was voided or comped
+func (oi *OrderItem) IsAdjusted() bool {
+	return oi.Adjustment != ""
+}
+
+// IsVoided checks if the item was taken off the bill
+func (oi *OrderItem) IsVoided() bool {
+	return oi.Adjustment == vo.ItemAdjustmentVoid
+}
+
+// IsComped checks if the item is given away at zero price
+func (oi *OrderItem) IsComped() bool {
+	return oi.Adjustment == vo.ItemAdjustmentComp
+}
+
+// Void takes a submitted item off the bill and cancels it in the kitchen.
+// The amount is what the item was billed, options included.
+func (oi *OrderItem) Void(reasonCode string, amount vo.Money, by *int) error {
+	if err := oi.adjust(vo.ItemAdjustmentVoid, reasonCode, amount, by); err != nil {
+		return err
+	}
+	oi.ItemStatus = vo.ItemStatusCancelled
+	return nil
+}
+
+// Comp keeps a submitted item on the bill at zero price. The amount is what
+// the item was billed, options included.
+func (oi *OrderItem) Comp(reasonCode string, amount vo.Money, by *int) error {
+	return oi.adjust(vo.ItemAdjustmentComp, reasonCode, amount, by)
+}
+
+func (oi *OrderItem) adjust(adjustment vo.ItemAdjustment, reasonCode string, amount vo.Money, by *int) error {
+	if oi.InCart() {
+		return errs.ErrItemNotSubmitted
+	}
+	if oi.IsAdjusted() {
+		return errs.ErrItemAlreadyAdjusted
+	}
+	if reasonCode == "" {
+		return errs.ErrInvalidReasonCode
+	}
+
+	now := time.Now()
+	oi.Adjustment = adjustment
+	oi.AdjustmentReason = reasonCode
+	oi.AdjustedAmount = amount
+	oi.AdjustedBy = by
+	oi.AdjustedAt = &now
+	oi.UpdatedBy = by
+	oi.UpdatedAt = now
+	oi.refreshTotal()
+	return nil
+}
+
+// IsHeld checks if the item waits for its course to be fired
+func (oi *OrderItem) IsHeld() bool {
+	return oi.ItemStatus == vo.ItemStatusHeld
+}
+
+// InCart checks if the item is still in the order's cart, where it may change
+// freely until its round is submitted
+func (oi *OrderItem) InCart() bool {
+	return oi.ItemStatus == vo.ItemStatusDraft
+}
+
+// Submit locks a cart item into a round and sends it to the kitchen, unless
+// it is to be held for its course
+func (oi *OrderItem) Submit(round int, at time.Time) error {
+	if !oi.InCart() {
+		return errs.ErrItemAlreadySubmitted
+	}
+	oi.Round = round
+	oi.SubmittedAt = &at
+	oi.ItemStatus = vo.ItemStatusPending
+	if oi.HoldOnSubmit {
+		oi.ItemStatus = vo.ItemStatusHeld
+	}
+	oi.HoldOnSubmit = false
+	oi.UpdatedAt = at
+	return nil
+}
+
+// Hold keeps the item away from the kitchen until it is fired. Only items the
+// kitchen has not started may be held; cart items are held once submitted.
+func (oi *OrderItem) Hold() error {
+	if oi.IsHeld() {
+		return nil
+	}
+	if oi.InCart() {
+		oi.HoldOnSubmit = true
+		return nil
+	}
+	if oi.ItemStatus != "" && oi.ItemStatus != vo.ItemStatusPending {
+		return errs.ErrCannotHoldStartedItem
+	}
+	oi.ItemStatus = vo.ItemStatusHeld
+	oi.UpdatedAt = time.Now()
+	return nil
+}
+
+// Fire sends a held item to the kitchen. It reports whether the item was held.
+func (oi *OrderItem) Fire() bool {
+	if !oi.IsHeld() {
+		return false
+	}
+	oi.ItemStatus = vo.ItemStatusPending
+	oi.UpdatedAt = time.Now()
+	return true
+}
+
+// ApplyDiscount sets a manual discount of at most what is left after the
+// markdown
+func (oi *OrderItem) ApplyDiscount(amount float64) error {
+	if oi.IsAdjusted() {
+		return errs.ErrItemAlreadyAdjusted
+	}
+	discount, err := vo.NewMoneyFromBaht(amount)
+	if err != nil || discount.AmountSatang() > oi.GrossAmount().AmountSatang()-oi.Markdown().AmountSatang() {
+		return errs.ErrInvalidDiscount
+	}
+
+	oi.Discount = discount
+	oi.refreshTotal()
+	oi.UpdatedAt = time.Now()
+	return nil
 }
 
 // UpdateQuantity updates the quantity of the order item
@@ -77,8 +286,56 @@ func (oi *OrderItem) UpdateQuantity(newQuantity int) error {
 	if newQuantity <= 0 {
 		return errs.ErrInvalidQuantity
 	}
+	if oi.IsAdjusted() {
+		return errs.ErrItemAlreadyAdjusted
+	}
 
 	oi.Quantity = newQuantity
+	// A manual discount never takes off more than the smaller line is worth
+	if left, err := oi.GrossAmount().Subtract(oi.Markdown()); err == nil && oi.Discount.AmountSatang() > left.AmountSatang() {
+		oi.Discount = left
+	}
+	oi.refreshTotal()
 	oi.UpdatedAt = time.Now()
 	return nil
 }
+
+// SortBySeat orders items seat by seat, keeping their order within a seat.
+// Shared items, with no seat, come last.
+func SortBySeat(items []*OrderItem) []*OrderItem {
+	sorted := make([]*OrderItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return seatRank(sorted[i].Seat) < seatRank(sorted[j].Seat)
+	})
+	return sorted
+}
+
+// HasSeats checks if any of the items was ordered for a seat
+func HasSeats(items []*OrderItem) bool {
+	for _, item := range items {
+		if item.Seat > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// LastSeat returns the highest seat any of the items was ordered for, or 0
+// when they are all shared
+func LastSeat(items []*OrderItem) int {
+	last := 0
+	for _, item := range items {
+		if item.Seat > last {
+			last = item.Seat
+		}
+	}
+	return last
+}
+
+func seatRank(seat int) int {
+	if seat <= 0 {
+		return math.MaxInt
+	}
+	return seat
+}
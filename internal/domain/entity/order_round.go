@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// OrderRound is a batch of cart items submitted to the kitchen together
+type OrderRound struct {
+	Number      int // 0 for items ordered before rounds were numbered
+	SubmittedAt *time.Time
+	Items       []*OrderItem
+}
+
+// itemProgress ranks item statuses by how far the kitchen got with them
+var itemProgress = map[vo.ItemStatus]int{
+	vo.ItemStatusHeld:      1,
+	vo.ItemStatusPending:   2,
+	vo.ItemStatusPreparing: 3,
+	vo.ItemStatusReady:     4,
+	vo.ItemStatusServed:    5,
+}
+
+// Status returns the status of the least advanced item in the round,
+// ignoring cancelled items. A round with only cancelled items is cancelled.
+func (r *OrderRound) Status() vo.ItemStatus {
+	status := vo.ItemStatusCancelled
+	for _, item := range r.Items {
+		rank, ok := itemProgress[item.ItemStatus]
+		if !ok {
+			continue
+		}
+		if status == vo.ItemStatusCancelled || rank < itemProgress[status] {
+			status = item.ItemStatus
+		}
+	}
+	return status
+}
+
+// GroupRounds groups submitted items by their round, earliest round first.
+// Cart items are left out.
+func GroupRounds(items []*OrderItem) []*OrderRound {
+	byNumber := make(map[int]*OrderRound)
+	var rounds []*OrderRound
+	for _, item := range items {
+		if item.InCart() {
+			continue
+		}
+		round, ok := byNumber[item.Round]
+		if !ok {
+			round = &OrderRound{Number: item.Round}
+			byNumber[item.Round] = round
+			rounds = append(rounds, round)
+		}
+		if round.SubmittedAt == nil && item.SubmittedAt != nil {
+			round.SubmittedAt = item.SubmittedAt
+		}
+		round.Items = append(round.Items, item)
+	}
+
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i].Number < rounds[j].Number })
+	return rounds
+}
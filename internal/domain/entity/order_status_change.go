@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// OrderStatusChange is one step in the lifecycle of an order. An order's
+// status history is the list of its changes, oldest first.
+type OrderStatusChange struct {
+	ID         int            `json:"id"`
+	OrderID    int            `json:"order_id"`
+	FromStatus vo.OrderStatus `json:"from_status,omitempty"` // empty when the order was opened
+	ToStatus   vo.OrderStatus `json:"to_status"`
+	ChangedBy  *int           `json:"changed_by,omitempty"` // staff member who made the change
+	ChangedAt  time.Time      `json:"changed_at"`
+}
+
+// NewOrderStatusChange records an order moving from one status to another
+func NewOrderStatusChange(orderID int, from, to vo.OrderStatus, by *int) *OrderStatusChange {
+	return &OrderStatusChange{
+		OrderID:    orderID,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  by,
+		ChangedAt:  time.Now(),
+	}
+}
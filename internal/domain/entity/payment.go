@@ -3,16 +3,22 @@ package entity
 import (
 	"time"
 
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
 type Payment struct {
-	ID        int              `json:"id"`
-	OrderID   int              `json:"order_id"`
-	Amount    vo.Money         `json:"amount"`
-	Method    vo.PaymentMethod `json:"method"`
-	Reference string           `json:"reference,omitempty"`
-	PaidAt    time.Time        `json:"paid_at"`
+	ID           int              `json:"id"`
+	OrderID      int              `json:"order_id"`
+	Amount       vo.Money         `json:"amount"`
+	Method       vo.PaymentMethod `json:"method"`
+	Reference    string           `json:"reference,omitempty"`
+	PaidAt       time.Time        `json:"paid_at"`
+	ProcessedBy  *int             `json:"processed_by,omitempty"`   // staff member who took the payment
+	ShiftID      *int             `json:"shift_id,omitempty"`       // open shift of the staff member who took the payment
+	OrderItemIDs []int            `json:"order_item_ids,omitempty"` // items this payment covers when the bill is split by item
+	RefundedAt   *time.Time       `json:"refunded_at,omitempty"`
+	RefundedBy   *int             `json:"refunded_by,omitempty"`
 }
 
 // IsValid validates payment data
@@ -40,6 +46,22 @@ func NewPayment(orderID int, amount float64, method string) (*Payment, error) {
 	}, nil
 }
 
+// IsRefunded checks if the payment has been refunded
+func (p *Payment) IsRefunded() bool {
+	return p.RefundedAt != nil
+}
+
+// Refund marks the payment as refunded
+func (p *Payment) Refund(refundedBy *int) error {
+	if p.IsRefunded() {
+		return errs.ErrPaymentAlreadyRefunded
+	}
+	now := time.Now()
+	p.RefundedAt = &now
+	p.RefundedBy = refundedBy
+	return nil
+}
+
 // AddReference adds a reference to the payment
 func (p *Payment) AddReference(reference string) {
 	p.Reference = reference
@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"math"
+	"sort"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// PricingPolicy holds the rules used to price an order bill
+type PricingPolicy struct {
+	VATRate                 float64 // e.g. 0.07 for 7% VAT
+	VATInclusive            bool    // item prices already include VAT
+	ServiceChargeRate       float64 // e.g. 0.10 for a 10% service charge
+	ServiceChargeDineInOnly bool
+	DiscountRules           []DiscountRule
+}
+
+// DiscountRule takes a percentage off orders whose subtotal reaches
+// MinSubtotal. Rules limited to some order types skip the others.
+type DiscountRule struct {
+	Name        string
+	Rate        float64 // e.g. 0.10 for 10% off
+	MinSubtotal vo.Money
+	OrderTypes  []vo.OrderType // empty for every order type
+}
+
+// AppliesTo checks if the rule applies to an order of the given type and subtotal
+func (r DiscountRule) AppliesTo(orderType vo.OrderType, subtotal vo.Money) bool {
+	if r.Rate <= 0 || subtotal.AmountSatang() < r.MinSubtotal.AmountSatang() {
+		return false
+	}
+	if len(r.OrderTypes) == 0 {
+		return true
+	}
+	for _, t := range r.OrderTypes {
+		if t == orderType {
+			return true
+		}
+	}
+	return false
+}
+
+// PriceBreakdown is a priced order bill
+type PriceBreakdown struct {
+	Subtotal          vo.Money // items and options after item discounts
+	Discount          vo.Money // promotions and the discount rule together
+	Promotions        []AppliedPromotion
+	RuleDiscount      vo.Money // taken off by the discount rule
+	ServiceCharge     vo.Money
+	TaxAmount         vo.Money // VAT, included in Total either way
+	Total             vo.Money // amount the customer pays
+	VATRate           float64
+	VATInclusive      bool
+	ServiceChargeRate float64 // zero when no service charge applied
+	DiscountName      string  // rule the discount came from
+	DiscountRate      float64
+}
+
+// Price prices a bill: promotions come off the subtotal, then the best
+// applicable discount rule off what is left. Service charge is added on the
+// discounted amount, and VAT is charged on the result, or taken out of it when
+// prices include VAT.
+func (p PricingPolicy) Price(orderType vo.OrderType, subtotal vo.Money, promotions []AppliedPromotion) *PriceBreakdown {
+	b := &PriceBreakdown{
+		Subtotal:     subtotal,
+		Promotions:   make([]AppliedPromotion, len(promotions)),
+		VATRate:      p.VATRate,
+		VATInclusive: p.VATInclusive,
+	}
+
+	net := subtotal
+	for i, promotion := range promotions {
+		if promotion.Amount.AmountSatang() > net.AmountSatang() {
+			promotion.Amount = net
+		}
+		net, _ = net.Subtract(promotion.Amount)
+		b.Promotions[i] = promotion
+	}
+
+	for _, rule := range p.DiscountRules {
+		if rule.AppliesTo(orderType, subtotal) && rule.Rate > b.DiscountRate {
+			b.DiscountName, b.DiscountRate = rule.Name, rule.Rate
+		}
+	}
+	b.RuleDiscount = percentOf(net, math.Min(b.DiscountRate, 1))
+	net, _ = net.Subtract(b.RuleDiscount)
+	b.Discount, _ = subtotal.Subtract(net)
+
+	if p.ServiceChargeRate > 0 && (!p.ServiceChargeDineInOnly || orderType == vo.OrderTypeDineIn) {
+		b.ServiceChargeRate = p.ServiceChargeRate
+		b.ServiceCharge = percentOf(net, p.ServiceChargeRate)
+	}
+	taxable := net.Add(b.ServiceCharge)
+
+	if p.VATRate <= 0 {
+		b.Total = taxable
+		return b
+	}
+	if p.VATInclusive {
+		exclusive, _ := vo.NewMoneyFromSatang(int64(math.Round(float64(taxable.AmountSatang()) / (1 + p.VATRate))))
+		b.TaxAmount, _ = taxable.Subtract(exclusive)
+		b.Total = taxable
+	} else {
+		b.TaxAmount = percentOf(taxable, p.VATRate)
+		b.Total = taxable.Add(b.TaxAmount)
+	}
+	return b
+}
+
+// Allocate spreads the bill total over parts of the subtotal, keyed by ID, in
+// proportion to their amounts. The leftover satang go to the lowest IDs, so
+// allocating every part of the subtotal adds up to the total exactly.
+func (b *PriceBreakdown) Allocate(amounts map[int]vo.Money) map[int]vo.Money {
+	ids := make([]int, 0, len(amounts))
+	for id := range amounts {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	allocated := make(map[int]vo.Money, len(amounts))
+	if b.Subtotal.IsZero() {
+		for _, id := range ids {
+			allocated[id], _ = vo.NewMoneyFromSatang(0)
+		}
+		return allocated
+	}
+
+	ratio := float64(b.Total.AmountSatang()) / float64(b.Subtotal.AmountSatang())
+	var sum, whole int64
+	for _, id := range ids {
+		satang := int64(math.Floor(float64(amounts[id].AmountSatang()) * ratio))
+		allocated[id], _ = vo.NewMoneyFromSatang(satang)
+		sum += satang
+		whole += amounts[id].AmountSatang()
+	}
+	if whole != b.Subtotal.AmountSatang() {
+		return allocated
+	}
+
+	for i := 0; sum < b.Total.AmountSatang() && len(ids) > 0; i++ {
+		id := ids[i%len(ids)]
+		allocated[id] = allocated[id].Add(oneSatang)
+		sum++
+	}
+	return allocated
+}
+
+var oneSatang, _ = vo.NewMoneyFromSatang(1)
+
+// percentOf returns rate of an amount, rounded to the nearest satang
+func percentOf(amount vo.Money, rate float64) vo.Money {
+	if rate <= 0 {
+		m, _ := vo.NewMoneyFromSatang(0)
+		return m
+	}
+	m, _ := vo.NewMoneyFromSatang(int64(math.Round(float64(amount.AmountSatang()) * rate)))
+	return m
+}
@@ -0,0 +1,242 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// Promotion is a marketing discount. Promotions without a code apply to every
+// order that meets their rules; those with a code only to orders given it.
+type Promotion struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	Code           string           `json:"code,omitempty"` // coupon code, upper case; empty for automatic promotions
+	Type           vo.PromotionType `json:"type"`
+	PercentOff     float64          `json:"percent_off,omitempty"`      // 0-100, for percentage promotions
+	AmountOff      vo.Money         `json:"amount_off,omitempty"`       // for fixed amount promotions
+	BuyQuantity    int              `json:"buy_quantity,omitempty"`     // units paid for in each buy-X-get-Y group
+	FreeQuantity   int              `json:"free_quantity,omitempty"`    // units free in each buy-X-get-Y group
+	MenuItemIDs    []int            `json:"menu_item_ids,omitempty"`    // eligible items; with no categories either, the whole order
+	CategoryIDs    []int            `json:"category_ids,omitempty"`     // eligible categories
+	MinSpend       vo.Money         `json:"min_spend,omitempty"`        // order subtotal needed to qualify
+	StartsAt       *time.Time       `json:"starts_at,omitempty"`        // orders opened before this do not qualify
+	EndsAt         *time.Time       `json:"ends_at,omitempty"`          // orders opened after this do not qualify
+	HappyHourStart string           `json:"happy_hour_start,omitempty"` // HH:MM; only items ordered inside the daily window qualify
+	HappyHourEnd   string           `json:"happy_hour_end,omitempty"`   // HH:MM; may be before the start for windows past midnight
+	Priority       int              `json:"priority"`                   // higher priorities are applied first
+	Stackable      bool             `json:"stackable"`                  // may combine with other stackable promotions
+	UsageLimit     int              `json:"usage_limit,omitempty"`      // orders that may use the promotion, 0 for no limit
+	UsageCount     int              `json:"usage_count"`                // orders that used the promotion or hold its code
+	IsActive       bool             `json:"is_active"`
+	CreatedBy      *int             `json:"created_by,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// PromotionLine is an order item as promotions see it
+type PromotionLine struct {
+	OrderItemID int
+	MenuItemID  int
+	CategoryID  int
+	Quantity    int
+	Amount      vo.Money // quantity times unit price, options included
+	OrderedAt   time.Time
+}
+
+// AppliedPromotion is a promotion line on a priced bill
+type AppliedPromotion struct {
+	PromotionID int      `json:"promotion_id"`
+	Name        string   `json:"name"`
+	Code        string   `json:"code,omitempty"`
+	Amount      vo.Money `json:"amount"`
+}
+
+// NormalizePromoCode puts a code the way promotions store it
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the promotion rules are complete and consistent
+func (p *Promotion) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errs.ErrInvalidPromotionName
+	}
+	switch p.Type {
+	case vo.PromotionPercentage:
+		if p.PercentOff <= 0 || p.PercentOff > 100 {
+			return errs.ErrInvalidPromotionValue
+		}
+	case vo.PromotionFixed:
+		if p.AmountOff.IsZero() {
+			return errs.ErrInvalidPromotionValue
+		}
+	case vo.PromotionBuyXGetY:
+		if p.BuyQuantity <= 0 || p.FreeQuantity <= 0 {
+			return errs.ErrInvalidPromotionValue
+		}
+	default:
+		return errs.ErrInvalidPromotionType
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.StartsAt.Before(*p.EndsAt) {
+		return errs.ErrInvalidDateRange
+	}
+	if (p.HappyHourStart == "") != (p.HappyHourEnd == "") {
+		return errs.ErrInvalidHappyHour
+	}
+	for _, clock := range []string{p.HappyHourStart, p.HappyHourEnd} {
+		if _, err := time.Parse("15:04", clock); clock != "" && err != nil {
+			return errs.ErrInvalidHappyHour
+		}
+	}
+	if p.UsageLimit < 0 {
+		return errs.ErrInvalidPromotionValue
+	}
+	return nil
+}
+
+// RequiresCode checks if the promotion only applies to orders given its code
+func (p *Promotion) RequiresCode() bool {
+	return p.Code != ""
+}
+
+// IsUsedUp checks if the promotion has been used as often as it may be
+func (p *Promotion) IsUsedUp() bool {
+	return p.UsageLimit > 0 && p.UsageCount >= p.UsageLimit
+}
+
+// IsRunning checks if an order opened at the given time may use the promotion
+func (p *Promotion) IsRunning(at time.Time) bool {
+	if !p.IsActive || p.IsUsedUp() {
+		return false
+	}
+	if p.StartsAt != nil && at.Before(*p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || !at.After(*p.EndsAt)
+}
+
+// inHappyHour checks if the time of day falls inside the daily window
+func (p *Promotion) inHappyHour(at time.Time) bool {
+	if p.HappyHourStart == "" {
+		return true
+	}
+	clock := at.Format("15:04")
+	if p.HappyHourStart <= p.HappyHourEnd {
+		return clock >= p.HappyHourStart && clock < p.HappyHourEnd
+	}
+	return clock >= p.HappyHourStart || clock < p.HappyHourEnd
+}
+
+// isEligible checks if an order line counts towards the promotion
+func (p *Promotion) isEligible(line PromotionLine) bool {
+	if !p.inHappyHour(line.OrderedAt) {
+		return false
+	}
+	if len(p.MenuItemIDs) == 0 && len(p.CategoryIDs) == 0 {
+		return true
+	}
+	for _, id := range p.MenuItemIDs {
+		if id == line.MenuItemID {
+			return true
+		}
+	}
+	for _, id := range p.CategoryIDs {
+		if id == line.CategoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// Discount returns how much the promotion takes off the given order lines
+func (p *Promotion) Discount(lines []PromotionLine) vo.Money {
+	eligible, _ := vo.NewMoneyFromSatang(0)
+	var units []int64
+	for _, line := range lines {
+		if line.Quantity <= 0 || !p.isEligible(line) {
+			continue
+		}
+		eligible = eligible.Add(line.Amount)
+		for i := 0; i < line.Quantity; i++ {
+			units = append(units, line.Amount.AmountSatang()/int64(line.Quantity))
+		}
+	}
+
+	switch p.Type {
+	case vo.PromotionPercentage:
+		return percentOf(eligible, p.PercentOff/100)
+	case vo.PromotionFixed:
+		if p.AmountOff.AmountSatang() > eligible.AmountSatang() {
+			return eligible
+		}
+		return p.AmountOff
+	case vo.PromotionBuyXGetY:
+		// Most expensive units first, so the free units of each group are
+		// the cheapest in it
+		sort.Slice(units, func(i, j int) bool { return units[i] > units[j] })
+		group := p.BuyQuantity + p.FreeQuantity
+		var free int64
+		for start := 0; start+group <= len(units); start += group {
+			for _, unit := range units[start+p.BuyQuantity : start+group] {
+				free += unit
+			}
+		}
+		discount, _ := vo.NewMoneyFromSatang(free)
+		return discount
+	}
+	m, _ := vo.NewMoneyFromSatang(0)
+	return m
+}
+
+// ApplyPromotions picks the promotions an order gets, highest priority first.
+// The first one always applies; after it only stackable promotions combine,
+// and only while every promotion applied so far is stackable. Promotions with
+// a code apply only when the order was given that code. Together they never
+// take off more than the subtotal.
+func ApplyPromotions(promotions []*Promotion, code string, openedAt time.Time, lines []PromotionLine, subtotal vo.Money) []AppliedPromotion {
+	ordered := make([]*Promotion, len(promotions))
+	copy(ordered, promotions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	code = NormalizePromoCode(code)
+	remaining := subtotal
+	stackable := true
+	var applied []AppliedPromotion
+	for _, p := range ordered {
+		if len(applied) > 0 && !(stackable && p.Stackable) {
+			continue
+		}
+		if p.RequiresCode() && p.Code != code {
+			continue
+		}
+		if !p.IsRunning(openedAt) || subtotal.AmountSatang() < p.MinSpend.AmountSatang() {
+			continue
+		}
+
+		amount := p.Discount(lines)
+		if amount.AmountSatang() > remaining.AmountSatang() {
+			amount = remaining
+		}
+		if amount.IsZero() {
+			continue
+		}
+		remaining, _ = remaining.Subtract(amount)
+		stackable = stackable && p.Stackable
+		applied = append(applied, AppliedPromotion{
+			PromotionID: p.ID,
+			Name:        p.Name,
+			Code:        p.Code,
+			Amount:      amount,
+		})
+	}
+	return applied
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// RefreshToken represents a persisted refresh token belonging to a login session.
+// Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"user_id"`
+	SessionID  string     `json:"session_id"`
+	TerminalID *int       `json:"terminal_id,omitempty"` // set for PIN sessions on shared terminals
+	TokenHash  string     `json:"-"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IsRevoked checks if the token has been revoked
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired checks if the token has expired
+func (t *RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// IsUsable checks if the token can still be exchanged for a new access token
+func (t *RefreshToken) IsUsable() bool {
+	return !t.IsRevoked() && !t.IsExpired()
+}
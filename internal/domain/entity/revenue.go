@@ -8,12 +8,68 @@ import (
 
 // DailyRevenue represents daily revenue summary
 type DailyRevenue struct {
-	Date         time.Time `json:"date"`
-	TotalRevenue vo.Money  `json:"total_revenue"`
+	Date           time.Time `json:"date"`
+	TotalRevenue   vo.Money  `json:"total_revenue"`
+	PartialRevenue vo.Money  `json:"partial_revenue"` // taken against orders not yet paid in full
 }
 
 // MonthlyRevenue represents monthly revenue summary
 type MonthlyRevenue struct {
-	Month        time.Time `json:"month"`
-	TotalRevenue vo.Money  `json:"total_revenue"`
+	Month          time.Time `json:"month"`
+	TotalRevenue   vo.Money  `json:"total_revenue"`
+	PartialRevenue vo.Money  `json:"partial_revenue"` // taken against orders not yet paid in full
+}
+
+// PromotionRevenue represents the discount one promotion gave on completed orders
+type PromotionRevenue struct {
+	PromotionID    int      `json:"promotion_id"`
+	Name           string   `json:"name"`
+	Code           string   `json:"code,omitempty"`
+	DiscountAmount vo.Money `json:"discount_amount"`
+	OrderCount     int      `json:"order_count"`
+}
+
+// ItemDiscountRevenue represents the discounts one menu item was sold with on
+// completed orders
+type ItemDiscountRevenue struct {
+	MenuItemID     int      `json:"menu_item_id"`
+	Name           string   `json:"name"`
+	Quantity       int      `json:"quantity"`
+	MarkdownAmount vo.Money `json:"markdown_amount"` // taken off by menu markdowns
+	DiscountAmount vo.Money `json:"discount_amount"` // taken off by manual item discounts
+}
+
+// ItemAdjustmentRevenue represents the value of the items voided or comped
+// with one reason code
+type ItemAdjustmentRevenue struct {
+	Adjustment vo.ItemAdjustment `json:"adjustment"`
+	ReasonCode string            `json:"reason_code"`
+	ItemCount  int               `json:"item_count"` // order items adjusted
+	Quantity   int               `json:"quantity"`
+	Amount     vo.Money          `json:"amount"` // taken off bills, options included
+}
+
+// CoverRevenue represents the guests served on completed orders that
+// recorded a guest count, and what they spent
+type CoverRevenue struct {
+	Covers       int      `json:"covers"`
+	OrderCount   int      `json:"order_count"`
+	TotalRevenue vo.Money `json:"total_revenue"`
+}
+
+// AverageSpendPerCover is the revenue spread over the guests served, or zero
+// when none were recorded
+func (r *CoverRevenue) AverageSpendPerCover() vo.Money {
+	average, err := r.TotalRevenue.Divide(float64(r.Covers))
+	if err != nil {
+		return vo.Money{}
+	}
+	return average
+}
+
+// OrderTypeRevenue represents the revenue taken on one order type
+type OrderTypeRevenue struct {
+	OrderType    vo.OrderType `json:"order_type"`
+	TotalRevenue vo.Money     `json:"total_revenue"`
+	OrderCount   int          `json:"order_count"`
 }
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"time"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// Shift is one stretch of work by a staff member, from clock-in to clock-out.
+// Payments a cashier takes while the shift is open are tied to it.
+type Shift struct {
+	ID         int           `json:"id"`
+	UserID     int           `json:"user_id"`
+	ClockInAt  time.Time     `json:"clock_in_at"`
+	ClockOutAt *time.Time    `json:"clock_out_at,omitempty"`
+	ClosedBy   *int          `json:"closed_by,omitempty"` // staff member who clocked the shift out
+	Note       string        `json:"note,omitempty"`
+	Breaks     []*ShiftBreak `json:"breaks,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// ShiftBreak is a break taken during a shift
+type ShiftBreak struct {
+	ID        int        `json:"id"`
+	ShiftID   int        `json:"shift_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+// NewShift clocks a staff member in
+func NewShift(userID int, note string) *Shift {
+	now := time.Now()
+	return &Shift{
+		UserID:    userID,
+		ClockInAt: now,
+		Note:      note,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsOpen checks if the staff member has not clocked out yet
+func (s *Shift) IsOpen() bool {
+	return s.ClockOutAt == nil
+}
+
+// CurrentBreak returns the break in progress, or nil
+func (s *Shift) CurrentBreak() *ShiftBreak {
+	for _, b := range s.Breaks {
+		if b.EndedAt == nil {
+			return b
+		}
+	}
+	return nil
+}
+
+// StartBreak begins a break on an open shift
+func (s *Shift) StartBreak() (*ShiftBreak, error) {
+	if !s.IsOpen() {
+		return nil, errs.ErrShiftClosed
+	}
+	if s.CurrentBreak() != nil {
+		return nil, errs.ErrShiftOnBreak
+	}
+
+	b := &ShiftBreak{
+		ShiftID:   s.ID,
+		StartedAt: time.Now(),
+	}
+	s.Breaks = append(s.Breaks, b)
+	s.UpdatedAt = b.StartedAt
+	return b, nil
+}
+
+// EndBreak ends the break in progress
+func (s *Shift) EndBreak() (*ShiftBreak, error) {
+	if !s.IsOpen() {
+		return nil, errs.ErrShiftClosed
+	}
+	b := s.CurrentBreak()
+	if b == nil {
+		return nil, errs.ErrShiftNotOnBreak
+	}
+
+	now := time.Now()
+	b.EndedAt = &now
+	s.UpdatedAt = now
+	return b, nil
+}
+
+// ClockOut closes the shift, ending any break in progress. It returns the
+// break that was ended, if any.
+func (s *Shift) ClockOut(closedBy *int) (*ShiftBreak, error) {
+	if !s.IsOpen() {
+		return nil, errs.ErrShiftClosed
+	}
+
+	now := time.Now()
+	b := s.CurrentBreak()
+	if b != nil {
+		b.EndedAt = &now
+	}
+	s.ClockOutAt = &now
+	s.ClosedBy = closedBy
+	s.UpdatedAt = now
+	return b, nil
+}
+
+// End returns when the shift ended, or now for an open shift
+func (s *Shift) End() time.Time {
+	if s.ClockOutAt != nil {
+		return *s.ClockOutAt
+	}
+	return time.Now()
+}
+
+// BreakDuration returns the time spent on breaks so far
+func (s *Shift) BreakDuration() time.Duration {
+	var total time.Duration
+	for _, b := range s.Breaks {
+		end := s.End()
+		if b.EndedAt != nil {
+			end = *b.EndedAt
+		}
+		total += end.Sub(b.StartedAt)
+	}
+	return total
+}
+
+// WorkedDuration returns the time on shift excluding breaks
+func (s *Shift) WorkedDuration() time.Duration {
+	return s.End().Sub(s.ClockInAt) - s.BreakDuration()
+}
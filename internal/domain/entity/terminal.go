@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Terminal represents a registered shared POS device (e.g. a front-of-house tablet).
+// Only the SHA-256 hash of the device token is stored.
+type Terminal struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	TokenHash  string     `json:"-"`
+	IsActive   bool       `json:"is_active"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsValid validates terminal data
+func (t *Terminal) IsValid() bool {
+	return t.Name != "" && t.TokenHash != ""
+}
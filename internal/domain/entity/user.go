@@ -11,6 +11,7 @@ type User struct {
 	ID            int         `json:"id"`
 	Email         string      `json:"email"`
 	PasswordHash  string      `json:"-"` // Hide password hash in JSON
+	PinHash       string      `json:"-"` // quick-login PIN for shared terminals
 	Role          vo.UserRole `json:"role"`
 	IsActive      bool        `json:"is_active"`
 	EmailVerified bool        `json:"email_verified"`
@@ -25,3 +26,8 @@ func (u *User) IsValid() bool {
 	}
 	return true
 }
+
+// HasPin checks if the user has set a quick-login PIN
+func (u *User) HasPin() bool {
+	return u.PinHash != ""
+}
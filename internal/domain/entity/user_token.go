@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
+)
+
+// UserToken is a single-use token mailed to a user to verify their email or
+// reset their password. Only the SHA-256 hash of the token is stored.
+type UserToken struct {
+	ID        int             `json:"id"`
+	UserID    int             `json:"user_id"`
+	Purpose   vo.TokenPurpose `json:"purpose"`
+	TokenHash string          `json:"-"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	UsedAt    *time.Time      `json:"used_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// IsUsed checks if the token has been used or invalidated
+func (t *UserToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsExpired checks if the token has expired
+func (t *UserToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// IsUsable checks if the token can still be redeemed for its purpose
+func (t *UserToken) IsUsable(purpose vo.TokenPurpose) bool {
+	return t.Purpose == purpose && !t.IsUsed() && !t.IsExpired()
+}
@@ -29,6 +29,7 @@ const (
 	CategoryForbidden    ErrorCategory = "FORBIDDEN"
 	CategoryInternal     ErrorCategory = "INTERNAL"
 	CategoryBusiness     ErrorCategory = "BUSINESS_RULE"
+	CategoryRateLimit    ErrorCategory = "RATE_LIMIT"
 )
 
 // BaseDomainError implements DomainError interface
@@ -54,8 +55,10 @@ func (e *BaseDomainError) Details() map[string]interface{} {
 
 func (e *BaseDomainError) WithDetails(details map[string]interface{}) DomainError {
 	newErr := *e
-	if newErr.details == nil {
-		newErr.details = make(map[string]interface{})
+	// Copy the details so the shared error values are never changed
+	newErr.details = make(map[string]interface{}, len(e.details)+len(details))
+	for k, v := range e.details {
+		newErr.details[k] = v
 	}
 	for k, v := range details {
 		newErr.details[k] = v
@@ -153,6 +156,20 @@ func NewForbiddenError(action string, resource string) DomainError {
 		},
 	}
 }
+
+// NewRateLimitError creates too many requests errors
+func NewRateLimitError(reason string) DomainError {
+	return &BaseDomainError{
+		code:       "TOO_MANY_REQUESTS",
+		message:    fmt.Sprintf("Too many requests: %s", reason),
+		httpStatus: http.StatusTooManyRequests,
+		category:   CategoryRateLimit,
+		details: map[string]interface{}{
+			"reason": reason,
+		},
+	}
+}
+
 func NewExternalServiceError(service string, reason string) DomainError {
 	return &BaseDomainError{
 		code:       "EXTERNAL_SERVICE_ERROR",
@@ -13,10 +13,14 @@ var (
 	ErrInvalidDiscountAmount = NewValidationError("discount_amount", "must be between 0 and order total", nil)
 
 	// Order Validation
-	ErrInvalidOrderStatus       = NewValidationError("order_status", "must be 'open' or 'closed'", nil)
+	ErrInvalidOrderStatus       = NewValidationError("order_status", "must be 'open', 'ordered', 'completed', or 'cancelled'", nil)
 	ErrInvalidOrderTime         = NewValidationError("order_time", "must be within business hours", nil)
 	ErrInvalidOrderNote         = NewValidationError("order_note", "exceeds maximum length", nil)
 	ErrInvalidOrderItemQuantity = NewValidationError("order_item_quantity", "must be greater than 0", nil)
+	ErrInvalidOrderType         = NewValidationError("order_type", "must be 'dine_in', 'takeaway', 'delivery', or 'pickup'", nil)
+	ErrTableRequired            = NewValidationError("table_id", "is required for dine-in orders", nil)
+	ErrCustomerContactRequired  = NewValidationError("customer", "name or phone is required for orders without a table", nil)
+	ErrDeliveryAddressRequired  = NewValidationError("delivery_address", "is required for delivery orders", nil)
 
 	// Table Validation
 	ErrInvalidTableNumber   = NewValidationError("table_number", "must be greater than 0", nil)
@@ -54,18 +58,47 @@ var (
 	// Promo Code Validation
 	ErrInvalidPromoCode     = NewValidationError("promo_code", "invalid format or not found", nil)
 	ErrInvalidLoyaltyPoints = NewValidationError("loyalty_points", "must be non-negative", nil)
+	// promotion
+	ErrInvalidPromotionName  = NewValidationError("promotion_name", "must be non-empty", nil)
+	ErrInvalidPromotionType  = NewValidationError("promotion_type", "must be 'percentage', 'fixed_amount', or 'buy_x_get_y'", nil)
+	ErrInvalidPromotionValue = NewValidationError("promotion_value", "percent off must be between 0 and 100, amount off positive, and buy and free quantities positive", nil)
+	ErrInvalidHappyHour      = NewValidationError("happy_hour", "start and end must both be HH:MM times", nil)
 	// menu_option
 	ErrInvalidMenuOption = NewValidationError("menu_option", "must have valid name and type", nil)
 	// option_value
 	ErrInvalidMenuOptionValue = NewValidationError("option_value", "must have valid name", nil)
 	// payment status
-	ErrInvalidPaymentStatus = NewValidationError("payment_status", "must be 'pending', 'completed', or 'failed'", nil)
+	ErrInvalidPaymentStatus = NewValidationError("payment_status", "must be 'unpaid', 'partial', 'paid', or 'refunded'", nil)
 	//
 	ErrInvalidOrderItemOption = NewValidationError("order_item_option", "must have valid order item ID, option ID, and value ID", nil)
 	//
-	ErrInvalidItemStatus   = NewValidationError("item_status", "must be 'pending', 'preparing', 'ready', or 'served'", nil)
-	ErrInvalidCategoryName = NewValidationError("category_name", "must be non-empty", nil)
-	ErrKitchenNotFound     = NewNotFoundError("kitchen", nil)
+	ErrInvalidItemStatus     = NewValidationError("item_status", "must be 'draft', 'held', 'pending', 'preparing', 'ready', 'served', or 'cancelled'", nil)
+	ErrInvalidCategoryName   = NewValidationError("category_name", "must be non-empty", nil)
+	ErrKitchenNotFound       = NewNotFoundError("kitchen", nil)
+	ErrInvalidPinFormat      = NewValidationError("pin", "must be 4 to 6 digits", nil)
+	ErrInvalidTerminalName   = NewValidationError("terminal_name", "must be non-empty", nil)
+	ErrInvalidAuditAction    = NewValidationError("audit_action", "must be a recorded audit action", nil)
+	ErrInvalidApprovalAction = NewValidationError("approval_action", "must be 'item_void', 'item_comp', 'discount', 'order_reopen', or 'refund'", nil)
+	ErrInvalidApprovalStatus = NewValidationError("approval_status", "must be 'pending', 'approved', 'rejected', or 'used'", nil)
+	ErrInvalidReasonCode     = NewValidationError("reason_code", "must be non-empty", nil)
+	ErrInvalidDiscount       = NewValidationError("discount", "must be between zero and the item subtotal", nil)
+	ErrInvalidMarkdown       = NewValidationError("discount_percent", "must be between 0 and 100", nil)
+	ErrInvalidTokenPurpose   = NewValidationError("token_purpose", "must be 'email_verification' or 'password_reset'", nil)
+	ErrInvalidPassword       = NewValidationError("password", "must be at least 8 characters", nil)
+	ErrInvalidPermission     = NewValidationError("permission", "must be a known permission", nil)
+	ErrInvalidAPIKeyName     = NewValidationError("api_key_name", "must be non-empty", nil)
+	ErrInvalidSplitMode      = NewValidationError("split_mode", "must be 'even', 'item', or 'custom'", nil)
+	ErrInvalidSplitParts     = NewValidationError("parts", "must split the bill between at least 2 people", nil)
+	ErrInvalidSplitItems     = NewValidationError("order_item_ids", "must be unpaid items of the order, each listed once", nil)
+	ErrInvalidSplitAmounts   = NewValidationError("amounts", "must be positive and add up to the outstanding balance", nil)
+	ErrInvalidAPIKeyScopes   = NewValidationError("scopes", "must list at least one permission that API keys may hold", nil)
+	ErrInvalidIdempotencyKey = NewValidationError("idempotency_key", "must be at most 255 characters", nil)
+	ErrInvalidCourse         = NewValidationError("course", "must not be negative", nil)
+	ErrInvalidRepeatItems    = NewValidationError("order_item_ids", "must be given instead of a round, not with it", nil)
+	ErrInvalidItemAdjustment = NewValidationError("adjustment", "must be 'void' or 'comp'", nil)
+	ErrUnknownReasonCode     = NewValidationError("reason_code", "must be one of the configured reason codes", nil)
+	ErrInvalidGuestCount     = NewValidationError("guest_count", "must not be negative", nil)
+	ErrInvalidSeat           = NewValidationError("seat", "must be between 1 and the guest count, or 0 for shared items", nil)
 )
 
 // ==========================================
@@ -79,7 +112,45 @@ var (
 	ErrMenuItemNotFound  = NewNotFoundError("menu item", nil)
 	ErrCategoryNotFound  = NewNotFoundError("category", nil)
 	ErrPaymentNotFound   = NewNotFoundError("payment", nil)
+	ErrApprovalNotFound  = NewNotFoundError("approval", nil)
 	ErrOrderItemNotFound = NewNotFoundError("order item", nil)
+	ErrUserNotFound      = NewNotFoundError("user", nil)
+	ErrTerminalNotFound  = NewNotFoundError("terminal", nil)
+	ErrAPIKeyNotFound    = NewNotFoundError("api key", nil)
+	ErrShiftNotFound     = NewNotFoundError("shift", nil)
+	ErrPromotionNotFound = NewNotFoundError("promotion", nil)
+)
+
+// ==========================================
+// Authentication Errors (401 Unauthorized)
+// ==========================================
+
+var (
+	ErrInvalidCredentials = NewUnauthorizedError("invalid credentials")
+	ErrMissingToken       = NewUnauthorizedError("missing authentication token")
+	ErrInvalidToken       = NewUnauthorizedError("invalid or expired token")
+	ErrTokenRevoked       = NewUnauthorizedError("token has been revoked")
+	ErrUserInactive       = NewUnauthorizedError("user account is deactivated")
+	ErrInvalidPin         = NewUnauthorizedError("invalid PIN")
+	ErrInvalidTerminal    = NewUnauthorizedError("terminal is not registered or inactive")
+	ErrSessionLocked      = NewUnauthorizedError("session locked after inactivity, enter PIN to unlock")
+	ErrInvalidOrderToken  = NewUnauthorizedError("invalid or expired order QR code")
+	ErrInvalidManagerPin  = NewUnauthorizedError("invalid manager PIN")
+	ErrInvalidEmailToken  = NewUnauthorizedError("invalid or expired email verification link")
+	ErrInvalidResetToken  = NewUnauthorizedError("invalid or expired password reset link")
+	ErrInvalidAPIKey      = NewUnauthorizedError("invalid, expired or revoked API key")
+)
+
+// ==========================================
+// Authorization Errors (403 Forbidden)
+// ==========================================
+
+var (
+	ErrPermissionDenied  = NewForbiddenError("access", "resource")
+	ErrOrderAccessDenied = NewForbiddenError("access", "order")
+	ErrApprovalRequired  = NewForbiddenError("perform", "this action without manager approval")
+	ErrNotAnApprover     = NewForbiddenError("approve", "manager-only actions")
+	ErrCannotManageRole  = NewForbiddenError("manage", "staff with the same or a higher role")
 )
 
 // ==========================================
@@ -90,9 +161,21 @@ var (
 	ErrDuplicateTableNumber     = NewConflictError("table", "table number already exists")
 	ErrDuplicateCategoryName    = NewConflictError("category", "category name already exists")
 	ErrPaymentAlreadyExists     = NewConflictError("payment", "payment already exists for this order")
+	ErrOrderAlreadyPaid         = NewConflictError("order", "order has already been paid in full")
+	ErrEmailAlreadyVerified     = NewConflictError("email", "email is already verified")
+	ErrDuplicatePromoCode       = NewConflictError("promotion", "promo code already exists")
+	ErrAPIKeyRevoked            = NewConflictError("api key", "api key has been revoked")
+	ErrShiftAlreadyOpen         = NewConflictError("shift", "already clocked in")
+	ErrShiftClosed              = NewConflictError("shift", "shift has already been clocked out")
+	ErrShiftOnBreak             = NewConflictError("shift", "a break is already in progress")
+	ErrShiftNotOnBreak          = NewConflictError("shift", "no break is in progress")
 	ErrTableAlreadyHasOpenOrder = NewConflictError("table", "table already has an open order")
 	ErrOrderItemAlreadyExists   = NewConflictError("order item", "item already exists in order")
 	ErrPromoCodeAlreadyUsed     = NewConflictError("promo code", "promo code has already been used")
+	ErrOrderVersionConflict     = NewConflictError("order", "order was changed on another device")
+	ErrOrderItemVersionConflict = NewConflictError("order item", "order item was changed on another device")
+	ErrIdempotencyKeyInFlight   = NewConflictError("idempotency key", "a request with this key is still being processed")
+	ErrIdempotencyKeyReused     = NewConflictError("idempotency key", "key was already used for a different request")
 )
 
 // ==========================================
@@ -116,6 +199,86 @@ var (
 	ErrCannotModifyClosedOrder = NewBusinessRuleError("cannot modify closed order", map[string]interface{}{
 		"rule": "order_modification",
 	})
+	ErrInvalidOrderTransition = NewBusinessRuleError("order status change is not allowed", map[string]interface{}{
+		"rule": "order_lifecycle",
+	})
+	ErrOrderHasUnservedItems = NewBusinessRuleError("cannot complete order with unserved items", map[string]interface{}{
+		"rule": "order_lifecycle",
+	})
+	ErrCannotCancelPaidOrder = NewBusinessRuleError("cannot cancel order after payment", map[string]interface{}{
+		"rule": "order_lifecycle",
+	})
+	ErrOrderNotInService = NewBusinessRuleError("order is closed or cancelled", map[string]interface{}{
+		"rule": "order_status_check",
+	})
+	ErrOrderAlreadyAtTable = NewBusinessRuleError("order is already at this table", map[string]interface{}{
+		"rule": "order_transfer",
+	})
+	ErrOrderTypeNotSeatable = NewBusinessRuleError("delivery and pickup orders cannot be moved to a table", map[string]interface{}{
+		"rule": "order_transfer",
+	})
+	ErrMergeSameOrder = NewBusinessRuleError("cannot merge an order into itself", map[string]interface{}{
+		"rule": "order_merge",
+	})
+	ErrOrderCancelled = NewBusinessRuleError("cannot take payments for a cancelled order", map[string]interface{}{
+		"rule": "order_status_check",
+	})
+	ErrPaymentExceedsBalance = NewBusinessRuleError("payment exceeds the outstanding balance", map[string]interface{}{
+		"rule": "payment_balance",
+	})
+	ErrPaymentAlreadyRefunded = NewBusinessRuleError("payment has already been refunded", map[string]interface{}{
+		"rule": "payment_refund",
+	})
+
+	// Course Rules
+	ErrItemOnHold = NewBusinessRuleError("item is on hold until its course is fired", map[string]interface{}{
+		"rule": "course_firing",
+	})
+	ErrCannotHoldStartedItem = NewBusinessRuleError("cannot hold an item the kitchen has already started", map[string]interface{}{
+		"rule": "course_firing",
+	})
+
+	// Round Rules
+	ErrEmptyCart = NewBusinessRuleError("cart has no items to submit", map[string]interface{}{
+		"rule": "round_submission",
+	})
+	ErrItemAlreadySubmitted = NewBusinessRuleError("item was already submitted to the kitchen", map[string]interface{}{
+		"rule": "round_submission",
+	})
+	ErrItemNotSubmitted = NewBusinessRuleError("item is still in the cart", map[string]interface{}{
+		"rule": "round_submission",
+	})
+
+	// Seating Rules
+	ErrGuestCountExceedsSeating = NewBusinessRuleError("guest count is more than the table seats", map[string]interface{}{
+		"rule": "table_seating",
+	})
+
+	// Void and Comp Rules
+	ErrItemAlreadyAdjusted = NewBusinessRuleError("item was already voided or comped", map[string]interface{}{
+		"rule": "item_adjustment",
+	})
+	ErrReductionNeedsVoid = NewBusinessRuleError("a submitted item cannot be reduced; void it with a reason code instead", map[string]interface{}{
+		"rule": "item_adjustment",
+	})
+
+	// Approval Rules
+	ErrApprovalNotGranted = NewBusinessRuleError("approval has not been granted", map[string]interface{}{
+		"rule": "approval_status",
+	})
+	ErrApprovalAlreadyDecided = NewBusinessRuleError("approval has already been decided", map[string]interface{}{
+		"rule": "approval_status",
+	})
+	ErrApprovalExpired = NewBusinessRuleError("approval has expired", map[string]interface{}{
+		"rule": "approval_expiry",
+	})
+	ErrApprovalMismatch = NewBusinessRuleError("approval was granted for a different change", map[string]interface{}{
+		"rule": "approval_target",
+	})
+	// Shift Rules
+	ErrNoOpenShift = NewBusinessRuleError("clock in before taking payments", map[string]interface{}{
+		"rule": "open_shift",
+	})
 	ErrMaxOrderItemsExceeded = NewBusinessRuleError("maximum number of order items exceeded", map[string]interface{}{
 		"rule": "order_item_limit",
 	})
@@ -138,6 +301,12 @@ var (
 	ErrMenuItemOutOfStock = NewBusinessRuleError("menu item is currently out of stock", map[string]interface{}{
 		"rule": "stock_availability",
 	})
+	ErrMenuItemUnavailable = NewBusinessRuleError("menu item is no longer available", map[string]interface{}{
+		"rule": "menu_availability",
+	})
+	ErrOptionUnavailable = NewBusinessRuleError("menu option is no longer offered", map[string]interface{}{
+		"rule": "menu_availability",
+	})
 	ErrInsufficientLoyaltyPoints = NewBusinessRuleError("insufficient loyalty points for redemption", map[string]interface{}{
 		"rule": "loyalty_points_check",
 	})
@@ -184,6 +353,9 @@ var (
 	ErrServiceChargeNotApplicable = NewBusinessRuleError("service charge is not applicable", map[string]interface{}{
 		"rule": "service_charge_applicability",
 	})
+	ErrPromoCodeUsedUp = NewBusinessRuleError("promo code has already been used", map[string]interface{}{
+		"rule": "promo_code_usage",
+	})
 
 	// Waiting List Rules
 	ErrWaitingListFull = NewBusinessRuleError("waiting list has reached maximum capacity", map[string]interface{}{
@@ -191,6 +363,15 @@ var (
 	})
 )
 
+// ==========================================
+// Rate Limit Errors (429 Too Many Requests)
+// ==========================================
+
+var (
+	ErrTooManyLoginAttempts = NewRateLimitError("too many failed login attempts, try again later")
+	ErrRateLimitExceeded    = NewRateLimitError("request quota exceeded, try again later")
+)
+
 // ==========================================
 // External Service Errors (503 Service Unavailable)
 // ==========================================
@@ -206,6 +387,15 @@ var (
 // Helper Functions for Context-Specific Errors
 // ==========================================
 
+// Rate Limit Errors with Context
+func ErrTooManyLoginAttemptsWithRetry(retryAfter time.Duration) DomainError {
+	return ErrTooManyLoginAttempts.WithField("retry_after_seconds", int(retryAfter.Seconds()+0.5))
+}
+
+func ErrRateLimitExceededWithRetry(retryAfter time.Duration) DomainError {
+	return ErrRateLimitExceeded.WithField("retry_after_seconds", int(retryAfter.Seconds()+0.5))
+}
+
 // Order Errors with Context
 func ErrOrderNotFoundWithID(orderID int) DomainError {
 	return ErrOrderNotFound.WithField("order_id", orderID)
@@ -215,10 +405,33 @@ func ErrOrderAlreadyClosedWithID(orderID int) DomainError {
 	return ErrOrderAlreadyClosed.WithField("order_id", orderID)
 }
 
+func ErrInvalidOrderTransitionWithStatus(from, to string) DomainError {
+	return ErrInvalidOrderTransition.WithDetails(map[string]interface{}{
+		"from": from,
+		"to":   to,
+	})
+}
+
 func ErrEmptyOrderWithID(orderID int) DomainError {
 	return ErrEmptyOrder.WithField("order_id", orderID)
 }
 
+// ErrOrderVersionConflictWithCurrent returns the conflict with the order as it
+// is now, so the client can merge its changes
+func ErrOrderVersionConflictWithCurrent(version int, current interface{}) DomainError {
+	return ErrOrderVersionConflict.WithDetails(map[string]interface{}{
+		"current_version": version,
+		"current":         current,
+	})
+}
+
+func ErrOrderItemVersionConflictWithCurrent(version int, current interface{}) DomainError {
+	return ErrOrderItemVersionConflict.WithDetails(map[string]interface{}{
+		"current_version": version,
+		"current":         current,
+	})
+}
+
 // Table Errors with Context
 func ErrTableNotFoundWithID(tableID int) DomainError {
 	return ErrTableNotFound.WithField("table_id", tableID)
@@ -368,6 +581,14 @@ func ErrTableCapacityExceededWithContext(tableID int, capacity int, requested in
 	})
 }
 
+// Authorization Errors with Context
+func ErrPermissionDeniedWithContext(role string, permission string) DomainError {
+	return ErrPermissionDenied.WithDetails(map[string]interface{}{
+		"role":       role,
+		"permission": permission,
+	})
+}
+
 // External Service Errors with Context
 func ErrPrinterNotAvailableWithID(printerID string) DomainError {
 	return ErrPrinterNotAvailable.WithField("printer_id", printerID)
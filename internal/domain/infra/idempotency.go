@@ -0,0 +1,31 @@
+package infra
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyStore remembers the responses to requests sent with an
+// idempotency key, so a retried request gets the original response instead
+// of running again. Keys are kept in a shared store so retries may reach any
+// server instance.
+type IdempotencyStore interface {
+	// Claim marks key as in flight for the request with the given
+	// fingerprint, for at most lockTimeout. It returns nil once claimed, or
+	// the record already held for the key.
+	Claim(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (*IdempotencyRecord, error)
+	// Complete stores the response to the request that claimed key
+	Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+	// Release forgets key so the request may be sent again
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyRecord is a request claimed under an idempotency key and, once
+// it completed, its response
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"` // hash of the request, to catch keys reused for another request
+	Completed   bool   `json:"completed"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
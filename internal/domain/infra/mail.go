@@ -0,0 +1,15 @@
+package infra
+
+import "context"
+
+// MailSender delivers email to users
+type MailSender interface {
+	Send(ctx context.Context, message *MailMessage) error
+}
+
+// MailMessage is a plain-text email
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
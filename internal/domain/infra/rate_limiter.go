@@ -0,0 +1,25 @@
+package infra
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimiter counts hits per key over a sliding time window. Hits are kept
+// in a shared store so limits hold across server instances.
+type RateLimiter interface {
+	// Allow records a hit for key unless the key already reached limit hits
+	// within the window
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error)
+	// Peek reports the state of key without recording a hit
+	Peek(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error)
+	// Reset forgets all hits recorded for key
+	Reset(ctx context.Context, key string) error
+}
+
+// RateLimitResult describes a key's position within its limit
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration // zero until the key reaches its limit
+}
@@ -0,0 +1,21 @@
+package infra
+
+import "time"
+
+// TokenService issues and verifies signed access tokens
+type TokenService interface {
+	// GenerateAccessToken signs the claims and returns the encoded token
+	GenerateAccessToken(claims *TokenClaims) (string, time.Time, error)
+	// ParseAccessToken verifies the token signature and expiry and returns its claims
+	ParseAccessToken(token string) (*TokenClaims, error)
+}
+
+// TokenClaims represents the identity carried by an access token
+type TokenClaims struct {
+	UserID     int
+	Role       string
+	SessionID  string
+	TerminalID int // zero unless the session was opened by PIN on a shared terminal
+	IssuedAt   time.Time
+	ExpiresAt  time.Time
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/vo"
 )
 
 // เพิ่ม interface ใหม่
@@ -48,6 +49,10 @@ type OrderItemOptionRepository interface {
 // อัปเดต Repository interface
 type Repository interface {
 	UserRepository() UserRepository
+	RefreshTokenRepository() RefreshTokenRepository
+	UserTokenRepository() UserTokenRepository
+	TerminalRepository() TerminalRepository
+	APIKeyRepository() APIKeyRepository
 	CategoryRepository() CategoryRepository
 	MenuItemRepository() MenuItemRepository
 	MenuOptionRepository() MenuOptionRepository
@@ -56,10 +61,15 @@ type Repository interface {
 	TableRepository() TableRepository
 	OrderRepository() OrderRepository
 	OrderItemRepository() OrderItemRepository
+	OrderStatusHistoryRepository() OrderStatusHistoryRepository
 	OrderItemOptionRepository() OrderItemOptionRepository
 	PaymentRepository() PaymentRepository
+	PromotionRepository() PromotionRepository
 	RevenueRepository() RevenueRepository
 	KitchenStationRepository() KitchenStationRepository
+	AuditLogRepository() AuditLogRepository
+	ApprovalRepository() ApprovalRepository
+	ShiftRepository() ShiftRepository
 	TxManager() TxManager
 }
 
@@ -79,6 +89,92 @@ type UserRepository interface {
 	UpdateLastLogin(ctx context.Context, id int) error
 }
 
+// TerminalRepository handles registered POS terminals
+type TerminalRepository interface {
+	Create(ctx context.Context, terminal *entity.Terminal) (*entity.Terminal, error)
+	GetByID(ctx context.Context, id int) (*entity.Terminal, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Terminal, error)
+	Update(ctx context.Context, terminal *entity.Terminal) (*entity.Terminal, error)
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context) ([]*entity.Terminal, error)
+	UpdateLastUsed(ctx context.Context, id int) error
+}
+
+// APIKeyRepository handles API keys for integrations
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error)
+	GetByID(ctx context.Context, id int) (*entity.APIKey, error)
+	GetByKeyHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	Update(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error)
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	UpdateLastUsed(ctx context.Context, id int) error
+}
+
+// RefreshTokenRepository handles refresh token persistence
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	Revoke(ctx context.Context, id int) error
+	RevokeBySession(ctx context.Context, sessionID string) error
+	RevokeByTerminal(ctx context.Context, terminalID int) error
+	RevokeAllByUser(ctx context.Context, userID int) error
+	HasActiveSession(ctx context.Context, sessionID string) (bool, error)
+}
+
+// UserTokenRepository handles email verification and password reset tokens
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *entity.UserToken) (*entity.UserToken, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.UserToken, error)
+	// MarkUsed reports false when the token was already used
+	MarkUsed(ctx context.Context, id int) (bool, error)
+	InvalidateByUser(ctx context.Context, userID int, purpose vo.TokenPurpose) error
+}
+
+// AuditLogFilter narrows audit log queries; zero values are ignored
+type AuditLogFilter struct {
+	ActorID    *int
+	Action     string
+	EntityType string
+	EntityID   int
+	From       *time.Time
+	To         *time.Time
+}
+
+// AuditLogRepository persists the append-only audit trail
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *entity.AuditLog) (*entity.AuditLog, error)
+	List(ctx context.Context, filter AuditLogFilter, limit, offset int) ([]*entity.AuditLog, error)
+	Count(ctx context.Context, filter AuditLogFilter) (int, error)
+}
+
+// ApprovalRepository handles manager approvals
+type ApprovalRepository interface {
+	Create(ctx context.Context, approval *entity.Approval) (*entity.Approval, error)
+	GetByID(ctx context.Context, id int) (*entity.Approval, error)
+	// Update saves the approval if its status is still from, the one it was read with
+	Update(ctx context.Context, approval *entity.Approval, from vo.ApprovalStatus) (*entity.Approval, error)
+	ListByStatus(ctx context.Context, status string, limit, offset int) ([]*entity.Approval, error)
+	CountByStatus(ctx context.Context, status string) (int, error)
+}
+
+// ShiftFilter narrows shift queries; zero values are ignored
+type ShiftFilter struct {
+	UserID *int
+	From   *time.Time
+	To     *time.Time
+}
+
+// ShiftRepository handles staff shifts and their breaks
+type ShiftRepository interface {
+	Create(ctx context.Context, shift *entity.Shift) (*entity.Shift, error)
+	GetByID(ctx context.Context, id int) (*entity.Shift, error)
+	GetOpenByUser(ctx context.Context, userID int) (*entity.Shift, error)
+	Update(ctx context.Context, shift *entity.Shift) (*entity.Shift, error)
+	List(ctx context.Context, filter ShiftFilter, limit, offset int) ([]*entity.Shift, error)
+	Count(ctx context.Context, filter ShiftFilter) (int, error)
+	SaveBreak(ctx context.Context, shiftBreak *entity.ShiftBreak) (*entity.ShiftBreak, error)
+}
+
 // CategoryRepository handles category operations
 type CategoryRepository interface {
 	Create(ctx context.Context, category *entity.Category) (*entity.Category, error)
@@ -105,6 +201,8 @@ type MenuItemRepository interface {
 type TableRepository interface {
 	Create(ctx context.Context, table *entity.Table) (*entity.Table, error)
 	GetByID(ctx context.Context, id int) (*entity.Table, error)
+	// GetByIDForUpdate reads the table and locks it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id int) (*entity.Table, error)
 	GetByNumber(ctx context.Context, number int) (*entity.Table, error)
 	GetByQRCode(ctx context.Context, qrCode string) (*entity.Table, error)
 	Update(ctx context.Context, table *entity.Table) (*entity.Table, error)
@@ -117,6 +215,8 @@ type TableRepository interface {
 type OrderRepository interface {
 	Create(ctx context.Context, order *entity.Order) (*entity.Order, error)
 	GetByID(ctx context.Context, id int) (*entity.Order, error)
+	// GetByIDForUpdate reads the order and locks it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id int) (*entity.Order, error)
 	GetByIDWithItems(ctx context.Context, id int) (*entity.Order, error)
 	Update(ctx context.Context, order *entity.Order) (*entity.Order, error)
 	Delete(ctx context.Context, id int) error
@@ -126,12 +226,15 @@ type OrderRepository interface {
 	GetOpenOrderByTable(ctx context.Context, tableID int) (*entity.Order, error)
 	GetOrderByQRCode(ctx context.Context, qrCode string) (*entity.Order, error)
 	ListByStatus(ctx context.Context, status string, limit, offset int) ([]*entity.Order, error)
+	ListByOrderType(ctx context.Context, orderType string, limit, offset int) ([]*entity.Order, error)
 	ListByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) ([]*entity.Order, error)
+	NextQueueNumber(ctx context.Context, day time.Time) (int, error)
 	Count(ctx context.Context) (int, error)
 	CountByStatus(ctx context.Context, status string) (int, error)
 	CountByTable(ctx context.Context, tableID int) (int, error)
 	CountByDateRange(ctx context.Context, startDate, endDate time.Time) (int, error)
 	GetOrderIDByQRCode(ctx context.Context, qrCode string) (int, error)
+	ListIDsHandledBy(ctx context.Context, userID int, startDate, endDate time.Time) ([]int, error)
 }
 
 // OrderItemRepository handles order item operations
@@ -140,21 +243,51 @@ type OrderItemRepository interface {
 	GetByID(ctx context.Context, id int) (*entity.OrderItem, error)
 	Update(ctx context.Context, item *entity.OrderItem) (*entity.OrderItem, error)
 	Delete(ctx context.Context, id int) error
-	ListByOrder(ctx context.Context, orderID int) ([]*entity.OrderItem, error)
+	ListByOrder(ctx context.Context, orderID int) ([]*entity.OrderItem, error) // submitted items only
+	ListCartByOrder(ctx context.Context, orderID int) ([]*entity.OrderItem, error)
 	DeleteByOrder(ctx context.Context, orderID int) error
 	GetByOrderAndItem(ctx context.Context, orderID, itemID int) (*entity.OrderItem, error)
+	MoveToOrder(ctx context.Context, fromOrderID, toOrderID, roundOffset, seatOffset int) error // submitted items only
+}
+
+// OrderStatusHistoryRepository stores the status changes of orders
+type OrderStatusHistoryRepository interface {
+	Create(ctx context.Context, change *entity.OrderStatusChange) (*entity.OrderStatusChange, error)
+	ListByOrder(ctx context.Context, orderID int) ([]*entity.OrderStatusChange, error)
+}
+
+// PromotionRepository handles promotions and the promotion lines recorded on
+// the final bills of orders
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *entity.Promotion) (*entity.Promotion, error)
+	GetByID(ctx context.Context, id int) (*entity.Promotion, error)
+	GetByCode(ctx context.Context, code string) (*entity.Promotion, error)
+	Update(ctx context.Context, promotion *entity.Promotion) (*entity.Promotion, error)
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, onlyActive bool, limit, offset int) ([]*entity.Promotion, error)
+	// CountUsage counts the orders, other than excludeOrderID, whose bill used the promotion or that hold its code
+	CountUsage(ctx context.Context, promotionID, excludeOrderID int) (int, error)
+	// LockUsage locks the promotion until the transaction ends, so its usage is counted and taken by one order at a time
+	LockUsage(ctx context.Context, promotionID int) error
+	ReplaceOrderPromotions(ctx context.Context, orderID int, promotions []entity.AppliedPromotion) error
+	ListOrderPromotions(ctx context.Context, orderID int) ([]entity.AppliedPromotion, error)
 }
 
 // PaymentRepository handles payment operations
 type PaymentRepository interface {
 	Create(ctx context.Context, payment *entity.Payment) (*entity.Payment, error)
 	GetByID(ctx context.Context, id int) (*entity.Payment, error)
-	GetByOrderID(ctx context.Context, orderID int) (*entity.Payment, error)
-	Update(ctx context.Context, payment *entity.Payment) (*entity.Payment, error)
+	// GetByIDForUpdate reads the payment and locks it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id int) (*entity.Payment, error)
+	ListByOrderID(ctx context.Context, orderID int) ([]*entity.Payment, error)
+	// Refund records a refund unless the payment was already refunded
+	Refund(ctx context.Context, payment *entity.Payment) (*entity.Payment, error)
 	Delete(ctx context.Context, id int) error
 	List(ctx context.Context, limit, offset int) ([]*entity.Payment, error)
 	ListByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) ([]*entity.Payment, error)
 	ListByMethod(ctx context.Context, method string, limit, offset int) ([]*entity.Payment, error)
+	ListByShift(ctx context.Context, shiftID int) ([]*entity.Payment, error)
+	MoveToOrder(ctx context.Context, fromOrderID, toOrderID int) error
 }
 
 // RevenueRepository handles revenue reporting
@@ -164,6 +297,12 @@ type RevenueRepository interface {
 	GetDailyRevenueRange(ctx context.Context, startDate, endDate time.Time) ([]*entity.DailyRevenue, error)
 	GetMonthlyRevenueRange(ctx context.Context, startDate, endDate time.Time) ([]*entity.MonthlyRevenue, error)
 	GetTotalRevenue(ctx context.Context, startDate, endDate time.Time) (float64, error)
+	GetPartialRevenue(ctx context.Context, startDate, endDate time.Time) (float64, error)
+	GetRevenueByOrderType(ctx context.Context, startDate, endDate time.Time) ([]*entity.OrderTypeRevenue, error)
+	GetPromotionRevenue(ctx context.Context, startDate, endDate time.Time) ([]*entity.PromotionRevenue, error)
+	GetItemDiscountRevenue(ctx context.Context, startDate, endDate time.Time) ([]*entity.ItemDiscountRevenue, error)
+	GetItemAdjustmentRevenue(ctx context.Context, startDate, endDate time.Time) ([]*entity.ItemAdjustmentRevenue, error)
+	GetCoverRevenue(ctx context.Context, startDate, endDate time.Time) (*entity.CoverRevenue, error)
 }
 
 type KitchenStationRepository interface {
@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"codeberg.org/go-pdf/fpdf"
 	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/entity"
@@ -15,19 +19,25 @@ import (
 	"github.com/skip2/go-qrcode"
 )
 
-const (
-	TAX_RATE      = 0.07 // 7% VAT
-	DISCOUNT_RATE = 0.10 // 10% discount
-)
-
 // OrderService provides domain logic for orders
 type OrderService interface {
 	// ValidateOrderCreation validates if order can be created for table
 	ValidateOrderCreation(ctx context.Context, tableID int) error
 
-	// CalculateOrderTotal calculates total amount for order
+	// PriceOrder prices the order bill and records the breakdown on the order
+	PriceOrder(ctx context.Context, order *entity.Order) (*entity.PriceBreakdown, error)
+
+	// CalculateOrderTotal calculates the amount payable for order
 	CalculateOrderTotal(ctx context.Context, order *entity.Order) (vo.Money, error)
 
+	// CalculateItemAmount calculates what an order item is billed, options
+	// included, as it would be without a void or comp
+	CalculateItemAmount(ctx context.Context, item *entity.OrderItem) vo.Money
+
+	// CalculateItemTotals calculates each order item's share of the amount
+	// payable, keyed by item ID
+	CalculateItemTotals(ctx context.Context, order *entity.Order) (map[int]vo.Money, error)
+
 	// ValidateOrderItem validates order item before adding
 	ValidateOrderItem(ctx context.Context, orderID, itemID int, quantity int) error
 
@@ -36,7 +46,13 @@ type OrderService interface {
 
 	ReceiptPdf(ctx context.Context, order *entity.Order) ([]byte, error)
 
+	// PaymentReceiptPdf renders the receipt for one payment of a split bill
+	PaymentReceiptPdf(ctx context.Context, order *entity.Order, payment *entity.Payment, balance vo.Money) ([]byte, error)
+
 	QRCodePdf(ctx context.Context, receipt *entity.Order) ([]byte, error)
+
+	// KitchenTicketPdf renders the ticket sending order items to one kitchen station
+	KitchenTicketPdf(ctx context.Context, order *entity.Order, station string, items []*entity.OrderItem) ([]byte, error)
 }
 
 type orderService struct {
@@ -47,6 +63,8 @@ type orderService struct {
 	optionValueRepo     repository.OptionValueRepository
 	tableRepo           repository.TableRepository
 	menuItemRepo        repository.MenuItemRepository
+	promotionRepo       repository.PromotionRepository
+	pricing             entity.PricingPolicy
 }
 
 func NewOrderService(
@@ -57,6 +75,8 @@ func NewOrderService(
 	optionValueRepo repository.OptionValueRepository,
 	tableRepo repository.TableRepository,
 	menuItemRepo repository.MenuItemRepository,
+	promotionRepo repository.PromotionRepository,
+	pricing entity.PricingPolicy,
 ) OrderService {
 	return &orderService{
 		orderRepo:           orderRepo,
@@ -66,6 +86,8 @@ func NewOrderService(
 		optionValueRepo:     optionValueRepo,
 		tableRepo:           tableRepo,
 		menuItemRepo:        menuItemRepo,
+		promotionRepo:       promotionRepo,
+		pricing:             pricing,
 	}
 }
 
@@ -91,41 +113,139 @@ func (s *orderService) ValidateOrderCreation(ctx context.Context, tableID int) e
 	return nil
 }
 
-func (s *orderService) CalculateOrderTotal(ctx context.Context, order *entity.Order) (vo.Money, error) {
+func (s *orderService) PriceOrder(ctx context.Context, order *entity.Order) (*entity.PriceBreakdown, error) {
+	breakdown, _, err := s.priceOrder(ctx, order)
+	return breakdown, err
+}
+
+// priceOrder prices the order bill, also returning the amount of each item
+// including options
+func (s *orderService) priceOrder(ctx context.Context, order *entity.Order) (*entity.PriceBreakdown, map[int]vo.Money, error) {
 	if order == nil {
-		return vo.Money{}, errs.ErrOrderNotFound
+		return nil, nil, errs.ErrOrderNotFound
 	}
 
-	// Get order items if not loaded
-	if order.Items == nil {
-		items, err := s.orderItemRepo.ListByOrder(ctx, order.ID)
-		if err != nil {
-			return vo.Money{}, fmt.Errorf("failed to get order items: %w", err)
-		}
-		order.Items = items
+	if err := s.loadOrderItemsWithOptions(ctx, order); err != nil {
+		return nil, nil, err
 	}
 
-	// Calculate total including options
-	total, _ := vo.NewMoneyFromSatang(0)
+	// Subtotal including options
+	subtotal, _ := vo.NewMoneyFromSatang(0)
+	amounts := make(map[int]vo.Money, len(order.Items))
 	for _, item := range order.Items {
-		itemSubtotal := item.CalculateSubtotal()
+		amounts[item.ID] = s.itemTotal(ctx, item)
+		subtotal = subtotal.Add(amounts[item.ID])
+	}
+
+	promotions, err := s.applyPromotions(ctx, order, amounts, subtotal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	breakdown := s.pricing.Price(order.OrderType, subtotal, promotions)
+	order.ApplyPricing(breakdown)
+	return breakdown, amounts, nil
+}
 
-		// Add option prices
-		options, err := s.orderItemOptionRepo.GetByOrderItemID(ctx, item.ID)
-		if err == nil { // Don't fail if options can't be loaded
-			for _, option := range options {
-				itemSubtotal = itemSubtotal.Add(option.AdditionalPrice.Multiply(float64(item.Quantity)))
+// applyPromotions works out the promotion lines of the order bill from the
+// active promotions
+func (s *orderService) applyPromotions(ctx context.Context, order *entity.Order, amounts map[int]vo.Money, subtotal vo.Money) ([]entity.AppliedPromotion, error) {
+	promotions, err := s.promotionRepo.List(ctx, true, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+	if len(promotions) == 0 {
+		return nil, nil
+	}
+
+	// Limited promotions count the other orders that used them, so pricing
+	// the same order again does not use it up
+	for _, promotion := range promotions {
+		if promotion.UsageLimit > 0 {
+			promotion.UsageCount, err = s.promotionRepo.CountUsage(ctx, promotion.ID, order.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count promotion usage: %w", err)
 			}
 		}
+	}
 
-		total = total.Add(itemSubtotal)
+	lines := make([]entity.PromotionLine, 0, len(order.Items))
+	categories := make(map[int]int)
+	for _, item := range order.Items {
+		// Voided and comped items do not count towards promotions
+		if item.IsAdjusted() {
+			continue
+		}
+		categoryID, ok := categories[item.ItemID]
+		if !ok {
+			menuItem, err := s.menuItemRepo.GetByID(ctx, item.ItemID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get menu item: %w", err)
+			}
+			if menuItem != nil {
+				categoryID = menuItem.CategoryID
+			}
+			categories[item.ItemID] = categoryID
+		}
+		lines = append(lines, entity.PromotionLine{
+			OrderItemID: item.ID,
+			MenuItemID:  item.ItemID,
+			CategoryID:  categoryID,
+			Quantity:    item.Quantity,
+			Amount:      amounts[item.ID],
+			OrderedAt:   item.CreatedAt,
+		})
+	}
+
+	return entity.ApplyPromotions(promotions, order.CouponCode, order.CreatedAt, lines, subtotal), nil
+}
+
+func (s *orderService) CalculateOrderTotal(ctx context.Context, order *entity.Order) (vo.Money, error) {
+	breakdown, err := s.PriceOrder(ctx, order)
+	if err != nil {
+		return vo.Money{}, err
+	}
+	return breakdown.Total, nil
+}
+
+func (s *orderService) CalculateItemTotals(ctx context.Context, order *entity.Order) (map[int]vo.Money, error) {
+	breakdown, amounts, err := s.priceOrder(ctx, order)
+	if err != nil {
+		return nil, err
 	}
 
-	return total, nil
+	// Discount, service charge and VAT are shared out in proportion to each
+	// item's amount, so paying for every item settles the bill exactly
+	return breakdown.Allocate(amounts), nil
+}
+
+// itemTotal calculates the amount of an order item including option prices.
+// Voided and comped items are billed nothing, options included.
+func (s *orderService) itemTotal(ctx context.Context, item *entity.OrderItem) vo.Money {
+	if item.IsAdjusted() {
+		m, _ := vo.NewMoneyFromSatang(0)
+		return m
+	}
+	return s.CalculateItemAmount(ctx, item)
+}
+
+func (s *orderService) CalculateItemAmount(ctx context.Context, item *entity.OrderItem) vo.Money {
+	itemSubtotal := item.NetAmount()
+
+	// Add option prices
+	options, err := s.orderItemOptionRepo.GetByOrderItemID(ctx, item.ID)
+	if err == nil { // Don't fail if options can't be loaded
+		for _, option := range options {
+			itemSubtotal = itemSubtotal.Add(option.AdditionalPrice.Multiply(float64(item.Quantity)))
+		}
+	}
+
+	return itemSubtotal
 }
 
 func (s *orderService) ValidateOrderItem(ctx context.Context, orderID, itemID int, quantity int) error {
-	// Check if order exists and is open
+	// Check if order exists and still takes items; ordered orders take
+	// further rounds
 	order, err := s.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
 		return fmt.Errorf("failed to get order: %w", err)
@@ -133,7 +253,7 @@ func (s *orderService) ValidateOrderItem(ctx context.Context, orderID, itemID in
 	if order == nil {
 		return errs.ErrOrderNotFound
 	}
-	if !order.IsOpen() {
+	if !order.InService() {
 		return errs.ErrOrderNotOpen
 	}
 
@@ -145,6 +265,9 @@ func (s *orderService) ValidateOrderItem(ctx context.Context, orderID, itemID in
 	if menuItem == nil {
 		return errs.ErrMenuItemNotFound
 	}
+	if !menuItem.IsActive {
+		return errs.ErrMenuItemUnavailable
+	}
 
 	// Validate quantity
 	if quantity <= 0 {
@@ -184,12 +307,32 @@ func (s *orderService) ReceiptPdf(ctx context.Context, order *entity.Order) ([]b
 	if order == nil {
 		return nil, errs.ErrOrderNotFound
 	}
+	breakdown, err := s.PriceOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
 	w := &bytes.Buffer{}
-	if err := s.generateReceiptPDF(order, w); err != nil {
+	if err := s.generateReceiptPDF(order, breakdown, w); err != nil {
 		return nil, fmt.Errorf("failed to generate receipt PDF: %w", err)
 	}
 	return w.Bytes(), nil
 }
+func (s *orderService) PaymentReceiptPdf(ctx context.Context, order *entity.Order, payment *entity.Payment, balance vo.Money) ([]byte, error) {
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	if payment == nil {
+		return nil, errs.ErrPaymentNotFound
+	}
+	if err := s.loadOrderItemsWithOptions(ctx, order); err != nil {
+		return nil, err
+	}
+	w := &bytes.Buffer{}
+	if err := s.generatePaymentReceiptPDF(ctx, order, payment, balance, w); err != nil {
+		return nil, fmt.Errorf("failed to generate payment receipt PDF: %w", err)
+	}
+	return w.Bytes(), nil
+}
 func (s *orderService) QRCodePdf(ctx context.Context, receipt *entity.Order) ([]byte, error) {
 	if receipt == nil {
 		return nil, errs.ErrOrderNotFound
@@ -200,6 +343,16 @@ func (s *orderService) QRCodePdf(ctx context.Context, receipt *entity.Order) ([]
 	}
 	return w.Bytes(), nil
 }
+func (s *orderService) KitchenTicketPdf(ctx context.Context, order *entity.Order, station string, items []*entity.OrderItem) ([]byte, error) {
+	if order == nil {
+		return nil, errs.ErrOrderNotFound
+	}
+	w := &bytes.Buffer{}
+	if err := s.generateKitchenTicketPDF(ctx, order, station, items, w); err != nil {
+		return nil, fmt.Errorf("failed to generate kitchen ticket PDF: %w", err)
+	}
+	return w.Bytes(), nil
+}
 
 // Helper struct to hold item with its options
 type ItemWithOptions struct {
@@ -258,7 +411,7 @@ func (s *orderService) getItemOptions(ctx context.Context, itemID int) ([]Option
 	return optionDetails, nil
 }
 
-func (s *orderService) generateReceiptPDF(order *entity.Order, writer io.Writer) error {
+func (s *orderService) generateReceiptPDF(order *entity.Order, breakdown *entity.PriceBreakdown, writer io.Writer) error {
 	pdf := fpdf.NewCustom(&fpdf.InitType{
 		OrientationStr: "P",
 		UnitStr:        "mm",
@@ -290,24 +443,46 @@ func (s *orderService) generateReceiptPDF(order *entity.Order, writer io.Writer)
 	pdf.SetFont("NotoSansThai", "", 8)
 	pdf.CellFormat(0, 5, fmt.Sprintf("เลขที่: %d", order.ID), "", 1, "L", false, 0, "")
 	pdf.CellFormat(0, 5, fmt.Sprintf("วันที่: %s", order.CreatedAt.Format("02/01/2006 15:04")), "", 1, "L", false, 0, "")
-	pdf.CellFormat(0, 5, fmt.Sprintf("โต๊ะ: %d", order.TableID), "", 1, "L", false, 0, "")
+	writeOrderHeading(pdf, order)
 	pdf.Ln(2)
 	pdf.Line(0, pdf.GetY(), 80, pdf.GetY())
 	pdf.Ln(2)
 
-	// Items with options
+	// Items with options, by seat when they were ordered for seats
 	ctx := context.Background()
-	var totalWithOptions float64
 
-	for _, item := range order.Items {
+	items := order.Items
+	bySeat := entity.HasSeats(items)
+	if bySeat {
+		items = entity.SortBySeat(items)
+	}
+	seat := -1
+	for _, item := range items {
+		// Voided items are off the bill
+		if item.IsVoided() {
+			continue
+		}
+
+		if bySeat && item.Seat != seat {
+			pdf.SetFont("NotoSansThai", "B", 8)
+			pdf.CellFormat(0, 5, seatLabel(item.Seat), "", 1, "L", false, 0, "")
+			seat = item.Seat
+		}
+
 		// Main item
 		itemPrice := item.UnitPrice.AmountBaht()
-		itemSubtotal := float64(item.Quantity) * itemPrice
+		itemSubtotal := item.GrossAmount().AmountBaht()
 
 		pdf.SetFont("NotoSansThai", "", 8)
 		pdf.CellFormat(0, 4, item.Name, "", 1, "L", false, 0, "")
 		pdf.CellFormat(0, 4, fmt.Sprintf("  %d x %.2f บาท = %.2f บาท",
 			item.Quantity, itemPrice, itemSubtotal), "", 1, "L", false, 0, "")
+		if markdown := item.Markdown(); !markdown.IsZero() {
+			pdf.CellFormat(0, 4, fmt.Sprintf("  ส่วนลด %.0f%%: -%.2f บาท", item.DiscountPercent, markdown.AmountBaht()), "", 1, "L", false, 0, "")
+		}
+		if !item.Discount.IsZero() {
+			pdf.CellFormat(0, 4, fmt.Sprintf("  ส่วนลดพิเศษ: -%.2f บาท", item.Discount.AmountBaht()), "", 1, "L", false, 0, "")
+		}
 
 		// Get and display options
 		options, err := s.getItemOptions(ctx, item.ID)
@@ -320,15 +495,17 @@ func (s *orderService) generateReceiptPDF(order *entity.Order, writer io.Writer)
 				if optionPrice > 0 {
 					pdf.CellFormat(0, 3, fmt.Sprintf("    + %s: %s (+%.2f บาท x%d = +%.2f บาท)",
 						opt.Option.Name, opt.Value.Name, optionPrice, item.Quantity, optionTotal), "", 1, "L", false, 0, "")
-					itemSubtotal += optionTotal
 				} else {
 					pdf.CellFormat(0, 3, fmt.Sprintf("    + %s: %s",
 						opt.Option.Name, opt.Value.Name), "", 1, "L", false, 0, "")
 				}
 			}
 		}
+		if item.IsComped() {
+			pdf.SetFont("NotoSansThai", "", 8)
+			pdf.CellFormat(0, 4, fmt.Sprintf("  ฟรี (%s): -%.2f บาท", item.AdjustmentReason, item.AdjustedAmount.AmountBaht()), "", 1, "L", false, 0, "")
+		}
 
-		totalWithOptions += itemSubtotal
 		pdf.Ln(1)
 	}
 
@@ -336,26 +513,111 @@ func (s *orderService) generateReceiptPDF(order *entity.Order, writer io.Writer)
 	pdf.Line(0, pdf.GetY(), 80, pdf.GetY())
 	pdf.Ln(2)
 
-	// Summary with corrected calculations
-	subtotal, _ := vo.NewMoneyFromBaht(totalWithOptions)
-	discount := subtotal.Multiply(DISCOUNT_RATE)
-	afterDiscount, _ := subtotal.Subtract(discount)
-	tax := afterDiscount.Multiply(TAX_RATE)
-	finalTotal := afterDiscount.Add(tax)
-
+	// Summary, priced the same way as the amount payable
 	pdf.SetFont("NotoSansThai", "", 8)
-	pdf.CellFormat(0, 5, fmt.Sprintf("ยอดรวม: %.2f บาท", subtotal.AmountBaht()), "", 1, "R", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("ยอดรวม: %.2f บาท", breakdown.Subtotal.AmountBaht()), "", 1, "R", false, 0, "")
+
+	for _, promotion := range breakdown.Promotions {
+		pdf.CellFormat(0, 5, fmt.Sprintf("%s: -%.2f บาท", promotion.Name, promotion.Amount.AmountBaht()), "", 1, "R", false, 0, "")
+	}
 
-	if discount.AmountBaht() > 0 {
-		pdf.CellFormat(0, 5, fmt.Sprintf("ส่วนลด %.0f%%: -%.2f บาท", DISCOUNT_RATE*100, discount.AmountBaht()), "", 1, "R", false, 0, "")
+	if !breakdown.RuleDiscount.IsZero() {
+		pdf.CellFormat(0, 5, fmt.Sprintf("ส่วนลด %.0f%%: -%.2f บาท", breakdown.DiscountRate*100, breakdown.RuleDiscount.AmountBaht()), "", 1, "R", false, 0, "")
 	}
 
-	if tax.AmountBaht() > 0 {
-		pdf.CellFormat(0, 5, fmt.Sprintf("VAT %.0f%%: %.2f บาท", TAX_RATE*100, tax.AmountBaht()), "", 1, "R", false, 0, "")
+	if !breakdown.ServiceCharge.IsZero() {
+		pdf.CellFormat(0, 5, fmt.Sprintf("ค่าบริการ %.0f%%: %.2f บาท", breakdown.ServiceChargeRate*100, breakdown.ServiceCharge.AmountBaht()), "", 1, "R", false, 0, "")
+	}
+
+	if !breakdown.TaxAmount.IsZero() {
+		if breakdown.VATInclusive {
+			pdf.CellFormat(0, 5, fmt.Sprintf("รวม VAT %.0f%%: %.2f บาท", breakdown.VATRate*100, breakdown.TaxAmount.AmountBaht()), "", 1, "R", false, 0, "")
+		} else {
+			pdf.CellFormat(0, 5, fmt.Sprintf("VAT %.0f%%: %.2f บาท", breakdown.VATRate*100, breakdown.TaxAmount.AmountBaht()), "", 1, "R", false, 0, "")
+		}
+	}
+
+	pdf.SetFont("NotoSansThai", "B", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("ยอดสุทธิ: %.2f บาท", breakdown.Total.AmountBaht()), "", 1, "R", false, 0, "")
+	pdf.Ln(4)
+
+	// Footer
+	pdf.SetFont("NotoSansThai", "", 8)
+	pdf.CellFormat(0, 5, "ขอบคุณที่ใช้บริการ", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, "Thank you for your business!", "", 1, "C", false, 0, "")
+
+	return pdf.Output(writer)
+}
+
+// generatePaymentReceiptPDF renders one split of a bill: the items it covers
+// when split by item, the amount and method paid, and what is left to pay
+func (s *orderService) generatePaymentReceiptPDF(ctx context.Context, order *entity.Order, payment *entity.Payment, balance vo.Money, writer io.Writer) error {
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "mm",
+		SizeStr:        "",
+		Size: fpdf.SizeType{
+			Wd: 80,  // 80mm width
+			Ht: 200, // split receipts list fewer items
+		},
+	})
+	pdf.AddPage()
+
+	// Add Thai font
+	pdf.AddUTF8Font("NotoSansThai", "", `E:\h_lab\go\poc_pos_restaurant\font\NotoSansThai-Regular.ttf`)
+	pdf.AddUTF8Font("NotoSansThai", "B", `E:\h_lab\go\poc_pos_restaurant\font\NotoSansThai-Bold.ttf`)
+
+	pdf.SetLeftMargin(5)
+	pdf.SetRightMargin(5)
+
+	// Header
+	pdf.SetFont("NotoSansThai", "B", 12)
+	pdf.CellFormat(0, 6, "ใบเสร็จรับเงิน (แยกชำระ)", "", 1, "C", false, 0, "")
+	pdf.SetFont("NotoSansThai", "", 9)
+	pdf.CellFormat(0, 5, "ร้านอาหารดีเลิศ", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, "123 ถนนสุขุมวิท กรุงเทพฯ 10110", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, "โทร: 02-123-4567", "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+
+	// Receipt info
+	pdf.SetFont("NotoSansThai", "", 8)
+	pdf.CellFormat(0, 5, fmt.Sprintf("เลขที่: %d-%d", order.ID, payment.ID), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("วันที่: %s", payment.PaidAt.Format("02/01/2006 15:04")), "", 1, "L", false, 0, "")
+	writeOrderHeading(pdf, order)
+	pdf.Ln(2)
+	pdf.Line(0, pdf.GetY(), 80, pdf.GetY())
+	pdf.Ln(2)
+
+	// Items covered by this payment
+	if len(payment.OrderItemIDs) > 0 {
+		covered := make(map[int]bool, len(payment.OrderItemIDs))
+		for _, itemID := range payment.OrderItemIDs {
+			covered[itemID] = true
+		}
+
+		pdf.SetFont("NotoSansThai", "", 8)
+		for _, item := range order.Items {
+			if !covered[item.ID] {
+				continue
+			}
+			pdf.CellFormat(0, 4, item.Name, "", 1, "L", false, 0, "")
+			pdf.CellFormat(0, 4, fmt.Sprintf("  %d x %.2f บาท = %.2f บาท",
+				item.Quantity, item.UnitPrice.AmountBaht(), s.itemTotal(ctx, item).AmountBaht()), "", 1, "L", false, 0, "")
+			pdf.Ln(1)
+		}
+
+		pdf.Ln(2)
+		pdf.Line(0, pdf.GetY(), 80, pdf.GetY())
+		pdf.Ln(2)
 	}
 
+	// Payment
+	pdf.SetFont("NotoSansThai", "", 8)
+	pdf.CellFormat(0, 5, fmt.Sprintf("ชำระโดย: %s", payment.Method.String()), "", 1, "R", false, 0, "")
 	pdf.SetFont("NotoSansThai", "B", 10)
-	pdf.CellFormat(0, 6, fmt.Sprintf("ยอดสุทธิ: %.2f บาท", finalTotal.AmountBaht()), "", 1, "R", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("ยอดชำระ: %.2f บาท", payment.Amount.AmountBaht()), "", 1, "R", false, 0, "")
+	pdf.SetFont("NotoSansThai", "", 8)
+	pdf.CellFormat(0, 5, fmt.Sprintf("ยอดคงเหลือ: %.2f บาท", balance.AmountBaht()), "", 1, "R", false, 0, "")
 	pdf.Ln(4)
 
 	// Footer
@@ -365,6 +627,160 @@ func (s *orderService) generateReceiptPDF(order *entity.Order, writer io.Writer)
 
 	return pdf.Output(writer)
 }
+
+func (s *orderService) generateKitchenTicketPDF(ctx context.Context, order *entity.Order, station string, items []*entity.OrderItem, writer io.Writer) error {
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "mm",
+		SizeStr:        "",
+		Size: fpdf.SizeType{
+			Wd: 80,  // 80mm width
+			Ht: 200, // tickets list one course at a time
+		},
+	})
+	pdf.AddPage()
+
+	// Add Thai font
+	pdf.AddUTF8Font("NotoSansThai", "", `E:\h_lab\go\poc_pos_restaurant\font\NotoSansThai-Regular.ttf`)
+	pdf.AddUTF8Font("NotoSansThai", "B", `E:\h_lab\go\poc_pos_restaurant\font\NotoSansThai-Bold.ttf`)
+
+	pdf.SetLeftMargin(5)
+	pdf.SetRightMargin(5)
+
+	// Header
+	pdf.SetFont("NotoSansThai", "B", 12)
+	pdf.CellFormat(0, 6, kitchenTicketTitle(items), "", 1, "C", false, 0, "")
+	if station != "" {
+		pdf.SetFont("NotoSansThai", "", 9)
+		pdf.CellFormat(0, 5, station, "", 1, "C", false, 0, "")
+	}
+	pdf.Ln(2)
+
+	// Ticket info
+	pdf.SetFont("NotoSansThai", "", 8)
+	pdf.CellFormat(0, 5, fmt.Sprintf("เลขที่: %d", order.ID), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("เวลา: %s", time.Now().Format("02/01/2006 15:04")), "", 1, "L", false, 0, "")
+	writeOrderHeading(pdf, order)
+	if rounds := ticketRounds(items); rounds != "" {
+		pdf.CellFormat(0, 5, fmt.Sprintf("รอบที่: %s", rounds), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(2)
+	pdf.Line(0, pdf.GetY(), 80, pdf.GetY())
+	pdf.Ln(2)
+
+	// Items by course, then by seat within a course
+	bySeat := entity.HasSeats(items)
+	items = entity.SortBySeat(items)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Course < items[j].Course
+	})
+	course, seat := -1, -1
+	for _, item := range items {
+		if item.Course != course && item.Course > 0 {
+			pdf.SetFont("NotoSansThai", "B", 9)
+			pdf.CellFormat(0, 5, fmt.Sprintf("คอร์สที่ %d", item.Course), "", 1, "L", false, 0, "")
+		}
+		if bySeat && (item.Course != course || item.Seat != seat) {
+			pdf.SetFont("NotoSansThai", "B", 9)
+			pdf.CellFormat(0, 5, seatLabel(item.Seat), "", 1, "L", false, 0, "")
+		}
+		course, seat = item.Course, item.Seat
+
+		pdf.SetFont("NotoSansThai", "B", 10)
+		if item.IsVoided() {
+			pdf.CellFormat(0, 5, fmt.Sprintf("ยกเลิก %d x %s", item.Quantity, item.Name), "", 1, "L", false, 0, "")
+			pdf.SetFont("NotoSansThai", "", 8)
+			pdf.CellFormat(0, 4, fmt.Sprintf("    เหตุผล: %s", item.AdjustmentReason), "", 1, "L", false, 0, "")
+			pdf.Ln(1)
+			continue
+		}
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d x %s", item.Quantity, item.Name), "", 1, "L", false, 0, "")
+
+		pdf.SetFont("NotoSansThai", "", 8)
+		options, err := s.getItemOptions(ctx, item.ID)
+		if err == nil {
+			for _, opt := range options {
+				pdf.CellFormat(0, 4, fmt.Sprintf("    + %s: %s", opt.Option.Name, opt.Value.Name), "", 1, "L", false, 0, "")
+			}
+		}
+		if item.SpecialReq != "" {
+			pdf.MultiCell(0, 4, fmt.Sprintf("    หมายเหตุ: %s", item.SpecialReq), "", "L", false)
+		}
+		if item.KitchenNotes != "" {
+			pdf.MultiCell(0, 4, fmt.Sprintf("    ครัว: %s", item.KitchenNotes), "", "L", false)
+		}
+		pdf.Ln(1)
+	}
+
+	return pdf.Output(writer)
+}
+
+// kitchenTicketTitle heads a ticket that only cancels items as a
+// cancellation, so the kitchen does not start on them
+func kitchenTicketTitle(items []*entity.OrderItem) string {
+	for _, item := range items {
+		if !item.IsVoided() {
+			return "ใบสั่งครัว"
+		}
+	}
+	return "ยกเลิกรายการ"
+}
+
+// seatLabel heads the items of a seat on receipts and kitchen tickets
+func seatLabel(seat int) string {
+	if seat <= 0 {
+		return "ส่วนกลาง"
+	}
+	return fmt.Sprintf("ที่นั่ง %d", seat)
+}
+
+// ticketRounds lists the rounds the items on a kitchen ticket were submitted
+// in, or nothing for items ordered before rounds were numbered
+func ticketRounds(items []*entity.OrderItem) string {
+	var rounds []string
+	seen := make(map[int]bool)
+	for _, item := range items {
+		if item.Round > 0 && !seen[item.Round] {
+			seen[item.Round] = true
+			rounds = append(rounds, strconv.Itoa(item.Round))
+		}
+	}
+	return strings.Join(rounds, ", ")
+}
+
+// orderTypeLabels are the order types as printed on receipts
+var orderTypeLabels = map[vo.OrderType]string{
+	vo.OrderTypeDineIn:   "ทานที่ร้าน",
+	vo.OrderTypeTakeaway: "กลับบ้าน",
+	vo.OrderTypeDelivery: "เดลิเวอรี่",
+	vo.OrderTypePickup:   "รับที่ร้าน",
+}
+
+// writeOrderHeading prints who the order is for: its table, or for orders
+// without one the order type, queue number and customer details
+func writeOrderHeading(pdf *fpdf.Fpdf, order *entity.Order) {
+	if order.TableID > 0 {
+		pdf.CellFormat(0, 5, fmt.Sprintf("โต๊ะ: %d", order.TableID), "", 1, "L", false, 0, "")
+	}
+	if order.OrderType == vo.OrderTypeDineIn {
+		return
+	}
+
+	pdf.CellFormat(0, 5, fmt.Sprintf("ประเภท: %s", orderTypeLabels[order.OrderType]), "", 1, "L", false, 0, "")
+	if order.QueueNumber > 0 {
+		pdf.CellFormat(0, 5, fmt.Sprintf("คิว: %d", order.QueueNumber), "", 1, "L", false, 0, "")
+	}
+	if order.CustomerName != "" {
+		pdf.CellFormat(0, 5, fmt.Sprintf("ลูกค้า: %s", order.CustomerName), "", 1, "L", false, 0, "")
+	}
+	if order.CustomerPhone != "" {
+		pdf.CellFormat(0, 5, fmt.Sprintf("โทร: %s", order.CustomerPhone), "", 1, "L", false, 0, "")
+	}
+	if order.DeliveryAddress != "" {
+		pdf.MultiCell(0, 5, fmt.Sprintf("ที่อยู่: %s", order.DeliveryAddress), "", "L", false)
+	}
+}
+
 func generateOrderQRCodePDF(receipt *entity.Order, writer io.Writer) error {
 	pdf := fpdf.NewCustom(&fpdf.InitType{
 		OrientationStr: "P",
@@ -388,7 +804,11 @@ func generateOrderQRCodePDF(receipt *entity.Order, writer io.Writer) error {
 	pdf.SetFont("NotoSansThai", "B", 12)
 	pdf.CellFormat(0, 6, "Order QR Code", "", 1, "C", false, 0, "")
 	pdf.SetFont("NotoSansThai", "", 9)
-	pdf.CellFormat(0, 5, "โต๊ะ: "+fmt.Sprintf("%d", receipt.TableID), "", 1, "C", false, 0, "")
+	if receipt.TableID > 0 {
+		pdf.CellFormat(0, 5, "โต๊ะ: "+fmt.Sprintf("%d", receipt.TableID), "", 1, "C", false, 0, "")
+	} else {
+		pdf.CellFormat(0, 5, "คิว: "+fmt.Sprintf("%d", receipt.QueueNumber), "", 1, "C", false, 0, "")
+	}
 	pdf.CellFormat(0, 5, "วันที่: "+receipt.CreatedAt.Format("02/01/2006 15:04"), "", 1, "C", false, 0, "")
 	pdf.Ln(2)
 
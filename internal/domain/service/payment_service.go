@@ -48,29 +48,33 @@ func (s *paymentService) ValidatePayment(ctx context.Context, orderID int, amoun
 		return errs.ErrOrderNotFound
 	}
 
-	// Check if order is closed
-	if !order.IsClosed() {
-		return errs.ErrOrderNotClosed
+	// Payments may be taken against open orders, but not cancelled ones
+	if order.OrderStatus == vo.OrderCancelled {
+		return errs.ErrOrderCancelled
 	}
 
-	// Check if payment already exists
-	existingPayment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	// Check the payments taken so far
+	payments, err := s.paymentRepo.ListByOrderID(ctx, orderID)
 	if err != nil {
-		return fmt.Errorf("failed to check existing payment: %w", err)
-	}
-	if existingPayment != nil {
-		return errs.ErrPaymentAlreadyExists
+		return fmt.Errorf("failed to check existing payments: %w", err)
 	}
 
-	// Calculate and validate amount
+	// Calculate and validate amount against the outstanding balance
 	total, err := s.orderService.CalculateOrderTotal(ctx, order)
 	if err != nil {
 		return fmt.Errorf("failed to calculate order total: %w", err)
 	}
 
-	if amount.AmountBaht() != total.AmountBaht() {
+	settled := entity.SettledAmount(payments)
+	if settled.AmountSatang() >= total.AmountSatang() {
+		return errs.ErrOrderAlreadyPaid
+	}
+	if amount.IsZero() {
 		return errs.ErrInvalidPaymentAmount
 	}
+	if settled.AmountSatang()+amount.AmountSatang() > total.AmountSatang() {
+		return errs.ErrPaymentExceedsBalance
+	}
 
 	// Validate payment method
 	if !method.Valid() {
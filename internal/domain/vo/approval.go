@@ -0,0 +1,70 @@
+package vo
+
+import (
+	"strings"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// ApprovalAction is an order or payment change that needs a manager's sign-off
+type ApprovalAction string
+
+const (
+	ApprovalActionItemVoid    ApprovalAction = "item_void"    // remove or reduce an item the kitchen already started
+	ApprovalActionItemComp    ApprovalAction = "item_comp"    // give an item away at zero price
+	ApprovalActionDiscount    ApprovalAction = "discount"     // apply a manual discount to an item
+	ApprovalActionOrderReopen ApprovalAction = "order_reopen" // reopen a completed order
+	ApprovalActionRefund      ApprovalAction = "refund"       // refund a payment
+)
+
+func (a ApprovalAction) IsValid() bool {
+	switch a {
+	case ApprovalActionItemVoid, ApprovalActionItemComp, ApprovalActionDiscount, ApprovalActionOrderReopen, ApprovalActionRefund:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewApprovalAction(action string) (ApprovalAction, error) {
+	a := ApprovalAction(strings.ToLower(action))
+	if !a.IsValid() {
+		return "", errs.ErrInvalidApprovalAction
+	}
+	return a, nil
+}
+
+func (a ApprovalAction) String() string {
+	return string(a)
+}
+
+// ApprovalStatus tracks an approval from request to use
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+	ApprovalStatusUsed     ApprovalStatus = "used" // the approved change has been committed
+)
+
+func (s ApprovalStatus) IsValid() bool {
+	switch s {
+	case ApprovalStatusPending, ApprovalStatusApproved, ApprovalStatusRejected, ApprovalStatusUsed:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewApprovalStatus(status string) (ApprovalStatus, error) {
+	s := ApprovalStatus(strings.ToLower(status))
+	if !s.IsValid() {
+		return "", errs.ErrInvalidApprovalStatus
+	}
+	return s, nil
+}
+
+func (s ApprovalStatus) String() string {
+	return string(s)
+}
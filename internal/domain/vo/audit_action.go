@@ -0,0 +1,48 @@
+package vo
+
+import (
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// AuditAction identifies a sensitive action recorded in the audit log
+type AuditAction string
+
+const (
+	AuditActionOrderClose        AuditAction = "order.close"
+	AuditActionOrderItemDelete   AuditAction = "order_item.delete"
+	AuditActionMenuPriceChange   AuditAction = "menu_item.price_change"
+	AuditActionPaymentDelete     AuditAction = "payment.delete"
+	AuditActionOrderItemVoid     AuditAction = "order_item.void"
+	AuditActionOrderItemDiscount AuditAction = "order_item.discount"
+	AuditActionOrderReopen       AuditAction = "order.reopen"
+	AuditActionPaymentRefund     AuditAction = "payment.refund"
+	AuditActionOrderTransfer     AuditAction = "order.transfer"
+	AuditActionOrderMerge        AuditAction = "order.merge"
+	AuditActionOrderCoupon       AuditAction = "order.coupon"
+	AuditActionOrderItemComp     AuditAction = "order_item.comp"
+	AuditActionOrderGuests       AuditAction = "order.guests"
+)
+
+func (a AuditAction) Valid() bool {
+	switch a {
+	case AuditActionOrderClose, AuditActionOrderItemDelete, AuditActionMenuPriceChange, AuditActionPaymentDelete,
+		AuditActionOrderItemVoid, AuditActionOrderItemDiscount, AuditActionOrderReopen, AuditActionPaymentRefund,
+		AuditActionOrderTransfer, AuditActionOrderMerge, AuditActionOrderCoupon, AuditActionOrderItemComp,
+		AuditActionOrderGuests:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewAuditAction(action string) (AuditAction, error) {
+	a := AuditAction(action)
+	if !a.Valid() {
+		return "", errs.ErrInvalidAuditAction
+	}
+	return a, nil
+}
+
+func (a AuditAction) String() string {
+	return string(a)
+}
@@ -0,0 +1,36 @@
+package vo
+
+import (
+	"strings"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// ItemAdjustment is how a submitted order item was taken off the bill
+type ItemAdjustment string
+
+const (
+	ItemAdjustmentVoid ItemAdjustment = "void" // removed from the bill, the record kept
+	ItemAdjustmentComp ItemAdjustment = "comp" // kept on the bill at zero price
+)
+
+func (a ItemAdjustment) IsValid() bool {
+	switch a {
+	case ItemAdjustmentVoid, ItemAdjustmentComp:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewItemAdjustment(adjustment string) (ItemAdjustment, error) {
+	a := ItemAdjustment(strings.ToLower(adjustment))
+	if !a.IsValid() {
+		return "", errs.ErrInvalidItemAdjustment
+	}
+	return a, nil
+}
+
+func (a ItemAdjustment) String() string {
+	return string(a)
+}
@@ -7,6 +7,8 @@ import (
 type ItemStatus string
 
 const (
+	ItemStatusDraft     ItemStatus = "draft" // in the order's cart, not yet submitted in a round
+	ItemStatusHeld      ItemStatus = "held"  // waiting for its course to be fired; the kitchen does not see it
 	ItemStatusPending   ItemStatus = "pending"
 	ItemStatusPreparing ItemStatus = "preparing"
 	ItemStatusReady     ItemStatus = "ready"
@@ -16,7 +18,7 @@ const (
 
 func (s ItemStatus) IsValid() bool {
 	switch s {
-	case ItemStatusPending, ItemStatusPreparing, ItemStatusReady, ItemStatusServed, ItemStatusCancelled:
+	case ItemStatusDraft, ItemStatusHeld, ItemStatusPending, ItemStatusPreparing, ItemStatusReady, ItemStatusServed, ItemStatusCancelled:
 		return true
 	default:
 		return false
@@ -29,7 +31,7 @@ func (s ItemStatus) String() string {
 
 func NewItemStatus(status string) (ItemStatus, error) {
 	switch status {
-	case string(ItemStatusPending), string(ItemStatusPreparing), string(ItemStatusReady), string(ItemStatusServed), string(ItemStatusCancelled):
+	case string(ItemStatusDraft), string(ItemStatusHeld), string(ItemStatusPending), string(ItemStatusPreparing), string(ItemStatusReady), string(ItemStatusServed), string(ItemStatusCancelled):
 		return ItemStatus(status), nil
 	default:
 		return "", errs.ErrInvalidItemStatus
@@ -15,6 +15,14 @@ const (
 	OrderCancelled       OrderStatus = "cancelled"
 )
 
+// orderTransitions lists the statuses an order may move to from each status.
+// Cancelled is final; a completed order can only be reopened.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusOpen:      {OrderStatusOrdered, OrderStatusCompleted, OrderCancelled},
+	OrderStatusOrdered:   {OrderStatusOpen, OrderStatusCompleted, OrderCancelled},
+	OrderStatusCompleted: {OrderStatusOpen},
+}
+
 func (s OrderStatus) IsValid() bool {
 	switch s {
 	case OrderStatusOpen, OrderStatusOrdered, OrderStatusCompleted, OrderCancelled:
@@ -32,6 +40,16 @@ func NewOrderStatus(status string) (OrderStatus, error) {
 	return s, nil
 }
 
+// CanTransitionTo checks if the order lifecycle allows moving to the next status
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 func (s OrderStatus) String() string {
 	return string(s)
 }
@@ -0,0 +1,47 @@
+package vo
+
+import (
+	"strings"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// OrderType is how the customer receives their order
+type OrderType string
+
+const (
+	OrderTypeDineIn   OrderType = "dine_in"  // served at a table
+	OrderTypeTakeaway OrderType = "takeaway" // ordered and collected at the counter
+	OrderTypeDelivery OrderType = "delivery" // delivered to the customer's address
+	OrderTypePickup   OrderType = "pickup"   // ordered ahead and collected later
+)
+
+func (t OrderType) IsValid() bool {
+	switch t {
+	case OrderTypeDineIn, OrderTypeTakeaway, OrderTypeDelivery, OrderTypePickup:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewOrderType parses an order type, defaulting to dine-in when empty
+func NewOrderType(orderType string) (OrderType, error) {
+	if orderType == "" {
+		return OrderTypeDineIn, nil
+	}
+	t := OrderType(strings.ToLower(orderType))
+	if !t.IsValid() {
+		return "", errs.ErrInvalidOrderType
+	}
+	return t, nil
+}
+
+// RequiresTable checks if orders of this type are seated at a table
+func (t OrderType) RequiresTable() bool {
+	return t == OrderTypeDineIn
+}
+
+func (t OrderType) String() string {
+	return string(t)
+}
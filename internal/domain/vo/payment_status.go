@@ -9,15 +9,15 @@ import (
 type PaymentStatus string
 
 const (
-	PaymentStatusUnpaid PaymentStatus = "unpaid"
-	// PaymentStatusPartial  PaymentStatus = "partial"
+	PaymentStatusUnpaid   PaymentStatus = "unpaid"
+	PaymentStatusPartial  PaymentStatus = "partial"
 	PaymentStatusPaid     PaymentStatus = "paid"
 	PaymentStatusRefunded PaymentStatus = "refunded"
 )
 
 func (s PaymentStatus) IsValid() bool {
 	switch s {
-	case PaymentStatusUnpaid, PaymentStatusPaid, PaymentStatusRefunded:
+	case PaymentStatusUnpaid, PaymentStatusPartial, PaymentStatusPaid, PaymentStatusRefunded:
 		return true
 	default:
 		return false
@@ -42,6 +42,9 @@ func (s PaymentStatus) IsPaid() bool {
 func (s PaymentStatus) IsUnpaid() bool {
 	return s == PaymentStatusUnpaid
 }
+func (s PaymentStatus) IsPartial() bool {
+	return s == PaymentStatusPartial
+}
 func (s PaymentStatus) IsRefunded() bool {
 	return s == PaymentStatusRefunded
 }
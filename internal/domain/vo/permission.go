@@ -0,0 +1,126 @@
+package vo
+
+import (
+	"strings"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// Permission represents an action a staff member may perform
+type Permission string
+
+const (
+	PermMenuManage      Permission = "menu:manage"      // create/update/delete categories, menu items and options
+	PermTableRead       Permission = "table:read"       // view tables
+	PermTableManage     Permission = "table:manage"     // create/update/delete tables
+	PermOrderRead       Permission = "order:read"       // view orders
+	PermOrderManage     Permission = "order:manage"     // create orders, change items, close orders
+	PermKitchenAccess   Permission = "kitchen:access"   // kitchen queue and item status
+	PermKitchenManage   Permission = "kitchen:manage"   // create/update/delete kitchen stations
+	PermPaymentRead     Permission = "payment:read"     // view payments
+	PermPaymentManage   Permission = "payment:manage"   // take payments
+	PermRevenueRead     Permission = "revenue:read"     // revenue reports
+	PermUserManage      Permission = "user:manage"      // staff account administration
+	PermTerminalManage  Permission = "terminal:manage"  // register and revoke shared POS terminals
+	PermAuditRead       Permission = "audit:read"       // review the audit log of sensitive actions
+	PermApprovalGrant   Permission = "approval:grant"   // approve voids, discounts, reopens and refunds
+	PermAPIKeyManage    Permission = "api_key:manage"   // issue, rotate and revoke API keys for integrations
+	PermShiftManage     Permission = "shift:manage"     // review staff shifts and clock out forgotten shifts
+	PermPromotionManage Permission = "promotion:manage" // create/update/delete promotions and coupon codes
+)
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+// IsValid returns true if the permission is known, false otherwise
+func (p Permission) IsValid() bool {
+	for _, known := range allPermissions {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePermission parses a permission string and returns the corresponding Permission
+func ParsePermission(permission string) (Permission, error) {
+	p := Permission(strings.ToLower(strings.TrimSpace(permission)))
+	if !p.IsValid() {
+		return "", errs.ErrInvalidPermission
+	}
+	return p, nil
+}
+
+// IsAPIKeyScope reports whether the permission may be granted to an API key.
+// Staff, credential and approval administration stays with people.
+func (p Permission) IsAPIKeyScope() bool {
+	switch p {
+	case PermUserManage, PermTerminalManage, PermAPIKeyManage, PermApprovalGrant:
+		return false
+	default:
+		return p.IsValid()
+	}
+}
+
+// allPermissions is granted to roles with unrestricted access
+var allPermissions = []Permission{
+	PermMenuManage,
+	PermTableRead,
+	PermTableManage,
+	PermOrderRead,
+	PermOrderManage,
+	PermKitchenAccess,
+	PermKitchenManage,
+	PermPaymentRead,
+	PermPaymentManage,
+	PermRevenueRead,
+	PermUserManage,
+	PermTerminalManage,
+	PermAuditRead,
+	PermApprovalGrant,
+	PermAPIKeyManage,
+	PermShiftManage,
+	PermPromotionManage,
+}
+
+// rolePermissions is the permission matrix for restaurant roles
+var rolePermissions = map[UserRole][]Permission{
+	RoleAdmin: allPermissions,
+	RoleOwner: allPermissions,
+	RoleManager: {
+		PermMenuManage,
+		PermTableRead,
+		PermTableManage,
+		PermOrderRead,
+		PermOrderManage,
+		PermKitchenAccess,
+		PermKitchenManage,
+		PermPaymentRead,
+		PermPaymentManage,
+		PermRevenueRead,
+		PermUserManage,
+		PermTerminalManage,
+		PermAuditRead,
+		PermApprovalGrant,
+		PermShiftManage,
+		PermPromotionManage,
+	},
+	RoleCashier: {
+		PermTableRead,
+		PermOrderRead,
+		PermOrderManage,
+		PermPaymentRead,
+		PermPaymentManage,
+	},
+	RoleWaiter: {
+		PermTableRead,
+		PermOrderRead,
+		PermOrderManage,
+		PermKitchenAccess,
+	},
+	RoleChef: {
+		PermOrderRead,
+		PermKitchenAccess,
+	},
+}
@@ -0,0 +1,38 @@
+package vo
+
+import (
+	"strings"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// PromotionType is how a promotion takes money off the bill
+type PromotionType string
+
+const (
+	PromotionPercentage PromotionType = "percentage"   // a percentage off the eligible items
+	PromotionFixed      PromotionType = "fixed_amount" // a fixed amount off the eligible items
+	PromotionBuyXGetY   PromotionType = "buy_x_get_y"  // the cheapest of every group of eligible units free
+)
+
+func (t PromotionType) IsValid() bool {
+	switch t {
+	case PromotionPercentage, PromotionFixed, PromotionBuyXGetY:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewPromotionType parses a promotion type
+func NewPromotionType(promotionType string) (PromotionType, error) {
+	t := PromotionType(strings.ToLower(promotionType))
+	if !t.IsValid() {
+		return "", errs.ErrInvalidPromotionType
+	}
+	return t, nil
+}
+
+func (t PromotionType) String() string {
+	return string(t)
+}
@@ -0,0 +1,37 @@
+package vo
+
+import (
+	"strings"
+
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// SplitMode is how a bill is divided between the people paying it
+type SplitMode string
+
+const (
+	SplitModeEven   SplitMode = "even"   // evenly between N people
+	SplitModeItem   SplitMode = "item"   // each person pays for their own items
+	SplitModeCustom SplitMode = "custom" // custom amounts that add up to the balance
+)
+
+func (m SplitMode) IsValid() bool {
+	switch m {
+	case SplitModeEven, SplitModeItem, SplitModeCustom:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewSplitMode(mode string) (SplitMode, error) {
+	m := SplitMode(strings.ToLower(mode))
+	if !m.IsValid() {
+		return "", errs.ErrInvalidSplitMode
+	}
+	return m, nil
+}
+
+func (m SplitMode) String() string {
+	return string(m)
+}
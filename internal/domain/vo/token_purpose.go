@@ -0,0 +1,34 @@
+package vo
+
+import (
+	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
+)
+
+// TokenPurpose is what a single-use token mailed to a user allows
+type TokenPurpose string
+
+const (
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+)
+
+func (p TokenPurpose) IsValid() bool {
+	switch p {
+	case TokenPurposeEmailVerification, TokenPurposePasswordReset:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewTokenPurpose(purpose string) (TokenPurpose, error) {
+	p := TokenPurpose(purpose)
+	if !p.IsValid() {
+		return "", errs.ErrInvalidTokenPurpose
+	}
+	return p, nil
+}
+
+func (p TokenPurpose) String() string {
+	return string(p)
+}
@@ -6,48 +6,71 @@ import (
 	errs "github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/error"
 )
 
-// UserRole represents the user role
+// UserRole represents the role of a restaurant staff member
 type UserRole string
 
 const (
-	RoleCandidate UserRole = "candidate"
-	RoleCompanyHR UserRole = "company_hr"
-	RoleAdmin     UserRole = "admin"
+	RoleAdmin   UserRole = "admin"
+	RoleOwner   UserRole = "owner"
+	RoleManager UserRole = "manager"
+	RoleCashier UserRole = "cashier"
+	RoleWaiter  UserRole = "waiter"
+	RoleChef    UserRole = "chef"
 )
 
 func (role UserRole) String() string {
-	switch role {
-	case RoleCandidate:
-		return "candidate"
-	case RoleCompanyHR:
-		return "company_hr"
-	case RoleAdmin:
-		return "admin"
-	default:
+	if !role.IsValid() {
 		return "unknown"
 	}
+	return string(role)
 }
 
 // ParseUserRole parses a user role string and returns the corresponding UserRole
 func ParseUserRole(role string) (UserRole, error) {
-	switch strings.ToLower(role) {
-	case string(RoleCandidate):
-		return RoleCandidate, nil
-	case string(RoleCompanyHR):
-		return RoleCompanyHR, nil
-	case string(RoleAdmin):
-		return RoleAdmin, nil
-	default:
+	r := UserRole(strings.ToLower(role))
+	if !r.IsValid() {
 		return "", errs.ErrInvalidUserRole
 	}
+	return r, nil
 }
 
 // IsValid returns true if the user role is valid, false otherwise
 func (r UserRole) IsValid() bool {
 	switch r {
-	case RoleCandidate, RoleCompanyHR, RoleAdmin:
+	case RoleAdmin, RoleOwner, RoleManager, RoleCashier, RoleWaiter, RoleChef:
 		return true
 	default:
 		return false
 	}
 }
+
+// roleRanks orders roles by seniority; staff may only administer accounts
+// ranked below their own
+var roleRanks = map[UserRole]int{
+	RoleAdmin:   4,
+	RoleOwner:   3,
+	RoleManager: 2,
+	RoleCashier: 1,
+	RoleWaiter:  1,
+	RoleChef:    1,
+}
+
+// Outranks reports whether the role is strictly more senior than the other
+func (r UserRole) Outranks(other UserRole) bool {
+	return roleRanks[r] > roleRanks[other]
+}
+
+// HasPermission reports whether the role is granted the permission
+func (r UserRole) HasPermission(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns the permissions granted to the role
+func (r UserRole) Permissions() []Permission {
+	return rolePermissions[r]
+}
@@ -43,9 +43,10 @@ func NewFiber(config ServerConfig) *FiberApp {
 	})
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*", // Allow all origins
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
+		AllowOrigins:  "*", // Allow all origins
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-Order-Token, X-API-Key, If-Match, Idempotency-Key",
+		AllowMethods:  "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
+		ExposeHeaders: "ETag, Idempotent-Replayed",
 	}))
 	// Add middlewares
 	app.Use(fb_logger.New())
@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+	"github.com/redis/go-redis/v9"
+)
+
+// claimScript stores the in-flight record unless the key already holds one,
+// in which case it returns the record held
+var claimScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return false
+end
+return redis.call("GET", KEYS[1])
+`)
+
+type redisIdempotencyStore struct {
+	client *redis.Client
+}
+
+// NewRedisIdempotencyStore creates an idempotency key store on the Redis client
+func NewRedisIdempotencyStore(client *RedisClient) infra.IdempotencyStore {
+	return &redisIdempotencyStore{client: client.client}
+}
+
+// Claim marks key as in flight unless it already holds a record
+func (r *redisIdempotencyStore) Claim(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (*infra.IdempotencyRecord, error) {
+	data, err := json.Marshal(&infra.IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	held, err := claimScript.Run(ctx, r.client, []string{key}, data, lockTimeout.Milliseconds()).Text()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	var record infra.IdempotencyRecord
+	if err := json.Unmarshal([]byte(held), &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+	}
+	return &record, nil
+}
+
+// Complete stores the response for key
+func (r *redisIdempotencyStore) Complete(ctx context.Context, key string, record *infra.IdempotencyRecord, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+	return r.client.Set(ctx, key, data, ttl).Err()
+}
+
+// Release forgets key
+func (r *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
@@ -0,0 +1,86 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+)
+
+// JWTService implements infra.TokenService using HMAC-signed JWTs
+type JWTService struct {
+	secret           []byte
+	accessExpiration time.Duration
+}
+
+type accessTokenClaims struct {
+	Role       string `json:"role"`
+	SessionID  string `json:"sid"`
+	TerminalID int    `json:"tid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// NewJWTService creates a new JWT service
+func NewJWTService(secret string, accessExpiration time.Duration) *JWTService {
+	return &JWTService{
+		secret:           []byte(secret),
+		accessExpiration: accessExpiration,
+	}
+}
+
+// GenerateAccessToken signs a new access token for the given claims
+func (s *JWTService) GenerateAccessToken(claims *infra.TokenClaims) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.accessExpiration)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims{
+		Role:       claims.Role,
+		SessionID:  claims.SessionID,
+		TerminalID: claims.TerminalID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(claims.UserID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(s.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseAccessToken verifies an access token and returns its claims
+func (s *JWTService) ParseAccessToken(tokenString string) (*infra.TokenClaims, error) {
+	var claims accessTokenClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse access token: %w", err)
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || userID <= 0 {
+		return nil, errors.New("invalid token subject")
+	}
+
+	result := &infra.TokenClaims{
+		UserID:     userID,
+		Role:       claims.Role,
+		SessionID:  claims.SessionID,
+		TerminalID: claims.TerminalID,
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return result, nil
+}
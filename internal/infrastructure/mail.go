@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+)
+
+// MailConfig selects and configures the mail sender
+type MailConfig struct {
+	Driver string // "log" or "file"
+	From   string
+	Dir    string // output directory for the file driver
+}
+
+// NewMailSender creates the mail sender selected by the config
+func NewMailSender(cfg MailConfig, logger infra.Logger) (infra.MailSender, error) {
+	switch cfg.Driver {
+	case "", "log":
+		return NewLogMailSender(cfg.From, logger), nil
+	case "file":
+		return NewFileMailSender(cfg.From, cfg.Dir)
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
+
+// logMailSender writes emails to the application log, for development
+type logMailSender struct {
+	from   string
+	logger infra.Logger
+}
+
+// NewLogMailSender creates a mail sender that logs every email
+func NewLogMailSender(from string, logger infra.Logger) infra.MailSender {
+	return &logMailSender{from: from, logger: logger}
+}
+
+func (s *logMailSender) Send(ctx context.Context, message *infra.MailMessage) error {
+	s.logger.Info("Email sent", "from", s.from, "to", message.To, "subject", message.Subject, "body", message.Body)
+	return nil
+}
+
+// fileMailSender writes each email to its own .eml file, for development
+type fileMailSender struct {
+	from string
+	dir  string
+}
+
+// NewFileMailSender creates a mail sender that writes emails into dir
+func NewFileMailSender(from, dir string) (infra.MailSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &fileMailSender{from: from, dir: dir}, nil
+}
+
+func (s *fileMailSender) Send(ctx context.Context, message *infra.MailMessage) error {
+	now := time.Now()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(message.Body)
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405"), uuid.NewString())
+	if err := os.WriteFile(filepath.Join(s.dir, name), []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
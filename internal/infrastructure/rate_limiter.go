@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hydr0g3nz/poc_pos_restuarant/internal/domain/infra"
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript keeps one sorted-set member per hit scored by its time
+// in milliseconds, drops hits older than the window and records the new hit
+// only while the key is under its limit. It returns whether the hit was
+// allowed, the hits in the window and the milliseconds until the oldest hit
+// leaves the window.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local member = ARGV[4]
+local record = ARGV[5] == "1"
+
+redis.call("ZREMRANGEBYSCORE", key, 0, now - window)
+local count = redis.call("ZCARD", key)
+local allowed = count < limit
+if allowed and record then
+	redis.call("ZADD", key, now, member)
+	redis.call("PEXPIRE", key, window)
+	count = count + 1
+end
+
+local retry = 0
+if count >= limit then
+	local oldest = redis.call("ZRANGE", key, 0, 0, "WITHSCORES")
+	if #oldest > 0 then
+		retry = tonumber(oldest[2]) + window - now
+	end
+end
+
+return {allowed and 1 or 0, count, retry}
+`)
+
+type redisRateLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisRateLimiter creates a sliding-window rate limiter on the Redis client
+func NewRedisRateLimiter(client *RedisClient) infra.RateLimiter {
+	return &redisRateLimiter{client: client.client}
+}
+
+// Allow records a hit for key unless it already reached limit hits within the window
+func (r *redisRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (*infra.RateLimitResult, error) {
+	return r.run(ctx, key, limit, window, true)
+}
+
+// Peek reports the state of key without recording a hit
+func (r *redisRateLimiter) Peek(ctx context.Context, key string, limit int, window time.Duration) (*infra.RateLimitResult, error) {
+	return r.run(ctx, key, limit, window, false)
+}
+
+// Reset forgets all hits recorded for key
+func (r *redisRateLimiter) Reset(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
+
+func (r *redisRateLimiter) run(ctx context.Context, key string, limit int, window time.Duration, record bool) (*infra.RateLimitResult, error) {
+	recordArg := "0"
+	if record {
+		recordArg = "1"
+	}
+
+	values, err := slidingWindowScript.Run(ctx, r.client, []string{key},
+		time.Now().UnixMilli(), window.Milliseconds(), limit, uuid.NewString(), recordArg,
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+
+	remaining := limit - int(values[1])
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &infra.RateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  remaining,
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
@@ -65,6 +65,15 @@ func (r *RedisClient) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// Exists checks whether a key exists
+func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check key: %w", err)
+	}
+	return n > 0, nil
+}
+
 // HashSet stores a hash field
 func (r *RedisClient) HashSet(ctx context.Context, key, field string, value interface{}) error {
 	data, err := json.Marshal(value)